@@ -8,8 +8,12 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/feegrant"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
 	"github.com/Oneledger/protocol/serialize"
 )
 
@@ -41,6 +45,8 @@ type Fee struct {
 type Signature struct {
 	Signer keys.PublicKey
 	Signed []byte
+	// MultiSig is the signature bundle of a multisig account signer, it is set instead of Signer and Signed
+	MultiSig *keys.MultiSig `json:",omitempty"`
 }
 
 type TxTypeDescribe struct {
@@ -56,11 +62,29 @@ func (s Signature) Verify(msg []byte) bool {
 	return handler.VerifyBytes(msg, s.Signed)
 }
 
+// Address returns the address of the signer, the address of the multisig account for a signature bundle
+func (s Signature) Address() (keys.Address, error) {
+	if s.MultiSig != nil {
+		return multisig.AccountAddress(s.MultiSig.M, s.MultiSig.Signers), nil
+	}
+	h, err := s.Signer.GetHandler()
+	if err != nil {
+		return nil, err
+	}
+	return h.Address(), nil
+}
+
 type RawTx struct {
 	Type Type    `json:"type"`
 	Data MsgData `json:"data"`
 	Fee  Fee     `json:"fee"`
 	Memo string  `json:"memo"`
+	// Nonce is the sequence of the signer account, left out of the encoding when zero so the
+	// transactions created before the nonce update keep their bytes
+	Nonce uint64 `json:"nonce,omitempty"`
+	// FeePayer pays the fee instead of the first signer, it either signs the transaction too or pays from the
+	// allowance it granted to the first signer
+	FeePayer Address `json:"feePayer,omitempty"`
 }
 
 func (t *RawTx) RawBytes() []byte {
@@ -74,6 +98,8 @@ func (t *RawTx) RawBytes() []byte {
 type SignedTx struct {
 	RawTx
 	Signatures []Signature `json:"signatures"`
+	// FeePayerSignature is the signature of the fee payer, if it sponsors the transaction without an allowance
+	FeePayerSignature *Signature `json:"feePayerSignature,omitempty"`
 }
 
 func (t *SignedTx) SignedBytes() []byte {
@@ -84,11 +110,24 @@ func (t *SignedTx) SignedBytes() []byte {
 	return value
 }
 
-func ValidateBasic(data []byte, signerAddr []Address, signatures []Signature) error {
+// ValidateBasic checks the transaction is signed by its signers, a simulation accepts the transaction without
+// signatures since the gas doesn't depend on them, and so does a message of a batch which is signed by the batch
+func ValidateBasic(ctx *Context, data []byte, signerAddr []Address, signatures []Signature) error {
+	if ctx != nil && (ctx.Simulation || ctx.InBatch) && len(signatures) == 0 {
+		return nil
+	}
 	if len(signatures) != len(signerAddr) {
 		return ErrUnmatchSigner
 	}
 	for i, s := range signerAddr {
+		if signatures[i].MultiSig != nil {
+			err := ValidateMultiSig(ctx, data, s, *signatures[i].MultiSig)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		pkey := signatures[i].Signer
 		h, err := pkey.GetHandler()
 		if err != nil {
@@ -106,19 +145,83 @@ func ValidateBasic(data []byte, signerAddr []Address, signatures []Signature) er
 	return nil
 }
 
-func ValidateFee(feeOpt *fees.FeeOption, fee Fee) error {
-	if fee.Price.Currency != feeOpt.FeeCurrency.Name {
+// isUnsignedSimulation tells the fee can't be charged, the payer is the first signer and the simulated transaction
+// has none. The gas is measured before the charge so skipping it doesn't change the gas used.
+func isUnsignedSimulation(ctx *Context, signedTx SignedTx) bool {
+	return ctx.Simulation && len(signedTx.Signatures) == 0
+}
+
+// ValidateFee checks the gas price of the transaction is in the fee currency and not below the minimum fee, the
+// base fee of the block when the fee market is enabled
+func ValidateFee(feePool *fees.Store, fee Fee) error {
+	if fee.Price.Currency != feePool.GetOpt().FeeCurrency.Name {
 		return ErrInvalidFeeCurrency
 	}
-	minFee := feeOpt.MinFee()
+	minFee := feePool.MinFee()
 	if minFee.Amount.BigInt().Cmp(fee.Price.Value.BigInt()) > 0 {
 		return ErrInvalidFeePrice
 	}
 	return nil
 }
 
+// ValidateFeePayer checks the fee payer of the transaction, if any, either signed the transaction or granted an
+// allowance to the first signer which pays for the transaction
+func ValidateFeePayer(ctx *Context, tx SignedTx) error {
+	if len(tx.FeePayer) == 0 {
+		if tx.FeePayerSignature != nil {
+			return errors.Wrap(feegrant.ErrInvalidFeePayer, "signature without fee payer")
+		}
+		return nil
+	}
+	if !ctx.IsForkActive(config.FeeGrantFork) {
+		return errors.Wrap(feegrant.ErrInvalidFeePayer, "fee payer not supported yet")
+	}
+	if err := tx.FeePayer.Err(); err != nil {
+		return errors.Wrap(feegrant.ErrInvalidFeePayer, err.Error())
+	}
+
+	if tx.FeePayerSignature != nil {
+		return ValidateBasic(ctx, tx.RawBytes(), []Address{tx.FeePayer}, []Signature{*tx.FeePayerSignature})
+	}
+
+	grantee, err := NonceSigner(tx)
+	if err != nil {
+		if ctx.Simulation {
+			return nil
+		}
+		return err
+	}
+	allowance, err := ctx.FeeGrants.Get(tx.FeePayer, grantee)
+	if err != nil {
+		return err
+	}
+	// the spend limit is checked against the fee charged once the gas used is known
+	return allowance.Check(int(tx.Type), balance.NewAmount(0), ctx.Header.Height)
+}
+
+// chargeFee takes the fee from the fee payer of the transaction if it is set, from the address otherwise. A fee
+// payer who didn't sign the transaction pays from the allowance it granted to the nonce signer, the grantee checked
+// by ValidateFeePayer.
+func chargeFee(ctx *Context, signedTx SignedTx, addr keys.Address, charge balance.Coin) error {
+	payer := addr
+	if len(signedTx.FeePayer) != 0 {
+		payer = signedTx.FeePayer
+		if signedTx.FeePayerSignature == nil {
+			grantee, err := NonceSigner(signedTx)
+			if err != nil {
+				return err
+			}
+			err = ctx.FeeGrants.UseAllowance(payer, grantee, int(signedTx.Type), charge.Amount, ctx.Header.Height)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return ctx.Balances.MinusFromAddress(payer, charge)
+}
+
 func StakingPayerFeeHandling(ctx *Context, feePayer keys.Address, signedTx SignedTx, start Gas, size Gas, signatureCnt Gas) (bool, Response) {
-	ctx.State.ConsumeVerifySigGas(signatureCnt)
+	ctx.State.ConsumeVerifySigGas(signatureCnt + bundleSignatures(signedTx))
 	ctx.State.ConsumeStorageGas(size)
 	// check the used gas for the tx
 	final := ctx.Balances.State.ConsumedGas()
@@ -126,13 +229,14 @@ func StakingPayerFeeHandling(ctx *Context, feePayer keys.Address, signedTx Signe
 	if used > signedTx.Fee.Gas {
 		return false, Response{Log: ErrGasOverflow.Error(), GasWanted: signedTx.Fee.Gas, GasUsed: signedTx.Fee.Gas}
 	}
+	if isUnsignedSimulation(ctx, signedTx) {
+		return true, Response{GasWanted: signedTx.Fee.Gas, GasUsed: used}
+	}
 
-	signer := signedTx.Signatures[0].Signer
-	h, err := signer.GetHandler()
+	addr, err := signedTx.Signatures[0].Address()
 	if err != nil {
 		return false, Response{Log: err.Error()}
 	}
-	addr := h.Address()
 
 	val, err := ctx.Validators.Get(addr)
 	if err != nil {
@@ -144,11 +248,11 @@ func StakingPayerFeeHandling(ctx *Context, feePayer keys.Address, signedTx Signe
 	}
 
 	charge := signedTx.Fee.Price.ToCoin(ctx.Currencies).MultiplyInt64(int64(used))
-	err = ctx.Balances.MinusFromAddress(val.StakeAddress, charge)
+	err = chargeFee(ctx, signedTx, val.StakeAddress, charge)
 	if err != nil {
 		return false, Response{Log: errors.Wrap(err, "charge fee").Error()}
 	}
-	err = ctx.FeePool.AddToPool(charge)
+	err = ctx.FeePool.AddFee(charge, used)
 	if err != nil {
 		return false, Response{Log: err.Error()}
 	}
@@ -172,7 +276,7 @@ func ContractFeeHandling(ctx *Context, signedTx SignedTx, gasUsed Gas, start Gas
 
 	charge := signedTx.Fee.Price.ToCoin(ctx.Currencies).MultiplyInt(int(gasUsed))
 
-	err := ctx.FeePool.AddToPool(charge)
+	err := ctx.FeePool.AddFee(charge, int64(gasUsed))
 	if err != nil {
 		return false, Response{Log: ErrInvalidVmExecution.Wrap(err).Marshal(), GasWanted: signedTx.Fee.Gas}
 	}
@@ -182,7 +286,7 @@ func ContractFeeHandling(ctx *Context, signedTx SignedTx, gasUsed Gas, start Gas
 }
 
 func BasicFeeHandling(ctx *Context, signedTx SignedTx, start Gas, size Gas, signatureCnt Gas) (bool, Response) {
-	ctx.State.ConsumeVerifySigGas(signatureCnt)
+	ctx.State.ConsumeVerifySigGas(signatureCnt + bundleSignatures(signedTx))
 	ctx.State.ConsumeStorageGas(size)
 	// check the used gas for the tx
 	final := ctx.Balances.State.ConsumedGas()
@@ -190,21 +294,22 @@ func BasicFeeHandling(ctx *Context, signedTx SignedTx, start Gas, size Gas, sign
 	if used > signedTx.Fee.Gas {
 		return false, Response{Log: ErrGasOverflow.Error(), GasWanted: signedTx.Fee.Gas, GasUsed: signedTx.Fee.Gas}
 	}
+	if isUnsignedSimulation(ctx, signedTx) {
+		return true, Response{GasWanted: signedTx.Fee.Gas, GasUsed: used}
+	}
 
-	// only charge the first signer for now
-	signer := signedTx.Signatures[0].Signer
-	h, err := signer.GetHandler()
+	// charge the first signer, unless a fee payer is set
+	addr, err := signedTx.Signatures[0].Address()
 	if err != nil {
 		return false, Response{Log: err.Error()}
 	}
-	addr := h.Address()
 
 	charge := signedTx.Fee.Price.ToCoin(ctx.Currencies).MultiplyInt64(int64(used))
-	err = ctx.Balances.MinusFromAddress(addr, charge)
+	err = chargeFee(ctx, signedTx, addr, charge)
 	if err != nil {
 		return false, Response{Log: errors.Wrap(err, "charge fee").Error()}
 	}
-	err = ctx.FeePool.AddToPool(charge)
+	err = ctx.FeePool.AddFee(charge, used)
 	if err != nil {
 		return false, Response{Log: err.Error()}
 	}
@@ -0,0 +1,187 @@
+package action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/feegrant"
+	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/identity"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestValidateBasic_Simulation(t *testing.T) {
+	tx, addr := assemblyNonceTx(SEND, 0)
+	signers := []Address{addr}
+
+	assert.NoError(t, ValidateBasic(nil, tx.RawBytes(), signers, tx.Signatures))
+	assert.Equal(t, ErrUnmatchSigner, ValidateBasic(nil, tx.RawBytes(), signers, nil))
+
+	// the simulation accepts the unsigned transaction only
+	ctx := &Context{Simulation: true}
+	assert.NoError(t, ValidateBasic(ctx, tx.RawBytes(), signers, nil))
+
+	tx.Signatures[0].Signed = []byte("wrong")
+	assert.Equal(t, ErrInvalidSignature, ValidateBasic(ctx, tx.RawBytes(), signers, tx.Signatures))
+}
+
+func TestBasicFeeHandling_FeePayer(t *testing.T) {
+	olt := balance.Currency{Name: "OLT", Decimal: 18, Unit: "nue"}
+	currencies := balance.NewCurrencySet()
+	require.NoError(t, currencies.Register(olt))
+
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	state = state.WithGas(storage.NewGasCalculator(1000000))
+	feePool := fees.NewStore("f", state)
+	feePool.SetupOpt(&fees.FeeOption{FeeCurrency: olt, MinFeeDecimal: 9})
+	ctx := &Context{
+		State:      state,
+		Header:     &abci.Header{Height: 10},
+		Balances:   balance.NewStore("b", state),
+		Currencies: currencies,
+		FeePool:    feePool,
+		FeeGrants:  feegrant.NewStore("fg", state),
+		Forks:      &config.ForkParams{Forks: []config.ForkHeight{{Name: config.FeeGrantFork, Height: 1}}},
+	}
+
+	granterKey := ed25519.GenPrivKey()
+	granter := keys.Address(granterKey.PubKey().Address())
+	require.NoError(t, ctx.Balances.AddToAddress(granter, olt.NewCoinFromInt(1)))
+
+	tx, grantee := assemblyNonceTx(SEND, 0)
+	tx.Fee = Fee{Price: Amount{Currency: "OLT", Value: *balance.NewAmount(1000000000)}, Gas: 100000}
+	tx.FeePayer = granter
+
+	assert.Equal(t, feegrant.ErrAllowanceNotFound, ValidateFeePayer(ctx, tx))
+	ok, _ := BasicFeeHandling(ctx, tx, 0, 10, 1)
+	assert.False(t, ok)
+
+	limit := balance.NewAmount(1000000000000000)
+	require.NoError(t, ctx.FeeGrants.Set(&feegrant.Allowance{Granter: granter, Grantee: grantee, SpendLimit: limit}))
+	assert.NoError(t, ValidateFeePayer(ctx, tx))
+
+	ok, resp := BasicFeeHandling(ctx, tx, 0, 10, 1)
+	require.True(t, ok, resp.Log)
+	charge := balance.NewAmount(1000000000 * resp.GasUsed)
+
+	// the fee is taken from the granter and its allowance
+	coin, err := ctx.Balances.GetBalanceForCurr(granter, &olt)
+	require.NoError(t, err)
+	left, _ := olt.NewCoinFromInt(1).Amount.Minus(*charge)
+	assert.Equal(t, left, coin.Amount)
+	allowance, err := ctx.FeeGrants.Get(granter, grantee)
+	require.NoError(t, err)
+	left, _ = limit.Minus(*charge)
+	assert.Equal(t, left, allowance.SpendLimit)
+
+	// a fee payer signing the transaction doesn't need an allowance
+	require.NoError(t, ctx.FeeGrants.Delete(granter, grantee))
+	signature, err := granterKey.Sign(tx.RawBytes())
+	require.NoError(t, err)
+	tx.FeePayerSignature = &Signature{
+		Signer: keys.PublicKey{KeyType: keys.ED25519, Data: granterKey.PubKey().Bytes()[5:]},
+		Signed: signature,
+	}
+	assert.NoError(t, ValidateFeePayer(ctx, tx))
+	ok, resp = BasicFeeHandling(ctx, tx, 0, 10, 1)
+	assert.True(t, ok, resp.Log)
+}
+
+func TestValidateBasic_MultiSig(t *testing.T) {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	ctx := &Context{MultiSigAccounts: multisig.NewStore("msa", state)}
+
+	privKeys := []ed25519.PrivKeyEd25519{ed25519.GenPrivKey(), ed25519.GenPrivKey(), ed25519.GenPrivKey()}
+	members := make([]keys.Address, len(privKeys))
+	for i, k := range privKeys {
+		members[i] = keys.Address(k.PubKey().Address())
+	}
+	account, err := multisig.NewAccount(2, members)
+	require.NoError(t, err)
+
+	tx := RawTx{Type: SEND, Data: []byte("send"), Memo: "multisig"}
+	msg := tx.RawBytes()
+	bundle, err := account.NewBundle(msg)
+	require.NoError(t, err)
+	for _, k := range privKeys[:2] {
+		pub, err := keys.PubKeyFromTendermint(k.PubKey().Bytes())
+		require.NoError(t, err)
+		index, err := bundle.GetSignerIndex(keys.Address(k.PubKey().Address()))
+		require.NoError(t, err)
+		signed, err := k.Sign(msg)
+		require.NoError(t, err)
+		require.NoError(t, bundle.AddSignature(keys.Signature{Index: index, PubKey: pub, Signed: signed}))
+	}
+	bundle.Msg = nil
+	signatures := []Signature{{MultiSig: bundle}}
+
+	// the account must be registered before it can sign
+	assert.Error(t, ValidateBasic(ctx, msg, []Address{account.Address}, signatures))
+	require.NoError(t, ctx.MultiSigAccounts.Set(account))
+	assert.NoError(t, ValidateBasic(ctx, msg, []Address{account.Address}, signatures))
+
+	signer, err := signatures[0].Address()
+	require.NoError(t, err)
+	assert.Equal(t, account.Address, signer)
+
+	// the signer is charged for a signature, the second signature of the bundle is charged on top
+	assert.Equal(t, Gas(1), bundleSignatures(SignedTx{RawTx: tx, Signatures: signatures}))
+
+	// a bundle under the threshold is refused
+	index, err := bundle.GetSignerIndex(members[0])
+	require.NoError(t, err)
+	bundle.Signatures[index] = keys.Signature{}
+	assert.Error(t, ValidateBasic(ctx, msg, []Address{account.Address}, signatures))
+}
+
+func TestStakingPayerFeeHandling_FeePayer(t *testing.T) {
+	olt := balance.Currency{Name: "OLT", Decimal: 18, Unit: "nue"}
+	currencies := balance.NewCurrencySet()
+	require.NoError(t, currencies.Register(olt))
+
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	state = state.WithGas(storage.NewGasCalculator(1000000))
+	feePool := fees.NewStore("f", state)
+	feePool.SetupOpt(&fees.FeeOption{FeeCurrency: olt, MinFeeDecimal: 9})
+	ctx := &Context{
+		State:      state,
+		Header:     &abci.Header{Height: 10},
+		Balances:   balance.NewStore("b", state),
+		Currencies: currencies,
+		FeePool:    feePool,
+		FeeGrants:  feegrant.NewStore("fg", state),
+		Validators: identity.NewValidatorStore("v", "purge", state),
+		Forks:      &config.ForkParams{Forks: []config.ForkHeight{{Name: config.FeeGrantFork, Height: 1}}},
+	}
+
+	granter := keys.Address(ed25519.GenPrivKey().PubKey().Address())
+	require.NoError(t, ctx.Balances.AddToAddress(granter, olt.NewCoinFromInt(1)))
+
+	// the validator signs the transaction, its stake address pays the fee unless a fee payer is set
+	tx, validator := assemblyNonceTx(ALLEGATION, 0)
+	tx.Fee = Fee{Price: Amount{Currency: "OLT", Value: *balance.NewAmount(1000000000)}, Gas: 100000}
+	tx.FeePayer = granter
+	stakeAddress := keys.Address(ed25519.GenPrivKey().PubKey().Address())
+	require.NoError(t, ctx.Validators.Set(identity.Validator{Address: validator, StakeAddress: stakeAddress}))
+
+	// the allowance is granted to the signer, as checked by ValidateFeePayer
+	limit := balance.NewAmount(1000000000000000)
+	require.NoError(t, ctx.FeeGrants.Set(&feegrant.Allowance{Granter: granter, Grantee: validator, SpendLimit: limit}))
+	assert.NoError(t, ValidateFeePayer(ctx, tx))
+
+	ok, resp := StakingPayerFeeHandling(ctx, validator, tx, 0, 10, 1)
+	require.True(t, ok, resp.Log)
+	allowance, err := ctx.FeeGrants.Get(granter, validator)
+	require.NoError(t, err)
+	left, _ := limit.Minus(*balance.NewAmount(1000000000 * resp.GasUsed))
+	assert.Equal(t, left, allowance.SpendLimit)
+}
@@ -0,0 +1,197 @@
+package batch
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/config"
+)
+
+var _ action.Msg = &Batch{}
+
+// Message is a transaction of the batch, without its own fee and signatures
+type Message struct {
+	Type action.Type    `json:"type"`
+	Data action.MsgData `json:"data"`
+}
+
+// Batch runs its messages in order as a single transaction: it is signed once by all the signers of the messages,
+// the fee is charged once to the first signer and the messages are either all applied or none is.
+type Batch struct {
+	Messages []Message `json:"messages"`
+}
+
+func (b Batch) Marshal() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+func (b *Batch) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, b)
+}
+
+// Signers returns the signers of all the messages in the order they first appear
+func (b Batch) Signers() []action.Address {
+	signers := make([]action.Address, 0)
+	seen := make(map[string]bool)
+	for _, m := range b.Messages {
+		msg, err := action.NewMsg(m.Type)
+		if err != nil {
+			continue
+		}
+		if err := msg.Unmarshal(m.Data); err != nil {
+			continue
+		}
+		for _, signer := range msg.Signers() {
+			if seen[signer.String()] {
+				continue
+			}
+			seen[signer.String()] = true
+			signers = append(signers, signer)
+		}
+	}
+	return signers
+}
+
+func (b Batch) Type() action.Type {
+	return action.BATCH
+}
+
+func (b Batch) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(b.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.messages"),
+		Value: []byte(strconv.Itoa(len(b.Messages))),
+	}
+
+	tags = append(tags, tag, tag2)
+	return tags
+}
+
+// rawTx returns the transaction of the message, with the fee and the memo of the batch
+func (m Message) rawTx(batchTx action.RawTx) action.RawTx {
+	return action.RawTx{
+		Type: m.Type,
+		Data: m.Data,
+		Fee:  batchTx.Fee,
+		Memo: batchTx.Memo,
+	}
+}
+
+var _ action.Tx = batchTx{}
+
+type batchTx struct{}
+
+func (batchTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	b := &Batch{}
+	err := b.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+	if len(b.Messages) == 0 {
+		return false, errors.Wrap(action.ErrInvalidBatch, "no message")
+	}
+
+	// the messages are checked before the signers, the signers of a message which can't be decoded are unknown
+	msgCtx := *ctx
+	msgCtx.InBatch = true
+	for i, m := range b.Messages {
+		msg, err := action.NewMsg(m.Type)
+		if err != nil {
+			return false, errors.Wrapf(action.ErrInvalidBatch, "message %d: type %s can't be batched", i, m.Type)
+		}
+		if err := msg.Unmarshal(m.Data); err != nil {
+			return false, errors.Wrapf(action.ErrInvalidBatch, "message %d: %s", i, err)
+		}
+		_, err = ctx.Router.Handler(m.Type).Validate(&msgCtx, action.SignedTx{RawTx: m.rawTx(tx.RawTx)})
+		if err != nil {
+			return false, errors.Wrapf(err, "message %d", i)
+		}
+	}
+
+	err = action.ValidateBasic(ctx, tx.RawBytes(), b.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (batchTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Detail("Processing Batch Transaction for CheckTx", tx)
+	return runBatch(ctx, tx, action.Tx.ProcessCheck)
+}
+
+func (batchTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Detail("Processing Batch Transaction for DeliverTx", tx)
+	return runBatch(ctx, tx, action.Tx.ProcessDeliver)
+}
+
+// ProcessFee charges the fee once for the whole batch, with a signature verified for every signer of the messages
+func (batchTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	b := &Batch{}
+	err := b.Unmarshal(signedTx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	return action.BasicFeeHandling(ctx, signedTx, start, size, action.Gas(len(b.Signers())))
+}
+
+// runBatch processes the messages in order and stops at the first failure, the changes of the messages processed
+// before are then discarded with the tx session of the batch. The events have the result of every processed message
+// followed by its own events.
+func runBatch(ctx *action.Context, tx action.RawTx, process func(action.Tx, *action.Context, action.RawTx) (bool, action.Response)) (bool, action.Response) {
+	b := &Batch{}
+	err := b.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	if !ctx.IsForkActive(config.BatchFork) {
+		return false, action.Response{Log: errors.Wrap(action.ErrWrongTxType, "batches not supported yet").Error()}
+	}
+
+	// the gas of every message is priced as its own transaction type
+	defer ctx.State.SetTxType(int(action.BATCH))
+
+	events := make([]types.Event, 0)
+	for i, m := range b.Messages {
+		ctx.State.SetTxType(int(m.Type))
+		ok, response := process(ctx.Router.Handler(m.Type), ctx, m.rawTx(tx))
+
+		events = append(events, resultEvent(i, m.Type, ok, response.Log))
+		events = append(events, response.Events...)
+		if !ok {
+			log := errors.Wrapf(action.ErrInvalidBatch, "message %d failed: %s", i, response.Log).Error()
+			return false, action.Response{Log: log, Events: events}
+		}
+	}
+
+	events = append(events, action.GetEvent(b.Tags(), "batch")...)
+	return true, action.Response{Events: events}
+}
+
+// resultEvent is the result of the message at the index in the batch
+func resultEvent(index int, msgType action.Type, ok bool, log string) types.Event {
+	pairs := kv.Pairs{
+		{Key: []byte("tx.index"), Value: []byte(strconv.Itoa(index))},
+		{Key: []byte("tx.type"), Value: []byte(msgType.String())},
+		{Key: []byte("tx.ok"), Value: []byte(strconv.FormatBool(ok))},
+	}
+	if log != "" {
+		pairs = append(pairs, kv.Pair{Key: []byte("tx.log"), Value: []byte(log)})
+	}
+	return types.Event{Type: "batch_message", Attributes: pairs}
+}
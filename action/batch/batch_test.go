@@ -0,0 +1,192 @@
+package batch
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/transfer"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
+)
+
+var olt = balance.Currency{Id: 0, Name: "OLT", Chain: 0, Decimal: 18, Unit: "nue"}
+
+func setupContext(t *testing.T) *action.Context {
+	cs := storage.NewState(storage.NewChainState("balance", db.NewDB("test", db.MemDBBackend, "")))
+
+	currencies := balance.NewCurrencySet()
+	require.NoError(t, currencies.Register(olt))
+
+	router := action.NewRouter("test")
+	require.NoError(t, transfer.EnableSend(router))
+	require.NoError(t, EnableBatch(router))
+
+	feePool := &fees.Store{}
+	feePool.SetupOpt(&fees.FeeOption{FeeCurrency: olt, MinFeeDecimal: 9})
+
+	return &action.Context{
+		Router:     router,
+		Header:     &abci.Header{Height: 1},
+		State:      cs,
+		Balances:   balance.NewStore("tb", cs),
+		Currencies: currencies,
+		FeePool:    feePool,
+		Logger:     log.NewLoggerWithPrefix(os.Stdout, "test"),
+		Forks:      config.DefaultForkParams(),
+	}
+}
+
+func sendMessage(t *testing.T, from, to keys.Address, amount int64) Message {
+	send := transfer.Send{
+		From:   from,
+		To:     to,
+		Amount: action.Amount{Currency: "OLT", Value: *balance.NewAmount(amount)},
+	}
+	data, err := send.Marshal()
+	require.NoError(t, err)
+	return Message{Type: action.SEND, Data: data}
+}
+
+func signBatch(t *testing.T, b Batch, privKeys ...ed25519.PrivKeyEd25519) action.SignedTx {
+	data, err := b.Marshal()
+	require.NoError(t, err)
+	tx := action.RawTx{
+		Type: action.BATCH,
+		Data: data,
+		Fee:  action.Fee{Price: action.Amount{Currency: "OLT", Value: *balance.NewAmount(1000000000)}, Gas: 100000},
+	}
+	signed := action.SignedTx{RawTx: tx}
+	for _, privKey := range privKeys {
+		signature, err := privKey.Sign(tx.RawBytes())
+		require.NoError(t, err)
+		signed.Signatures = append(signed.Signatures, action.Signature{
+			Signer: keys.PublicKey{KeyType: keys.ED25519, Data: privKey.PubKey().Bytes()[5:]},
+			Signed: signature,
+		})
+	}
+	return signed
+}
+
+func TestBatch_Signers(t *testing.T) {
+	alice := ed25519.GenPrivKey()
+	bob := ed25519.GenPrivKey()
+	aliceAddr := keys.Address(alice.PubKey().Address())
+	bobAddr := keys.Address(bob.PubKey().Address())
+
+	b := Batch{Messages: []Message{
+		sendMessage(t, aliceAddr, bobAddr, 10),
+		sendMessage(t, bobAddr, aliceAddr, 10),
+		sendMessage(t, aliceAddr, bobAddr, 10),
+	}}
+	signers := b.Signers()
+	require.Len(t, signers, 2)
+	assert.Equal(t, aliceAddr.Bytes(), signers[0].Bytes())
+	assert.Equal(t, bobAddr.Bytes(), signers[1].Bytes())
+}
+
+func TestBatchTx_Validate(t *testing.T) {
+	ctx := setupContext(t)
+	alice := ed25519.GenPrivKey()
+	bob := ed25519.GenPrivKey()
+	aliceAddr := keys.Address(alice.PubKey().Address())
+	bobAddr := keys.Address(bob.PubKey().Address())
+
+	b := Batch{Messages: []Message{
+		sendMessage(t, aliceAddr, bobAddr, 10),
+		sendMessage(t, bobAddr, aliceAddr, 10),
+	}}
+
+	t.Run("signed by all the signers, should return ok", func(t *testing.T) {
+		ok, err := batchTx{}.Validate(ctx, signBatch(t, b, alice, bob))
+		assert.True(t, ok)
+		assert.NoError(t, err)
+	})
+	t.Run("missing a signer, should return error", func(t *testing.T) {
+		ok, err := batchTx{}.Validate(ctx, signBatch(t, b, alice))
+		assert.False(t, ok)
+		assert.Error(t, err)
+	})
+	t.Run("nested batch, should return error", func(t *testing.T) {
+		inner, err := b.Marshal()
+		require.NoError(t, err)
+		nested := Batch{Messages: []Message{{Type: action.BATCH, Data: inner}}}
+		ok, err := batchTx{}.Validate(ctx, signBatch(t, nested, alice, bob))
+		assert.False(t, ok)
+		assert.Error(t, err)
+	})
+	t.Run("empty batch, should return error", func(t *testing.T) {
+		ok, err := batchTx{}.Validate(ctx, signBatch(t, Batch{}))
+		assert.False(t, ok)
+		assert.Error(t, err)
+	})
+}
+
+func TestBatchTx_ProcessDeliver(t *testing.T) {
+	ctx := setupContext(t)
+	alice := ed25519.GenPrivKey()
+	aliceAddr := keys.Address(alice.PubKey().Address())
+	bobAddr := keys.Address(ed25519.GenPrivKey().PubKey().Address())
+
+	require.NoError(t, ctx.Balances.AddToAddress(aliceAddr, olt.NewCoinFromUnit(100)))
+	ctx.State.Commit()
+
+	t.Run("all the messages succeed, should return a result per message", func(t *testing.T) {
+		b := Batch{Messages: []Message{
+			sendMessage(t, aliceAddr, bobAddr, 30),
+			sendMessage(t, aliceAddr, bobAddr, 20),
+		}}
+		ctx.State.BeginTxSession()
+		ok, resp := batchTx{}.ProcessDeliver(ctx, signBatch(t, b, alice).RawTx)
+		require.True(t, ok, resp.Log)
+		ctx.State.CommitTxSession()
+
+		results := 0
+		for _, event := range resp.Events {
+			if event.Type == "batch_message" {
+				results++
+			}
+		}
+		assert.Equal(t, 2, results)
+
+		coin, err := ctx.Balances.GetBalanceForCurr(bobAddr, &olt)
+		require.NoError(t, err)
+		assert.Equal(t, olt.NewCoinFromUnit(50).Amount, coin.Amount)
+	})
+	t.Run("a message fails, should fail the whole batch", func(t *testing.T) {
+		b := Batch{Messages: []Message{
+			sendMessage(t, aliceAddr, bobAddr, 30),
+			sendMessage(t, aliceAddr, bobAddr, 30),
+		}}
+		ctx.State.BeginTxSession()
+		ok, resp := batchTx{}.ProcessDeliver(ctx, signBatch(t, b, alice).RawTx)
+		assert.False(t, ok)
+		assert.Contains(t, resp.Log, "message 1 failed")
+		ctx.State.DiscardTxSession()
+
+		coin, err := ctx.Balances.GetBalanceForCurr(aliceAddr, &olt)
+		require.NoError(t, err)
+		assert.Equal(t, olt.NewCoinFromUnit(50).Amount, coin.Amount)
+	})
+	t.Run("before the batch fork, should fail", func(t *testing.T) {
+		b := Batch{Messages: []Message{sendMessage(t, aliceAddr, bobAddr, 10)}}
+		ctx.Header = &abci.Header{Height: 0}
+		defer func() { ctx.Header = &abci.Header{Height: 1} }()
+
+		ctx.State.BeginTxSession()
+		ok, resp := batchTx{}.ProcessDeliver(ctx, signBatch(t, b, alice).RawTx)
+		assert.False(t, ok)
+		assert.Contains(t, resp.Log, "batches not supported yet")
+		ctx.State.DiscardTxSession()
+	})
+}
@@ -0,0 +1,20 @@
+package batch
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+func init() {
+	serialize.RegisterConcrete(new(Batch), "action_batch")
+}
+
+func EnableBatch(r action.Router) error {
+	err := r.AddHandler(action.BATCH, batchTx{})
+	if err != nil {
+		return errors.Wrap(err, "batchTx")
+	}
+	return nil
+}
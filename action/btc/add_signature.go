@@ -85,7 +85,7 @@ func (ast btcAddSignatureTx) Validate(ctx *action.Context, signedTx action.Signe
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
 
-	err = action.ValidateBasic(signedTx.RawBytes(), addSignature.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), addSignature.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
@@ -75,7 +75,7 @@ func (b *btcBroadcastSuccessTx) Validate(ctx *action.Context, signedTx action.Si
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
 
-	err = action.ValidateBasic(signedTx.RawBytes(), broadcastSuccess.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), broadcastSuccess.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
@@ -110,7 +110,7 @@ func (reportFinalityMintTx) Validate(ctx *action.Context, signedTx action.Signed
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
 
-	err = action.ValidateBasic(signedTx.RawBytes(), f.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), f.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
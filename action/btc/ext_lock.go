@@ -93,12 +93,12 @@ func (btcLockTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bool,
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
 
-	err = action.ValidateBasic(signedTx.RawBytes(), lock.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), lock.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -80,12 +80,12 @@ func (btcRedeemTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bool
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
 
-	err = action.ValidateBasic(signedTx.RawBytes(), redeem.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), redeem.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -95,7 +95,7 @@ func (btcBroadcastFailureReset) Validate(ctx *action.Context, signedTx action.Si
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
 
-	err = action.ValidateBasic(signedTx.RawBytes(), failedBroadcastReset.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), failedBroadcastReset.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
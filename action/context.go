@@ -1,6 +1,7 @@
 package action
 
 import (
+	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/data"
 	"github.com/Oneledger/protocol/data/rewards"
 	"github.com/Oneledger/protocol/vm"
@@ -13,11 +14,14 @@ import (
 	"github.com/Oneledger/protocol/data/delegation"
 	"github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/evidence"
+	"github.com/Oneledger/protocol/data/feegrant"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/jobs"
+	"github.com/Oneledger/protocol/data/multisig"
 	netwkDeleg "github.com/Oneledger/protocol/data/network_delegation"
 	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/data/tokens"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
 	"github.com/Oneledger/protocol/storage"
@@ -48,9 +52,21 @@ type Context struct {
 	GovernanceStore     *governance.Store
 	ExtStores           data.Router
 	GovUpdate           *GovernaceUpdateAndValidate
+	FeeGrants           *feegrant.Store
+	MultiSigAccounts    *multisig.Store
+	Tokens              *tokens.Store
 
 	// evm
 	StateDB *vm.CommitStateDB
+
+	// activation heights of the network forks
+	Forks *config.ForkParams
+
+	// the transaction is run to estimate its gas, the signatures are not required
+	Simulation bool
+
+	// the transaction is a message of a batch, its signatures are verified with the batch
+	InBatch bool
 }
 
 func NewContext(r Router, header *abci.Header, state *storage.State,
@@ -61,7 +77,8 @@ func NewContext(r Router, header *abci.Header, state *storage.State,
 	btcTrackers *bitcoin.TrackerStore, ethTrackers *ethereum.TrackerStore, jobStore *jobs.JobStore,
 	lockScriptStore *bitcoin.LockScriptStore, logger *log.Logger, proposalmaster *governance.ProposalMasterStore,
 	rewardmaster *rewards.RewardMasterStore, govern *governance.Store, extStores data.Router, govUpdate *GovernaceUpdateAndValidate,
-	stateDB *vm.CommitStateDB,
+	stateDB *vm.CommitStateDB, forks *config.ForkParams, feeGrants *feegrant.Store,
+	multiSigAccounts *multisig.Store, tokens *tokens.Store,
 ) *Context {
 	return &Context{
 		Router:              r,
@@ -88,5 +105,17 @@ func NewContext(r Router, header *abci.Header, state *storage.State,
 		ExtStores:           extStores,
 		GovUpdate:           govUpdate,
 		StateDB:             stateDB,
+		Forks:               forks,
+		FeeGrants:           feeGrants,
+		MultiSigAccounts:    multiSigAccounts,
+		Tokens:              tokens,
+	}
+}
+
+// IsForkActive tells whether the changes of the named fork apply to the block being processed
+func (ctx *Context) IsForkActive(name string) bool {
+	if ctx.Forks == nil || ctx.Header == nil {
+		return false
 	}
+	return ctx.Forks.IsActive(name, ctx.Header.Height)
 }
@@ -0,0 +1,65 @@
+package action
+
+import (
+	"github.com/Oneledger/protocol/storage"
+)
+
+// DeliverTx runs the signed transaction on the state of the context the way the block processing does, in a tx
+// session which is committed when both the transaction and its fee succeed and discarded otherwise. A transaction
+// with a wrong nonce isn't run and the error is returned, the nonce of any other transaction is used even when it
+// fails, so it can't be replayed later. The size is the size of the raw transaction.
+func DeliverTx(ctx *Context, tx *SignedTx, size storage.Gas) (ok bool, response Response, feeOk bool, feeResponse Response, err error) {
+	ctx.State.BeginTxSession()
+
+	handler := ctx.Router.Handler(tx.Type)
+
+	ctx.State.SetTxType(int(tx.Type))
+	defer ctx.State.SetTxType(storage.NO_TX_TYPE)
+
+	gas := ctx.State.ConsumedGas()
+
+	withNonce := NonceRequired(ctx, *tx)
+	if withNonce {
+		err = ValidateNonce(ctx, *tx)
+		if err != nil {
+			ctx.State.DiscardTxSession()
+			return
+		}
+	}
+
+	ok, response = handler.ProcessDeliver(ctx, tx.RawTx)
+	feeOk, feeResponse = handler.ProcessFee(ctx, *tx, gas, size, storage.Gas(response.GasUsed))
+	if ok && feeOk && withNonce {
+		err := IncrementNonce(ctx, *tx)
+		if err != nil {
+			ctx.Logger.Error("failed to increment account nonce", err)
+			ok = false
+			response.Log = err.Error()
+		}
+	}
+
+	ctx.StateDB.Finality(response.Events)
+
+	if !(ok && feeOk) {
+		ctx.State.DiscardTxSession()
+		if withNonce {
+			useNonce(ctx, tx)
+		}
+	} else {
+		ctx.State.CommitTxSession()
+	}
+	return
+}
+
+// useNonce increments the nonce of a failed transaction in its own tx session, the session of the transaction is
+// already discarded
+func useNonce(ctx *Context, tx *SignedTx) {
+	ctx.State.BeginTxSession()
+	err := IncrementNonce(ctx, *tx)
+	if err != nil {
+		ctx.Logger.Error("failed to increment account nonce", err)
+		ctx.State.DiscardTxSession()
+		return
+	}
+	ctx.State.CommitTxSession()
+}
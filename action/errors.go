@@ -19,6 +19,8 @@ var (
 	ErrGasOverflow        = codes.ProtocolError{codes.TxErrGasOverflow, "gas used exceed limit"}
 	ErrInvalidExtTx       = codes.ProtocolError{codes.TxErrInvalidExtTx, "invalid external tx"}
 	ErrInvalidVmExecution = codes.ProtocolError{codes.TxErrVMExecution, "vm execution error"}
+	ErrInvalidNonce       = codes.ProtocolError{codes.TxErrInvalidNonce, "invalid account nonce"}
+	ErrInvalidBatch       = codes.ProtocolError{codes.TxErrInvalidBatch, "invalid batch of messages"}
 
 	ErrInvalidAddress          = codes.ErrBadAddress
 	ErrInvalidCurrency         = codes.ProtocolError{codes.TxErrInvalidFeeCurrency, "invalid fee currency"}
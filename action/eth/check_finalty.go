@@ -83,7 +83,7 @@ func (reportFinalityMintTx) Validate(ctx *action.Context, signedTx action.Signed
 		return false, errors.Wrap(err, action.ErrWrongTxType.Error())
 	}
 
-	err = action.ValidateBasic(signedTx.RawBytes(), f.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), f.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
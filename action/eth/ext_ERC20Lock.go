@@ -82,7 +82,7 @@ func (e ethERC20LockTx) Validate(ctx *action.Context, signedTx action.SignedTx)
 		return false, errors.Wrap(err, action.ErrWrongTxType.Msg)
 	}
 
-	err = action.ValidateBasic(signedTx.RawBytes(), erclock.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), erclock.Signers(), signedTx.Signatures)
 	if err != nil {
 		ctx.Logger.Error("validate basic failed", err)
 		return false, err
@@ -90,7 +90,7 @@ func (e ethERC20LockTx) Validate(ctx *action.Context, signedTx action.SignedTx)
 
 	// validate fee
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		ctx.Logger.Error("validate fee failed", err)
 		return false, err
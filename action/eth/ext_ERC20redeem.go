@@ -82,14 +82,14 @@ func (e ethERC20RedeemTx) Validate(ctx *action.Context, signedTx action.SignedTx
 		return false, errors.Wrap(err, action.ErrWrongTxType.Error())
 	}
 
-	err = action.ValidateBasic(signedTx.RawBytes(), erc20redeem.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), erc20redeem.Signers(), signedTx.Signatures)
 	if err != nil {
 		ctx.Logger.Error("validate basic failed", err)
 		return false, err
 	}
 
 	// validate fee
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		ctx.Logger.Error("validate fee failed", err)
 		return false, err
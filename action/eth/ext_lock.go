@@ -84,14 +84,14 @@ func (ethLockTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bool,
 	}
 
 	// validate basic
-	err = action.ValidateBasic(signedTx.RawBytes(), lock.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), lock.Signers(), signedTx.Signatures)
 	if err != nil {
 		ctx.Logger.Error("validate basic failed", err)
 		return false, err
 	}
 
 	// validate fee
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		ctx.Logger.Error("validate fee failed", err)
 		return false, err
@@ -85,12 +85,12 @@ func (atx allegationTx) Validate(ctx *action.Context, tx action.SignedTx) (bool,
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
 
-	err = action.ValidateBasic(tx.RawBytes(), r.Signers(), tx.Signatures)
+	err = action.ValidateBasic(ctx, tx.RawBytes(), r.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,162 @@
+package feegrant
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	"github.com/Oneledger/protocol/config"
+	fg "github.com/Oneledger/protocol/data/feegrant"
+)
+
+var _ action.Msg = &FeeGrant{}
+
+// FeeGrant lets the grantee pay the fees of its transactions from the balance of the granter, up to the spend limit
+// and until the expiry height. It replaces the allowance previously granted to the grantee.
+type FeeGrant struct {
+	Granter action.Address `json:"granter"`
+	Grantee action.Address `json:"grantee"`
+	// fees the grantee can spend, in the fee currency, no limit when nil
+	SpendLimit *action.Amount `json:"spendLimit,omitempty"`
+	// last height the allowance can be used at, 0 for no expiry
+	Expiry int64 `json:"expiry,omitempty"`
+	// tx types the allowance pays for, all of them when empty
+	AllowedTxTypes []action.Type `json:"allowedTxTypes,omitempty"`
+}
+
+func (g FeeGrant) Marshal() ([]byte, error) {
+	return json.Marshal(g)
+}
+
+func (g *FeeGrant) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, g)
+}
+
+func (g FeeGrant) Signers() []action.Address {
+	return []action.Address{g.Granter}
+}
+
+func (g FeeGrant) Type() action.Type {
+	return action.FEE_GRANT
+}
+
+func (g FeeGrant) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(g.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.granter"),
+		Value: g.Granter.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.grantee"),
+		Value: g.Grantee.Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+// allowance returns the allowance granted, the spend limit is in the smallest unit of the fee currency
+func (g FeeGrant) allowance(ctx *action.Context) *fg.Allowance {
+	allowance := &fg.Allowance{
+		Granter: g.Granter,
+		Grantee: g.Grantee,
+		Expiry:  g.Expiry,
+	}
+	if g.SpendLimit != nil {
+		allowance.SpendLimit = g.SpendLimit.ToCoin(ctx.Currencies).Amount
+	}
+	for _, t := range g.AllowedTxTypes {
+		allowance.AllowedTxTypes = append(allowance.AllowedTxTypes, int(t))
+	}
+	return allowance
+}
+
+var _ action.Tx = feeGrantTx{}
+
+type feeGrantTx struct{}
+
+func (feeGrantTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	grant := &FeeGrant{}
+	err := grant.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateBasic(ctx, tx.RawBytes(), grant.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if grant.Granter.Err() != nil || grant.Grantee.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+	if grant.Granter.Equal(grant.Grantee) {
+		return false, errors.Wrap(action.ErrInvalidAddress, "granter and grantee are the same")
+	}
+
+	if grant.SpendLimit != nil {
+		if grant.SpendLimit.Currency != ctx.FeePool.GetOpt().FeeCurrency.Name {
+			return false, errors.Wrap(action.ErrInvalidCurrency, grant.SpendLimit.Currency)
+		}
+		if !grant.SpendLimit.IsValid(ctx.Currencies) {
+			return false, errors.Wrap(action.ErrInvalidAmount, grant.SpendLimit.String())
+		}
+	}
+	if grant.Expiry < 0 {
+		return false, errors.Wrap(fg.ErrAllowanceExpired, "negative expiry")
+	}
+	for _, t := range grant.AllowedTxTypes {
+		if t.String() == "UNKNOWN" {
+			return false, errors.Wrapf(action.ErrWrongTxType, "allowed tx type %d", t)
+		}
+	}
+	return true, nil
+}
+
+func (feeGrantTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Fee Grant Transaction for CheckTx", tx)
+	return runFeeGrant(ctx, tx)
+}
+
+func (feeGrantTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Fee Grant Transaction for DeliverTx", tx)
+	return runFeeGrant(ctx, tx)
+}
+
+func (feeGrantTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runFeeGrant(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	grant := &FeeGrant{}
+	err := grant.Unmarshal(tx.Data)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrUnserializable, grant.Tags(), err)
+	}
+
+	if !ctx.IsForkActive(config.FeeGrantFork) {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrWrongTxType, grant.Tags(), errors.New("fee grants not supported yet"))
+	}
+	if grant.Expiry != 0 && grant.Expiry < ctx.Header.Height {
+		return helpers.LogAndReturnFalse(ctx.Logger, fg.ErrAllowanceExpired, grant.Tags(), errors.New("expiry before the current height"))
+	}
+
+	err = ctx.FeeGrants.Set(grant.allowance(ctx))
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrUnserializable, grant.Tags(), err)
+	}
+	return helpers.LogAndReturnTrue(ctx.Logger, grant.Tags(), "fee_grant")
+}
@@ -0,0 +1,28 @@
+package feegrant
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+func init() {
+	serialize.RegisterConcrete(new(FeeGrant), "action_fee_grant")
+	serialize.RegisterConcrete(new(FeeRevoke), "action_fee_revoke")
+
+	action.RegisterMsgType(action.FEE_GRANT, func() action.Msg { return &FeeGrant{} })
+	action.RegisterMsgType(action.FEE_REVOKE, func() action.Msg { return &FeeRevoke{} })
+}
+
+func EnableFeeGrant(r action.Router) error {
+	err := r.AddHandler(action.FEE_GRANT, feeGrantTx{})
+	if err != nil {
+		return errors.Wrap(err, "feeGrantTx")
+	}
+	err = r.AddHandler(action.FEE_REVOKE, feeRevokeTx{})
+	if err != nil {
+		return errors.Wrap(err, "feeRevokeTx")
+	}
+	return nil
+}
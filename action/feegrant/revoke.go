@@ -0,0 +1,111 @@
+package feegrant
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	fg "github.com/Oneledger/protocol/data/feegrant"
+)
+
+var _ action.Msg = &FeeRevoke{}
+
+// FeeRevoke removes the allowance the granter gave to the grantee
+type FeeRevoke struct {
+	Granter action.Address `json:"granter"`
+	Grantee action.Address `json:"grantee"`
+}
+
+func (r FeeRevoke) Marshal() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *FeeRevoke) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+func (r FeeRevoke) Signers() []action.Address {
+	return []action.Address{r.Granter}
+}
+
+func (r FeeRevoke) Type() action.Type {
+	return action.FEE_REVOKE
+}
+
+func (r FeeRevoke) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(r.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.granter"),
+		Value: r.Granter.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.grantee"),
+		Value: r.Grantee.Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = feeRevokeTx{}
+
+type feeRevokeTx struct{}
+
+func (feeRevokeTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	revoke := &FeeRevoke{}
+	err := revoke.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateBasic(ctx, tx.RawBytes(), revoke.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if revoke.Granter.Err() != nil || revoke.Grantee.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+	return true, nil
+}
+
+func (feeRevokeTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Fee Revoke Transaction for CheckTx", tx)
+	return runFeeRevoke(ctx, tx)
+}
+
+func (feeRevokeTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Fee Revoke Transaction for DeliverTx", tx)
+	return runFeeRevoke(ctx, tx)
+}
+
+func (feeRevokeTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runFeeRevoke(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	revoke := &FeeRevoke{}
+	err := revoke.Unmarshal(tx.Data)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrUnserializable, revoke.Tags(), err)
+	}
+
+	err = ctx.FeeGrants.Delete(revoke.Granter, revoke.Grantee)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, fg.ErrAllowanceNotFound, revoke.Tags(), err)
+	}
+	return helpers.LogAndReturnTrue(ctx.Logger, revoke.Tags(), "fee_revoke")
+}
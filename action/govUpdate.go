@@ -1,6 +1,7 @@
 package action
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"strconv"
@@ -8,7 +9,10 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/tokens"
+	"github.com/Oneledger/protocol/storage"
 )
 
 type FunctionBehaviour int
@@ -63,6 +67,29 @@ func (g GovernaceUpdateAndValidate) inititalizize() {
 	g.GovernanceUpdateFunction["evidenceOptions.minVotesRequired"] = evidenceOptionsminVotesRequired
 	g.GovernanceUpdateFunction["evidenceOptions.blockVotesDiff"] = evidenceOptionsblockVotesDiff
 	g.GovernanceUpdateFunction["evidenceOptions.penaltyBasePercentage"] = evidenceOptionspenaltyBasePercentage
+	g.GovernanceUpdateFunction["feeMarketOptions.enabled"] = feeMarketOptionsenabled
+	g.GovernanceUpdateFunction["feeMarketOptions.targetUtilisation"] = feeMarketOptionstargetUtilisation
+	g.GovernanceUpdateFunction["feeMarketOptions.baseFeeChangeDenominator"] = feeMarketOptionsbaseFeeChangeDenominator
+	g.GovernanceUpdateFunction["feeMarketOptions.baseFeeAddress"] = feeMarketOptionsbaseFeeAddress
+	g.GovernanceUpdateFunction["gasOptions.schedule.storeBytes"] = gasOptionsSchedule(func(s *storage.GasSchedule) *storage.Gas { return &s.StoreBytes })
+	g.GovernanceUpdateFunction["gasOptions.schedule.readFlat"] = gasOptionsSchedule(func(s *storage.GasSchedule) *storage.Gas { return &s.ReadFlat })
+	g.GovernanceUpdateFunction["gasOptions.schedule.readBytes"] = gasOptionsSchedule(func(s *storage.GasSchedule) *storage.Gas { return &s.ReadBytes })
+	g.GovernanceUpdateFunction["gasOptions.schedule.writeFlat"] = gasOptionsSchedule(func(s *storage.GasSchedule) *storage.Gas { return &s.WriteFlat })
+	g.GovernanceUpdateFunction["gasOptions.schedule.writeBytes"] = gasOptionsSchedule(func(s *storage.GasSchedule) *storage.Gas { return &s.WriteBytes })
+	g.GovernanceUpdateFunction["gasOptions.schedule.verifySig"] = gasOptionsSchedule(func(s *storage.GasSchedule) *storage.Gas { return &s.VerifySig })
+	g.GovernanceUpdateFunction["gasOptions.schedule.hashBytes"] = gasOptionsSchedule(func(s *storage.GasSchedule) *storage.Gas { return &s.HashBytes })
+	g.GovernanceUpdateFunction["gasOptions.schedule.checkExist"] = gasOptionsSchedule(func(s *storage.GasSchedule) *storage.Gas { return &s.CheckExist })
+	g.GovernanceUpdateFunction["gasOptions.schedule.delete"] = gasOptionsSchedule(func(s *storage.GasSchedule) *storage.Gas { return &s.Delete })
+	g.GovernanceUpdateFunction["tokenOptions.enabled"] = tokenOptionsenabled
+	g.GovernanceUpdateFunction["tokenOptions.creationFee"] = tokenOptionscreationFee
+	g.GovernanceUpdateFunction["tokenOptions.createToken"] = tokenOptionscreateToken
+	// one multiplier per transaction type, the evm transactions are priced by the evm
+	for txType, name := range txTypeMap {
+		if txType == OLVM {
+			continue
+		}
+		g.GovernanceUpdateFunction["gasOptions.txMultipliers."+name] = gasOptionsTxMultiplier(txType)
+	}
 	//g.GovernanceUpdateFunction["evidenceOptions.penaltyPercentage"] = evidenceOptionspenaltyPercentage
 
 	//MinVotesRequired: 2, // should be atleast 70% or greater of block votes diff
@@ -1097,6 +1124,244 @@ func feeOptionminFeeDecimal(value interface{}, ctx *Context, validationOnly Func
 	return true, nil
 }
 
+func feeMarketOptionsenabled(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	marketOptions, err := ctx.GovernanceStore.GetFeeMarketOptions()
+	if err != nil {
+		return false, err
+	}
+	newValue, err := getNewValueBool(value)
+	if err != nil {
+		return false, err
+	}
+	marketOptions.Enabled = newValue
+	return updateFeeMarketOptions(marketOptions, ctx, validationOnly, "enabled", newValue)
+}
+
+func feeMarketOptionstargetUtilisation(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	marketOptions, err := ctx.GovernanceStore.GetFeeMarketOptions()
+	if err != nil {
+		return false, err
+	}
+	newValue, err := getNewValueInt64(value)
+	if err != nil {
+		return false, err
+	}
+	marketOptions.TargetUtilisation = newValue
+	return updateFeeMarketOptions(marketOptions, ctx, validationOnly, "targetUtilisation", newValue)
+}
+
+func feeMarketOptionsbaseFeeChangeDenominator(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	marketOptions, err := ctx.GovernanceStore.GetFeeMarketOptions()
+	if err != nil {
+		return false, err
+	}
+	newValue, err := getNewValueInt64(value)
+	if err != nil {
+		return false, err
+	}
+	marketOptions.BaseFeeChangeDenominator = newValue
+	return updateFeeMarketOptions(marketOptions, ctx, validationOnly, "baseFeeChangeDenominator", newValue)
+}
+
+func feeMarketOptionsbaseFeeAddress(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	marketOptions, err := ctx.GovernanceStore.GetFeeMarketOptions()
+	if err != nil {
+		return false, err
+	}
+	newValue, ok := value.(string)
+	if !ok {
+		return false, errors.New("Type assertion failed")
+	}
+	// an empty address burns the base fee
+	address := Address{}
+	if len(newValue) != 0 {
+		err = address.UnmarshalText([]byte(newValue))
+		if err != nil {
+			return false, err
+		}
+	}
+	marketOptions.BaseFeeAddress = address
+	return updateFeeMarketOptions(marketOptions, ctx, validationOnly, "baseFeeAddress", newValue)
+}
+
+// updateFeeMarketOptions validates the updated fee market options and sets them unless it is a validation only
+func updateFeeMarketOptions(marketOptions *fees.MarketOptions, ctx *Context, validationOnly FunctionBehaviour, field string, newValue interface{}) (bool, error) {
+	ok, err := ctx.GovernanceStore.ValidateFeeMarket(marketOptions, ctx.FeePool.GetBlockGasLimit())
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, errors.New("Validation Failed")
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetFeeMarketOptions(*marketOptions)
+	if err != nil {
+		return false, errors.Wrap(err, "Setup Fee Market Options")
+	}
+	ctx.FeePool.SetupMarketOpt(marketOptions)
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetLUH(governance.LAST_UPDATE_HEIGHT_FEE_MARKET)
+	if err != nil {
+		return false, errors.Wrap(err, "Unable to set last Update height ")
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| feeMarketOptions."+field+" :", newValue)
+	return true, nil
+}
+
+// gasOptionsSchedule returns the update function of the cost of an operation in the gas schedule
+func gasOptionsSchedule(field func(*storage.GasSchedule) *storage.Gas) func(interface{}, *Context, FunctionBehaviour) (bool, error) {
+	return func(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+		gasOptions, err := ctx.GovernanceStore.GetGasOptions()
+		if err != nil {
+			return false, err
+		}
+		newValue, err := getNewValueInt64(value)
+		if err != nil {
+			return false, err
+		}
+		*field(&gasOptions.Schedule) = storage.Gas(newValue)
+		return updateGasOptions(gasOptions, ctx, validationOnly, "schedule", newValue)
+	}
+}
+
+// gasOptionsTxMultiplier returns the update function of the gas multiplier of the transaction type, in percent
+func gasOptionsTxMultiplier(txType Type) func(interface{}, *Context, FunctionBehaviour) (bool, error) {
+	return func(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+		gasOptions, err := ctx.GovernanceStore.GetGasOptions()
+		if err != nil {
+			return false, err
+		}
+		newValue, err := getNewValueInt64(value)
+		if err != nil {
+			return false, err
+		}
+		gasOptions.SetMultiplier(int(txType), newValue)
+		return updateGasOptions(gasOptions, ctx, validationOnly, "txMultipliers."+txType.String(), newValue)
+	}
+}
+
+// updateGasOptions validates the updated gas schedule and sets it unless it is a validation only, the new schedule
+// prices the transactions from the next block
+func updateGasOptions(gasOptions *storage.GasOptions, ctx *Context, validationOnly FunctionBehaviour, field string, newValue interface{}) (bool, error) {
+	ok, err := ctx.GovernanceStore.ValidateGas(gasOptions)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, errors.New("Validation Failed")
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetGasOptions(*gasOptions)
+	if err != nil {
+		return false, errors.Wrap(err, "Setup Gas Options")
+	}
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetLUH(governance.LAST_UPDATE_HEIGHT_GAS)
+	if err != nil {
+		return false, errors.Wrap(err, "Unable to set last Update height ")
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| gasOptions."+field+" :", newValue)
+	return true, nil
+}
+
+func tokenOptionsenabled(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	tokenOptions, err := ctx.GovernanceStore.GetTokenOptions()
+	if err != nil {
+		return false, err
+	}
+	newValue, err := getNewValueBool(value)
+	if err != nil {
+		return false, err
+	}
+	tokenOptions.Enabled = newValue
+	return updateTokenOptions(tokenOptions, ctx, validationOnly, "enabled", newValue)
+}
+
+func tokenOptionscreationFee(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	tokenOptions, err := ctx.GovernanceStore.GetTokenOptions()
+	if err != nil {
+		return false, err
+	}
+	newValue, err := getNewBigInt(value)
+	if err != nil {
+		return false, err
+	}
+	tokenOptions.CreationFee = *balance.NewAmountFromBigInt(newValue)
+	return updateTokenOptions(tokenOptions, ctx, validationOnly, "creationFee", newValue)
+}
+
+// updateTokenOptions validates the updated token options and sets them unless it is a validation only
+func updateTokenOptions(tokenOptions *tokens.Options, ctx *Context, validationOnly FunctionBehaviour, field string, newValue interface{}) (bool, error) {
+	ok, err := ctx.GovernanceStore.ValidateToken(tokenOptions)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, errors.New("Validation Failed")
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetTokenOptions(*tokenOptions)
+	if err != nil {
+		return false, errors.Wrap(err, "Setup Token Options")
+	}
+	err = ctx.GovernanceStore.WithHeight(ctx.Header.Height).SetLUH(governance.LAST_UPDATE_HEIGHT_TOKEN)
+	if err != nil {
+		return false, errors.Wrap(err, "Unable to set last Update height ")
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| tokenOptions."+field+" :", newValue)
+	return true, nil
+}
+
+// tokenDefinition is the value of a config update creating a token, as a json string
+type tokenDefinition struct {
+	Name      string         `json:"name"`
+	Unit      string         `json:"unit"`
+	Decimal   int64          `json:"decimal"`
+	Issuer    Address        `json:"issuer"`
+	MaxSupply balance.Amount `json:"maxSupply"`
+}
+
+// tokenOptionscreateToken registers the token of the passed proposal, no creation fee is charged
+func tokenOptionscreateToken(value interface{}, ctx *Context, validationOnly FunctionBehaviour) (bool, error) {
+	newValue, ok := value.(string)
+	if !ok {
+		return false, errors.New("Type assertion failed")
+	}
+	def := tokenDefinition{}
+	err := json.Unmarshal([]byte(newValue), &def)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid token definition")
+	}
+	token, err := tokens.NewToken(def.Name, def.Unit, def.Decimal, def.Issuer, def.MaxSupply, ctx.Header.Height)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := ctx.Currencies.GetCurrencyByName(token.Currency.Name); ok || ctx.Tokens.Exists(token.Currency.Name) {
+		return false, errors.Wrap(tokens.ErrTokenExists, token.Currency.Name)
+	}
+	if validationOnly == ValidateOnly {
+		return true, nil
+	}
+	err = ctx.Tokens.Create(token, ctx.Currencies)
+	if err != nil {
+		return false, err
+	}
+	ctx.Logger.Debug("Governance options set at height : ", ctx.Header.Height, "| tokenOptions.createToken :", newValue)
+	return true, nil
+}
+
+func getNewValueBool(value interface{}) (bool, error) {
+	newValue, ok := value.(string)
+	if !ok {
+		return false, errors.New("Type assertion failed")
+	}
+	return strconv.ParseBool(newValue)
+}
+
 func getNewValueInt64(value interface{}) (int64, error) {
 	newValue, ok := value.(string)
 	if !ok {
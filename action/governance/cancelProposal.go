@@ -75,12 +75,12 @@ func (c cancelProposalTx) Validate(ctx *action.Context, tx action.SignedTx) (boo
 	}
 
 	// validate basic signature
-	err = action.ValidateBasic(tx.RawBytes(), cc.Signers(), tx.Signatures)
+	err = action.ValidateBasic(ctx, tx.RawBytes(), cc.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -28,6 +28,7 @@ type CreateProposal struct {
 	VotingDeadline  int64                   `json:"votingDeadline"`
 	PassPercentage  int                     `json:"passPercentage"`
 	ConfigUpdate    string                  `json:"configUpdate"`
+	Upgrade         *governance.UpgradePlan `json:"upgrade,omitempty"`
 }
 
 func (c CreateProposal) Validate(ctx *action.Context, signedTx action.SignedTx) (bool, error) {
@@ -38,12 +39,12 @@ func (c CreateProposal) Validate(ctx *action.Context, signedTx action.SignedTx)
 	}
 
 	//validate basic signature
-	err = action.ValidateBasic(signedTx.RawBytes(), createProposal.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), createProposal.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -80,6 +81,17 @@ func (c CreateProposal) Validate(ctx *action.Context, signedTx action.SignedTx)
 		return false, governance.ErrInvalidProposalDesc
 	}
 
+	//Only code change proposals schedule an upgrade
+	if createProposal.Upgrade != nil {
+		if createProposal.ProposalType != governance.ProposalTypeCodeChange {
+			return false, governance.ErrInvalidUpgradePlan
+		}
+		err = createProposal.Upgrade.Validate()
+		if err != nil {
+			return false, err
+		}
+	}
+
 	return true, nil
 }
 
@@ -173,6 +185,11 @@ func runTx(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 
 	}
 
+	//The upgrade can only happen after the proposal is finalized
+	if createProposal.Upgrade != nil && createProposal.Upgrade.Height <= createProposal.VotingDeadline {
+		return helpers.LogAndReturnFalse(ctx.Logger, governance.ErrInvalidUpgradePlan, createProposal.Tags(), errors.New("Upgrade height before voting deadline"))
+	}
+
 	//Create Proposal and save to Proposal Store
 	proposal := governance.NewProposal(
 		createProposal.ProposalID,
@@ -185,6 +202,11 @@ func runTx(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 		createProposal.VotingDeadline,
 		createProposal.PassPercentage,
 		createProposal.ConfigUpdate)
+	if createProposal.Upgrade != nil {
+		upgrade := *createProposal.Upgrade
+		upgrade.ProposalID = createProposal.ProposalID
+		proposal.Upgrade = &upgrade
+	}
 
 	//Check if Proposal already exists
 	if ctx.ProposalMasterStore.Proposal.Exists(proposal.ProposalID) {
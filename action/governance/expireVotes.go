@@ -25,7 +25,7 @@ func (e ExpireVotes) Validate(ctx *action.Context, signedTx action.SignedTx) (bo
 	}
 
 	//validate basic signature
-	err = action.ValidateBasic(signedTx.RawBytes(), expireVotes.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), expireVotes.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
@@ -63,7 +63,7 @@ func (FinalizeProposal) Validate(ctx *action.Context, signedTx action.SignedTx)
 	}
 
 	//Validate basic signature
-	err = action.ValidateBasic(signedTx.RawBytes(), finalizedProposal.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), finalizedProposal.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
@@ -171,6 +171,18 @@ func runFinalizeProposal(ctx *action.Context, tx action.RawTx) (bool, action.Res
 			}
 
 		}
+		if proposal.Type == governance.ProposalTypeCodeChange && proposal.Upgrade != nil {
+			err = scheduleUpgrade(ctx, proposal)
+			if err != nil {
+				ctx.Logger.Error("Scheduling upgrade failed ", err)
+				err = setToFinalizeFailed(ctx, proposal)
+				if err != nil {
+					return helpers.LogAndReturnFalse(ctx.Logger, governance.ErrStatusUnableToSetFinalizeFailed, finalizedProposal.Tags(), err)
+				}
+
+				return helpers.LogAndReturnTrue(ctx.Logger, finalizedProposal.Tags(), governance.ErrFinalizeCodeChangeFailed.Wrap(err).Marshal())
+			}
+		}
 		proposalDistribution := options.PassedFundDistribution
 		distributeErr := distributeFunds(ctx, proposal, &proposalDistribution)
 		if distributeErr != nil {
@@ -208,6 +220,23 @@ func runFinalizeProposal(ctx *action.Context, tx action.RawTx) (bool, action.Res
 	return helpers.LogAndReturnTrue(ctx.Logger, finalizedProposal.Tags(), "finalize_proposal_success")
 }
 
+//Persist the upgrade plan of a passed code change proposal, a later proposal replaces the pending plan
+func scheduleUpgrade(ctx *action.Context, proposal *governance.Proposal) error {
+	plan := *proposal.Upgrade
+	if plan.Height <= ctx.Header.Height {
+		return errors.Errorf("upgrade height %d already reached", plan.Height)
+	}
+	pending, err := ctx.GovernanceStore.GetUpgradePlan()
+	if err != nil {
+		return err
+	}
+	if pending != nil {
+		ctx.Logger.Infof("Upgrade %s at height %d replaced by %s", pending.Name, pending.Height, plan.Name)
+	}
+	ctx.Logger.Infof("Upgrade %s scheduled at height %d", plan.Name, plan.Height)
+	return ctx.GovernanceStore.SetUpgradePlan(plan)
+}
+
 //Function to distribute funds
 func distributeFunds(ctx *action.Context, proposal *governance.Proposal, proposalDistribution *governance.ProposalFundDistribution) error {
 	// Required Perimeters for Fund Distribution
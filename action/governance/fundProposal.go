@@ -8,6 +8,7 @@ import (
 
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/action/helpers"
+	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/keys"
@@ -74,12 +75,12 @@ func (fundProposalTx) Validate(ctx *action.Context, signedTx action.SignedTx) (b
 	}
 
 	//Validate basic signature
-	err = action.ValidateBasic(signedTx.RawBytes(), fundProposal.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), fundProposal.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
 	//Validate Fee for funding request
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -172,6 +173,14 @@ func runFundProposal(ctx *action.Context, tx action.RawTx) (bool, action.Respons
 		}
 		for _, v := range validatorList {
 			vote := governance.NewProposalVote(v.Address, governance.OPIN_UNKNOWN, v.Power)
+			if ctx.IsForkActive(config.DelegatorVotingFork) {
+				// the delegators of the validator inherit its vote unless they vote themselves
+				delegated, err := ctx.NetwkDelegators.Deleg.GetValidatorPower(v.Address)
+				if err != nil {
+					return helpers.LogAndReturnFalse(ctx.Logger, governance.ErrSetupVotingValidator, fundProposal.Tags(), err)
+				}
+				vote.DelegatedPower = delegationPower(delegated)
+			}
 			err = ctx.ProposalMasterStore.ProposalVote.Setup(proposal.ProposalID, vote)
 			if err != nil {
 				return helpers.LogAndReturnFalse(ctx.Logger, governance.ErrSetupVotingValidator, fundProposal.Tags(), err)
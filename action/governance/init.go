@@ -11,6 +11,12 @@ func init() {
 	serialize.RegisterConcrete(new(CreateProposal), "action_cp")
 	serialize.RegisterConcrete(new(CancelProposal), "action_ccp")
 	serialize.RegisterConcrete(new(VoteProposal), "action_vp")
+
+	action.RegisterMsgType(action.PROPOSAL_CREATE, func() action.Msg { return &CreateProposal{} })
+	action.RegisterMsgType(action.PROPOSAL_CANCEL, func() action.Msg { return &CancelProposal{} })
+	action.RegisterMsgType(action.PROPOSAL_FUND, func() action.Msg { return &FundProposal{} })
+	action.RegisterMsgType(action.PROPOSAL_VOTE, func() action.Msg { return &VoteProposal{} })
+	action.RegisterMsgType(action.PROPOSAL_WITHDRAW_FUNDS, func() action.Msg { return &WithdrawFunds{} })
 }
 
 func EnableGovernance(r action.Router) error {
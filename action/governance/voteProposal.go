@@ -2,17 +2,24 @@ package governance
 
 import (
 	"encoding/json"
+	"math"
+	"math/big"
 
 	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/libs/kv"
 
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/action/helpers"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
 	gov "github.com/Oneledger/protocol/data/governance"
+	net_delg "github.com/Oneledger/protocol/data/network_delegation"
 )
 
 var _ action.Msg = &VoteProposal{}
 
+// VoteProposal is the vote of a validator, signed by the validator and the address paying the fee. Without a
+// validator address it is the vote of the network delegator at the address, weighted by its active delegation.
 type VoteProposal struct {
 	ProposalID       gov.ProposalID  `json:"proposalId"`
 	Address          action.Address  `json:"address"`
@@ -35,12 +42,12 @@ func (v voteProposalTx) Validate(ctx *action.Context, tx action.SignedTx) (bool,
 	}
 
 	// validate basic signature
-	err = action.ValidateBasic(tx.RawBytes(), vote.Signers(), tx.Signatures)
+	err = action.ValidateBasic(ctx, tx.RawBytes(), vote.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -52,7 +59,11 @@ func (v voteProposalTx) Validate(ctx *action.Context, tx action.SignedTx) (bool,
 	if err = vote.Address.Err(); err != nil {
 		return false, gov.ErrInvalidVoterId
 	}
-	if !ctx.Validators.IsValidatorAddress(vote.ValidatorAddress) {
+	if vote.isDelegatorVote() {
+		if !ctx.IsForkActive(config.DelegatorVotingFork) {
+			return false, action.ErrInvalidValidatorAddr
+		}
+	} else if !ctx.Validators.IsValidatorAddress(vote.ValidatorAddress) {
 		return false, action.ErrInvalidValidatorAddr
 	}
 	if err = vote.Opinion.Err(); err != nil {
@@ -108,20 +119,50 @@ func runVote(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 		}
 	}
 
-	// Get validator's voting power
-	validator, err := ctx.Validators.Get(vote.ValidatorAddress)
-	if err != nil {
-		return false, action.Response{
-			Log: action.ErrGettingValidatorList.Wrap(err).Marshal(),
+	if vote.isDelegatorVote() {
+		// Update the vote of the delegator in proposal vote store, it votes with the power of its active delegation and
+		// overrides the vote of the validator it picked
+		delegation, err := ctx.NetwkDelegators.Deleg.WithPrefix(net_delg.ActiveType).Get(vote.Address)
+		if err != nil {
+			return false, action.Response{
+				Log: gov.ErrNoActiveDelegation.Wrap(err).Marshal(),
+			}
+		}
+		power := delegationPower(delegation)
+		if power <= 0 {
+			return false, action.Response{
+				Log: gov.ErrNoActiveDelegation.Marshal(),
+			}
+		}
+		validator, err := ctx.NetwkDelegators.Deleg.GetValidator(vote.Address)
+		if err != nil {
+			return false, action.Response{
+				Log: gov.ErrNoActiveDelegation.Wrap(err).Marshal(),
+			}
+		}
+		dv := gov.NewDelegatorVote(vote.Address, validator, vote.Opinion, power)
+		err = ctx.ProposalMasterStore.ProposalVote.UpdateDelegator(vote.ProposalID, dv)
+		if err != nil {
+			return false, action.Response{
+				Log: gov.ErrAddingVoteToVoteStore.Wrap(err).Marshal(),
+			}
+		}
+	} else {
+		// Get validator's voting power
+		validator, err := ctx.Validators.Get(vote.ValidatorAddress)
+		if err != nil {
+			return false, action.Response{
+				Log: action.ErrGettingValidatorList.Wrap(err).Marshal(),
+			}
 		}
-	}
 
-	// Add this vote to proposal vote store
-	pv := gov.NewProposalVote(vote.ValidatorAddress, vote.Opinion, validator.Power)
-	err = ctx.ProposalMasterStore.ProposalVote.Update(vote.ProposalID, pv)
-	if err != nil {
-		return false, action.Response{
-			Log: gov.ErrAddingVoteToVoteStore.Wrap(err).Marshal(),
+		// Add this vote to proposal vote store
+		pv := gov.NewProposalVote(vote.ValidatorAddress, vote.Opinion, validator.Power)
+		err = ctx.ProposalMasterStore.ProposalVote.Update(vote.ProposalID, pv)
+		if err != nil {
+			return false, action.Response{
+				Log: gov.ErrAddingVoteToVoteStore.Wrap(err).Marshal(),
+			}
 		}
 	}
 
@@ -172,9 +213,26 @@ func runVote(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 }
 
 func (vote VoteProposal) Signers() []action.Address {
+	if vote.isDelegatorVote() {
+		return []action.Address{vote.Address.Bytes()}
+	}
 	return []action.Address{vote.Address.Bytes(), vote.ValidatorAddress.Bytes()}
 }
 
+func (vote VoteProposal) isDelegatorVote() bool {
+	return len(vote.ValidatorAddress) == 0
+}
+
+// delegationPower converts an active network delegation to voting power, the power of the validators is their stake
+// in whole OLT
+func delegationPower(delegation *balance.Coin) int64 {
+	power := big.NewInt(0).Div(delegation.Amount.BigInt(), delegation.Currency.Base())
+	if !power.IsInt64() {
+		return math.MaxInt64
+	}
+	return power.Int64()
+}
+
 func (vote VoteProposal) Type() action.Type {
 	return action.PROPOSAL_VOTE
 }
@@ -29,13 +29,13 @@ func (wp WithdrawFunds) Validate(ctx *action.Context, signedTx action.SignedTx)
 	}
 
 	//validate basic signature
-	err = action.ValidateBasic(signedTx.RawBytes(), withdrawFunds.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), withdrawFunds.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
 	//validate fee
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 
 	if err != nil {
 		return false, err
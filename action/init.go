@@ -13,14 +13,18 @@ type TxTypeMap map[Type]string
 
 var txTypeMap TxTypeMap
 
+// msgTypes are the constructors of the messages which can be sent in a batch
+var msgTypes = map[Type]func() Msg{}
+
 const (
 	SEND     Type = 0x01
 	SENDPOOL Type = 0x02
 
 	//staking related transaction
-	STAKE    Type = 0x11
-	UNSTAKE  Type = 0x12
-	WITHDRAW Type = 0x13
+	STAKE          Type = 0x11
+	UNSTAKE        Type = 0x12
+	WITHDRAW       Type = 0x13
+	EDIT_VALIDATOR Type = 0x14
 
 	//network network_delegation
 	ADD_NETWORK_DELEGATE              Type = 0x51
@@ -41,6 +45,7 @@ const (
 	DOMAIN_SEND       Type = 0x25
 	DOMAIN_DELETE_SUB Type = 0x26
 	DOMAIN_RENEW      Type = 0x27
+	DOMAIN_SET_RECORD Type = 0x28
 
 	BTC_LOCK                   Type = 0x81
 	BTC_ADD_SIGNATURE          Type = 0x82
@@ -72,6 +77,24 @@ const (
 	// OLVM transactions (new sends + evm)
 	OLVM Type = 0x101
 
+	//Batch of transactions
+	BATCH Type = 0x71
+
+	//Fee grants
+	FEE_GRANT  Type = 0xA1
+	FEE_REVOKE Type = 0xA2
+
+	//Multisig accounts
+	MULTISIG_ACCOUNT_CREATE Type = 0xB1
+
+	//Vesting accounts
+	CREATE_VESTING Type = 0xC1
+
+	//Token factory
+	TOKEN_CREATE Type = 0xD1
+	TOKEN_MINT   Type = 0xD2
+	TOKEN_BURN   Type = 0xD3
+
 	//EOF here Only used as a marker to mark the end of Type list
 	//So that the query for Types can return all Types dynamically
 	//, when there is a change made in Type list
@@ -92,6 +115,7 @@ func init() {
 	RegisterTxType(STAKE, "STAKE")
 	RegisterTxType(UNSTAKE, "UNSTAKE")
 	RegisterTxType(WITHDRAW, "WITHDRAW")
+	RegisterTxType(EDIT_VALIDATOR, "EDIT_VALIDATOR")
 
 	RegisterTxType(DOMAIN_CREATE, "DOMAIN_CREATE")
 	RegisterTxType(DOMAIN_UPDATE, "DOMAIN_UPDATE")
@@ -100,6 +124,7 @@ func init() {
 	RegisterTxType(DOMAIN_SEND, "DOMAIN_SEND")
 	RegisterTxType(DOMAIN_DELETE_SUB, "DOMAIN_DELETE_SUB")
 	RegisterTxType(DOMAIN_RENEW, "DOMAIN_RENEW")
+	RegisterTxType(DOMAIN_SET_RECORD, "DOMAIN_SET_RECORD")
 
 	RegisterTxType(BTC_LOCK, "BTC_LOCK")
 	RegisterTxType(BTC_ADD_SIGNATURE, "BTC_ADD_SIGNATURE")
@@ -135,6 +160,19 @@ func init() {
 	RegisterTxType(RELEASE, "RELEASE")
 
 	RegisterTxType(OLVM, "OLVM")
+
+	RegisterTxType(BATCH, "BATCH")
+
+	RegisterTxType(FEE_GRANT, "FEE_GRANT")
+	RegisterTxType(FEE_REVOKE, "FEE_REVOKE")
+
+	RegisterTxType(MULTISIG_ACCOUNT_CREATE, "MULTISIG_ACCOUNT_CREATE")
+
+	RegisterTxType(CREATE_VESTING, "CREATE_VESTING")
+
+	RegisterTxType(TOKEN_CREATE, "TOKEN_CREATE")
+	RegisterTxType(TOKEN_MINT, "TOKEN_MINT")
+	RegisterTxType(TOKEN_BURN, "TOKEN_BURN")
 }
 
 func RegisterTxType(value Type, name string) {
@@ -145,6 +183,25 @@ func RegisterTxType(value Type, name string) {
 	txTypeMap[value] = name
 }
 
+// RegisterMsgType registers the constructor of the message of the tx type, only the registered messages can be sent
+// in a batch
+func RegisterMsgType(value Type, newMsg func() Msg) {
+	if _, ok := msgTypes[value]; ok {
+		logger.Errorf("Trying to register msg type %s failed, type value conflicts with existing type", value)
+		return
+	}
+	msgTypes[value] = newMsg
+}
+
+// NewMsg returns an empty message of the tx type, ErrWrongTxType if the message isn't registered
+func NewMsg(t Type) (Msg, error) {
+	newMsg, ok := msgTypes[t]
+	if !ok {
+		return nil, ErrWrongTxType
+	}
+	return newMsg(), nil
+}
+
 func (t Type) String() string {
 	if name, ok := txTypeMap[t]; ok {
 		return name
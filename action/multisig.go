@@ -0,0 +1,49 @@
+package action
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+// ValidateMultiSig checks the signature bundle over the raw tx bytes reaches the threshold of the multisig account
+// registered at the signer address
+func ValidateMultiSig(ctx *Context, data []byte, signer Address, bundle keys.MultiSig) error {
+	if ctx == nil || ctx.MultiSigAccounts == nil {
+		return errors.Wrap(ErrInvalidSignature, "multisig accounts not available")
+	}
+	account, err := ctx.MultiSigAccounts.Get(signer)
+	if err != nil {
+		return errors.Wrap(err, signer.String())
+	}
+	return account.Verify(data, bundle)
+}
+
+// bundleSignatures returns the signatures verified for the multisig bundles of the transaction beyond the one each
+// signer is charged for, the gas of every signature verified is charged
+func bundleSignatures(tx SignedTx) Gas {
+	bundles := make([]*keys.MultiSig, 0, len(tx.Signatures)+1)
+	for _, s := range tx.Signatures {
+		bundles = append(bundles, s.MultiSig)
+	}
+	if tx.FeePayerSignature != nil {
+		bundles = append(bundles, tx.FeePayerSignature.MultiSig)
+	}
+
+	extra := Gas(0)
+	for _, bundle := range bundles {
+		if bundle == nil {
+			continue
+		}
+		signed := Gas(0)
+		for _, s := range bundle.Signatures {
+			if len(s.Signed) != 0 {
+				signed++
+			}
+		}
+		if signed > 1 {
+			extra += signed - 1
+		}
+	}
+	return extra
+}
@@ -0,0 +1,135 @@
+package multisig
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	"github.com/Oneledger/protocol/config"
+	ms "github.com/Oneledger/protocol/data/multisig"
+)
+
+var _ action.Msg = &CreateAccount{}
+
+// CreateAccount registers the multisig account where Threshold of the Members must sign, the account address is
+// derived from this policy. The creator pays the fee, it doesn't need to be one of the members.
+type CreateAccount struct {
+	Creator   action.Address   `json:"creator"`
+	Threshold int              `json:"threshold"`
+	Members   []action.Address `json:"members"`
+}
+
+func (c CreateAccount) Marshal() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func (c *CreateAccount) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+func (c CreateAccount) Signers() []action.Address {
+	return []action.Address{c.Creator}
+}
+
+func (c CreateAccount) Type() action.Type {
+	return action.MULTISIG_ACCOUNT_CREATE
+}
+
+func (c CreateAccount) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(c.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.creator"),
+		Value: c.Creator.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.account"),
+		Value: ms.AccountAddress(c.Threshold, c.Members).Bytes(),
+	}
+	tag4 := kv.Pair{
+		Key:   []byte("tx.threshold"),
+		Value: []byte(strconv.Itoa(c.Threshold)),
+	}
+
+	tags = append(tags, tag, tag2, tag3, tag4)
+	return tags
+}
+
+var _ action.Tx = createAccountTx{}
+
+type createAccountTx struct{}
+
+func (createAccountTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	create := &CreateAccount{}
+	err := create.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateBasic(ctx, tx.RawBytes(), create.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if create.Creator.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+	_, err = ms.NewAccount(create.Threshold, create.Members)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (createAccountTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Multisig Account Create Transaction for CheckTx", tx)
+	return runCreateAccount(ctx, tx)
+}
+
+func (createAccountTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Multisig Account Create Transaction for DeliverTx", tx)
+	return runCreateAccount(ctx, tx)
+}
+
+func (createAccountTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runCreateAccount(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	create := &CreateAccount{}
+	err := create.Unmarshal(tx.Data)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrUnserializable, create.Tags(), err)
+	}
+
+	if !ctx.IsForkActive(config.MultiSigFork) {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrWrongTxType, create.Tags(), errors.New("multisig accounts not supported yet"))
+	}
+
+	account, err := ms.NewAccount(create.Threshold, create.Members)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, ms.ErrInvalidPolicy, create.Tags(), err)
+	}
+	// the address of a multisig account has no key, but it can already hold the funds sent to it
+	if ctx.MultiSigAccounts.Exists(account.Address) {
+		return helpers.LogAndReturnFalse(ctx.Logger, ms.ErrAccountExists, create.Tags(), errors.New(account.Address.String()))
+	}
+	err = ctx.MultiSigAccounts.Set(account)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrUnserializable, create.Tags(), err)
+	}
+	return helpers.LogAndReturnTrue(ctx.Logger, create.Tags(), "multisig_account_create")
+}
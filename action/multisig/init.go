@@ -0,0 +1,22 @@
+package multisig
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+func init() {
+	serialize.RegisterConcrete(new(CreateAccount), "action_multisig_account_create")
+
+	action.RegisterMsgType(action.MULTISIG_ACCOUNT_CREATE, func() action.Msg { return &CreateAccount{} })
+}
+
+func EnableMultiSig(r action.Router) error {
+	err := r.AddHandler(action.MULTISIG_ACCOUNT_CREATE, createAccountTx{})
+	if err != nil {
+		return errors.Wrap(err, "createAccountTx")
+	}
+	return nil
+}
@@ -18,6 +18,8 @@ var _ action.Msg = &AddNetworkDelegation{}
 type AddNetworkDelegation struct {
 	DelegationAddress keys.Address  `json:"delegationAddress"`
 	Amount            action.Amount `json:"amount"`
+	// the validator the delegation goes to, the delegation pool when empty
+	Validator keys.Address `json:"validator,omitempty"`
 }
 
 func (n AddNetworkDelegation) Signers() []action.Address {
@@ -62,12 +64,12 @@ func (n addNetworkDelegationTx) Validate(ctx *action.Context, tx action.SignedTx
 	if err != nil {
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
-	err = action.ValidateBasic(tx.RawBytes(), delegate.Signers(), tx.Signatures)
+	err = action.ValidateBasic(ctx, tx.RawBytes(), delegate.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -76,6 +78,12 @@ func (n addNetworkDelegationTx) Validate(ctx *action.Context, tx action.SignedTx
 		return false, err
 	}
 
+	if len(delegate.Validator) != 0 {
+		if err := delegate.Validator.Err(); err != nil {
+			return false, err
+		}
+	}
+
 	return true, nil
 }
 
@@ -117,7 +125,7 @@ func runNetworkDelegate(ctx *action.Context, tx action.RawTx) (bool, action.Resp
 	}
 
 	//Deduct Delegation Amount
-	err = ctx.Balances.MinusFromAddress(delegate.DelegationAddress, coin)
+	err = ctx.Balances.DelegateFromAddress(delegate.DelegationAddress, coin)
 	if err != nil {
 		return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrBalanceErrorMinusFailed, delegate.Tags(), err)
 	}
@@ -137,11 +145,36 @@ func runNetworkDelegate(ctx *action.Context, tx action.RawTx) (bool, action.Resp
 
 	//Add balance to delegation
 	currentDelegation, _ := ctx.NetwkDelegators.Deleg.WithPrefix(network_delegation.ActiveType).Get(delegate.DelegationAddress)
+
+	//Pick the validator of the delegation, it can only change once nothing is delegated. A delegation without a
+	//validator keeps the current one, as the delegations sent before the validators could be picked.
+	validator, err := ctx.NetwkDelegators.Deleg.GetValidator(delegate.DelegationAddress)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, network_delegation.ErrGettingActiveDelgAmount, delegate.Tags(), err)
+	}
+	keepValidator := len(delegate.Validator) == 0 && !currentDelegation.Amount.IsZero()
+	if !keepValidator && !validator.Equal(delegate.Validator) {
+		if !currentDelegation.Amount.IsZero() {
+			return helpers.LogAndReturnFalse(ctx.Logger, network_delegation.ErrValidatorMismatch, delegate.Tags(), errors.New(validator.String()))
+		}
+		if len(delegate.Validator) != 0 && (!ValidatorDelegationActive(ctx) || !ctx.Validators.Exists(delegate.Validator)) {
+			return helpers.LogAndReturnFalse(ctx.Logger, network_delegation.ErrValidatorNotFound, delegate.Tags(), errors.New(delegate.Validator.String()))
+		}
+		err = ctx.NetwkDelegators.Deleg.SetValidator(delegate.DelegationAddress, delegate.Validator)
+		if err != nil {
+			return helpers.LogAndReturnFalse(ctx.Logger, network_delegation.ErrSettingActiveDelgAmount, delegate.Tags(), err)
+		}
+	}
+
 	newCoin := currentDelegation.Plus(coin)
 	err = ctx.NetwkDelegators.Deleg.WithPrefix(network_delegation.ActiveType).Set(delegate.DelegationAddress, &newCoin)
 	if err != nil {
 		return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrBalanceErrorAddFailed, delegate.Tags(), err)
 	}
+	err = SetStake(ctx, delegate.DelegationAddress, currentDelegation, &newCoin)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, network_delegation.ErrSettingActiveDelgAmount, delegate.Tags(), err)
+	}
 
 	return helpers.LogAndReturnTrue(ctx.Logger, delegate.Tags(), "Success")
 }
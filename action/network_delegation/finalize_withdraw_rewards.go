@@ -69,12 +69,12 @@ package network_delegation
 //	}
 //
 //	// validate basic signature
-//	err = action.ValidateBasic(tx.RawBytes(), w.Signers(), tx.Signatures)
+//	err = action.ValidateBasic(ctx, tx.RawBytes(), w.Signers(), tx.Signatures)
 //	if err != nil {
 //		return false, err
 //	}
 //
-//	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+//	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 //	if err != nil {
 //		return false, err
 //	}
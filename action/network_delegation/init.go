@@ -2,9 +2,18 @@ package network_delegation
 
 import (
 	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
 	"github.com/pkg/errors"
 )
 
+func init() {
+	action.RegisterMsgType(action.ADD_NETWORK_DELEGATE, func() action.Msg { return &AddNetworkDelegation{} })
+	action.RegisterMsgType(action.NETWORK_UNDELEGATE, func() action.Msg { return &Undelegate{} })
+	action.RegisterMsgType(action.REWARDS_WITHDRAW_NETWORK_DELEGATE, func() action.Msg { return &Withdraw{} })
+	action.RegisterMsgType(action.REWARDS_REINVEST_NETWORK_DELEGATE, func() action.Msg { return &Reinvest{} })
+}
+
 func EnableNetworkDelegation(r action.Router) error {
 	err := r.AddHandler(action.ADD_NETWORK_DELEGATE, addNetworkDelegationTx{})
 	if err != nil {
@@ -35,3 +44,37 @@ func EnableNetworkDelegation(r action.Router) error {
 	//}
 	return nil
 }
+
+// ValidatorDelegationActive tells whether the delegators can pick a validator, its rewards go through the reward index
+func ValidatorDelegationActive(ctx *action.Context) bool {
+	return ctx.IsForkActive(config.LazyRewardsFork) && ctx.IsForkActive(config.ValidatorDelegationFork)
+}
+
+// SetStake settles the rewards of the delegator with its stake before the change, its next rewards are computed from
+// the new active delegation. The rewards were credited at every block before the reward index. The power of the
+// validator picked by the delegator follows its active delegation.
+func SetStake(ctx *action.Context, delegator action.Address, previous, active *balance.Coin) error {
+	validator, err := ctx.NetwkDelegators.Deleg.GetValidator(delegator)
+	if err != nil {
+		return err
+	}
+	if len(validator) != 0 {
+		power, err := ctx.NetwkDelegators.Deleg.GetValidatorPower(validator)
+		if err != nil {
+			return err
+		}
+		newPower, err := power.Plus(*active).Minus(*previous)
+		if err != nil {
+			return err
+		}
+		err = ctx.NetwkDelegators.Deleg.SetValidatorPower(validator, &newPower)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !ctx.IsForkActive(config.LazyRewardsFork) {
+		return nil
+	}
+	return ctx.NetwkDelegators.Rewards.SetStake(delegator, validator, active.Amount)
+}
@@ -69,12 +69,12 @@ func (u undelegateTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, e
 	}
 
 	// validate basic signature
-	err = action.ValidateBasic(tx.RawBytes(), ud.Signers(), tx.Signatures)
+	err = action.ValidateBasic(ctx, tx.RawBytes(), ud.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -126,6 +126,10 @@ func runUndelegate(ctx *action.Context, tx action.RawTx) (bool, action.Response)
 	if err != nil {
 		return helpers.LogAndReturnFalse(ctx.Logger, net_delg.ErrSettingActiveDelgAmount, ud.Tags(), err)
 	}
+	err = SetStake(ctx, ud.Delegator, delegationCoin, &remainCoin)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, net_delg.ErrSettingActiveDelgAmount, ud.Tags(), err)
+	}
 
 	// get mature height
 	delegationOptions, err := ctx.GovernanceStore.GetNetworkDelegOptions()
@@ -64,12 +64,12 @@ func (delegReinvestRewardsTx) Validate(ctx *action.Context, signedTx action.Sign
 		return false, err
 	}
 
-	err = action.ValidateBasic(signedTx.RawBytes(), invest.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), invest.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -138,6 +138,10 @@ func runReinvest(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 	if err != nil {
 		return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrBalanceErrorAddFailed, invest.Tags(), err)
 	}
+	err = SetStake(ctx, invest.Delegator, currentDelegation, &newCoin)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, network_delegation.ErrSettingActiveDelgAmount, invest.Tags(), err)
+	}
 
 	ctx.Logger.Debugf("Successfully reinvested, delegator= %s, amount= %s", invest.Delegator.String(), coinAmt)
 	return helpers.LogAndReturnTrue(ctx.Logger, invest.Tags(), "Success")
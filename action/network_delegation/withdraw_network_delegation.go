@@ -51,12 +51,12 @@ package network_delegation
 //	if err != nil {
 //		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 //	}
-//	err = action.ValidateBasic(tx.RawBytes(), withdraw.Signers(), tx.Signatures)
+//	err = action.ValidateBasic(ctx, tx.RawBytes(), withdraw.Signers(), tx.Signatures)
 //	if err != nil {
 //		return false, err
 //	}
 //
-//	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+//	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 //	if err != nil {
 //		return false, err
 //	}
@@ -0,0 +1,99 @@
+package action
+
+import (
+	"github.com/pkg/errors"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+// noNonceTxs don't carry the account nonce: OLVM transactions have the nonce of the evm transaction
+// and the internal ones are broadcast by the validator jobs, which are deduplicated by the job itself
+var noNonceTxs = map[Type]bool{
+	OLVM:                       true,
+	ETH_REPORT_FINALITY_MINT:   true,
+	BTC_ADD_SIGNATURE:          true,
+	BTC_BROADCAST_SUCCESS:      true,
+	BTC_REPORT_FINALITY_MINT:   true,
+	BTC_FAILED_BROADCAST_RESET: true,
+	PROPOSAL_FINALIZE:          true,
+	EXPIRE_VOTES:               true,
+}
+
+// HasNonce tells whether the transaction type must carry the nonce of the signer account
+func HasNonce(t Type) bool {
+	return !noNonceTxs[t]
+}
+
+// NonceRequired tells whether the transaction must carry the nonce of the signer account at the current block
+func NonceRequired(ctx *Context, tx SignedTx) bool {
+	return ctx.IsForkActive(config.NonceFork) && HasNonce(tx.Type)
+}
+
+// NonceSigner returns the account whose nonce is used by the transaction, the same one paying the fee
+func NonceSigner(tx SignedTx) (keys.Address, error) {
+	if len(tx.Signatures) == 0 {
+		return nil, ErrUnmatchSigner
+	}
+	addr, err := tx.Signatures[0].Address()
+	if err != nil {
+		return nil, ErrInvalidPubkey
+	}
+	return addr, nil
+}
+
+// ValidateNonce checks the nonce of the transaction is the current sequence of the signer account
+func ValidateNonce(ctx *Context, tx SignedTx) error {
+	addr, err := NonceSigner(tx)
+	if err != nil {
+		return err
+	}
+	nonce := ctx.StateDB.GetAccountKeeper().GetNonce(addr)
+	if tx.Nonce != nonce {
+		return errors.Wrapf(ErrInvalidNonce, "expected %d, got %d", nonce, tx.Nonce)
+	}
+	return nil
+}
+
+// IncrementNonce bumps the sequence of the signer account, the same sequence is used by the evm
+func IncrementNonce(ctx *Context, tx SignedTx) error {
+	addr, err := NonceSigner(tx)
+	if err != nil {
+		return err
+	}
+	keeper := ctx.StateDB.GetAccountKeeper()
+	acc, err := keeper.GetAccount(addr)
+	if err == balance.ErrAccountNotFound {
+		acc, err = keeper.NewAccountWithAddress(addr)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get account")
+	}
+	acc.Sequence++
+	return keeper.SetAccount(*acc)
+}
+
+// PendingNonce returns the nonce of the next transaction of the address, the transactions waiting in the
+// mempool are counted on top of the sequence committed at the given height
+func PendingNonce(keeper balance.AccountKeeper, addr keys.Address, height int64, pending tmtypes.Txs) uint64 {
+	var nonce uint64
+	acc, err := keeper.GetVersionedAccount(addr, height)
+	if err == nil {
+		nonce = acc.Sequence
+	}
+	for _, rawTx := range pending {
+		tx := &SignedTx{}
+		err := serialize.GetSerializer(serialize.NETWORK).Deserialize(rawTx, tx)
+		if err != nil || noNonceTxs[tx.Type] && tx.Type != OLVM {
+			continue
+		}
+		signer, err := NonceSigner(*tx)
+		if err == nil && signer.Equal(addr) {
+			nonce++
+		}
+	}
+	return nonce
+}
@@ -0,0 +1,119 @@
+package action
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmtypes "github.com/tendermint/tendermint/types"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/evm"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/vm"
+)
+
+func assemblyNonceCtx(t *testing.T) *Context {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	currencies := balance.NewCurrencySet()
+	err := currencies.Register(balance.Currency{Name: "OLT", Decimal: 18, Unit: "nue"})
+	assert.NoError(t, err)
+
+	keeper := balance.NewNesterAccountKeeper(state, balance.NewStore("b", state), currencies)
+	return &Context{
+		State:   state,
+		StateDB: vm.NewCommitStateDB(evm.NewContractStore(state), keeper, log.NewLoggerWithPrefix(os.Stdout, "test")),
+	}
+}
+
+func assemblyNonceTx(t Type, nonce uint64) (SignedTx, keys.Address) {
+	priKey := ed25519.GenPrivKey()
+	pubKey := priKey.PubKey()
+
+	tx := RawTx{
+		Type:  t,
+		Data:  []byte("data"),
+		Memo:  "test_memo",
+		Nonce: nonce,
+	}
+	signature, _ := priKey.Sign(tx.RawBytes())
+	return SignedTx{
+		RawTx: tx,
+		Signatures: []Signature{{
+			Signer: keys.PublicKey{KeyType: keys.ED25519, Data: pubKey.Bytes()[5:]},
+			Signed: signature,
+		}},
+	}, pubKey.Address().Bytes()
+}
+
+func TestRawTx_NonceEncoding(t *testing.T) {
+	tx := RawTx{Type: SEND, Data: []byte("data"), Memo: "test_memo"}
+	assert.NotContains(t, string(tx.RawBytes()), "nonce")
+
+	tx.Nonce = 1
+	assert.Contains(t, string(tx.RawBytes()), "nonce")
+}
+
+func TestValidateNonce(t *testing.T) {
+	ctx := assemblyNonceCtx(t)
+	tx, addr := assemblyNonceTx(SEND, 0)
+
+	assert.NoError(t, ValidateNonce(ctx, tx))
+
+	assert.NoError(t, IncrementNonce(ctx, tx))
+	assert.Equal(t, uint64(1), ctx.StateDB.GetAccountKeeper().GetNonce(addr))
+
+	// the same transaction can't be processed again
+	assert.Error(t, ValidateNonce(ctx, tx))
+
+	tx.Nonce = 1
+	assert.NoError(t, ValidateNonce(ctx, tx))
+	tx.Nonce = 2
+	assert.Error(t, ValidateNonce(ctx, tx))
+}
+
+func TestHasNonce(t *testing.T) {
+	assert.True(t, HasNonce(SEND))
+	assert.True(t, HasNonce(PROPOSAL_CREATE))
+	assert.False(t, HasNonce(OLVM))
+	assert.False(t, HasNonce(EXPIRE_VOTES))
+}
+
+func TestNonceRequired(t *testing.T) {
+	tx, _ := assemblyNonceTx(SEND, 0)
+	ctx := &Context{Header: &abci.Header{Height: 10}}
+	assert.False(t, NonceRequired(ctx, tx))
+
+	ctx.Forks = &config.ForkParams{NonceBlock: 11}
+	assert.False(t, NonceRequired(ctx, tx))
+
+	ctx.Forks.NonceBlock = 10
+	assert.True(t, NonceRequired(ctx, tx))
+
+	olvm, _ := assemblyNonceTx(OLVM, 0)
+	assert.False(t, NonceRequired(ctx, olvm))
+}
+
+func TestPendingNonce(t *testing.T) {
+	ctx := assemblyNonceCtx(t)
+	tx, addr := assemblyNonceTx(SEND, 0)
+	other, _ := assemblyNonceTx(SEND, 0)
+
+	pending := tmtypes.Txs{}
+	for _, signed := range []SignedTx{tx, other, tx} {
+		packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(signed)
+		assert.NoError(t, err)
+		pending = append(pending, packet)
+	}
+
+	keeper := ctx.StateDB.GetAccountKeeper()
+	assert.Equal(t, uint64(2), PendingNonce(keeper, addr, ctx.State.Version(), pending))
+	assert.Equal(t, uint64(0), PendingNonce(keeper, addr, ctx.State.Version(), nil))
+}
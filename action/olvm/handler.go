@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/utils"
 	"github.com/Oneledger/protocol/vm"
@@ -42,9 +43,12 @@ type Transaction struct {
 	Amount  action.Amount   `json:"amount"`
 	Data    []byte          `json:"data"`
 	ChainID *big.Int        `json:"chainID"`
-	// for future
+	// the ethereum transaction type, the access list of the typed transactions and the fee caps of the dynamic
+	// fee ones, those pay the effective gas price at the base fee of the block instead of the fee price of the raw tx
 	TxType     int64                `json:"type"`
 	AccessList *ethtypes.AccessList `json:"accessList"`
+	GasFeeCap  *big.Int             `json:"gasFeeCap,omitempty"`
+	GasTipCap  *big.Int             `json:"gasTipCap,omitempty"`
 }
 
 func (tx Transaction) Marshal() ([]byte, error) {
@@ -109,6 +113,35 @@ func (tx *Transaction) tmToEthTx(ctx *action.Context, raw action.RawTx) *ethtype
 		to = new(ethcmn.Address)
 		*to = ethcmn.BytesToAddress(tx.To.Bytes())
 	}
+	var accesses ethtypes.AccessList
+	if tx.AccessList != nil {
+		accesses = *tx.AccessList
+	}
+	switch tx.TxType {
+	case ethtypes.AccessListTxType:
+		return ethtypes.NewTx(&ethtypes.AccessListTx{
+			ChainID:    tx.ChainID,
+			Nonce:      tx.Nonce,
+			To:         to,
+			Value:      tx.Amount.Value.BigInt(),
+			Gas:        uint64(raw.Fee.Gas),
+			GasPrice:   raw.Fee.Price.Value.BigInt(),
+			Data:       tx.Data,
+			AccessList: accesses,
+		})
+	case ethtypes.DynamicFeeTxType:
+		return ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID:    tx.ChainID,
+			Nonce:      tx.Nonce,
+			To:         to,
+			Value:      tx.Amount.Value.BigInt(),
+			Gas:        uint64(raw.Fee.Gas),
+			GasTipCap:  tx.GasTipCap,
+			GasFeeCap:  tx.GasFeeCap,
+			Data:       tx.Data,
+			AccessList: accesses,
+		})
+	}
 	ethTx := ethtypes.NewTx(&ethtypes.LegacyTx{
 		Nonce:    tx.Nonce,
 		To:       to,
@@ -121,7 +154,54 @@ func (tx *Transaction) tmToEthTx(ctx *action.Context, raw action.RawTx) *ethtype
 }
 
 func (tx *Transaction) getEthSigner(ctx *action.Context) ethtypes.Signer {
-	return ethtypes.NewEIP155Signer(utils.HashToBigInt(ctx.Header.ChainID))
+	return ethtypes.NewLondonSigner(utils.HashToBigInt(ctx.Header.ChainID))
+}
+
+// validateTxType checks the ethereum transaction type is known and enabled. The gas price of the dynamic fee
+// transactions isn't signed by their sender, only the caps are, it can't be above the fee cap.
+func (tx *Transaction) validateTxType(ctx *action.Context, fee action.Fee) error {
+	switch tx.TxType {
+	case ethtypes.LegacyTxType:
+		return nil
+	case ethtypes.AccessListTxType, ethtypes.DynamicFeeTxType:
+		if !ctx.IsForkActive(config.TypedTxFork) {
+			return ethtypes.ErrTxTypeNotSupported
+		}
+	default:
+		return ethtypes.ErrTxTypeNotSupported
+	}
+	if tx.TxType != ethtypes.DynamicFeeTxType {
+		return nil
+	}
+	if tx.GasFeeCap == nil || tx.GasTipCap == nil {
+		return errors.New("missing fee caps")
+	}
+	if tx.GasFeeCap.Cmp(tx.GasTipCap) < 0 {
+		return ethcore.ErrTipAboveFeeCap
+	}
+	if fee.Price.Value.BigInt().Cmp(tx.GasFeeCap) > 0 {
+		return ethcore.ErrFeeCapTooLow
+	}
+	return nil
+}
+
+// gasPrice is the gas price the transaction pays, the dynamic fee transactions pay the effective gas price at the
+// base fee of the block whatever the unsigned fee price of the raw tx is
+func (tx *Transaction) gasPrice(ctx *action.Context, fee action.Fee) *big.Int {
+	if tx.TxType != ethtypes.DynamicFeeTxType || tx.GasFeeCap == nil || tx.GasTipCap == nil {
+		return fee.Price.Value.BigInt()
+	}
+	return effectiveGasPrice(tx.GasFeeCap, tx.GasTipCap, ctx.FeePool.MinFee().Amount.BigInt())
+}
+
+// effectiveGasPrice is the gas price a dynamic fee transaction pays at the base fee, the tip on top of the base fee
+// up to the fee cap
+func effectiveGasPrice(feeCap, tipCap, baseFee *big.Int) *big.Int {
+	price := new(big.Int).Add(baseFee, tipCap)
+	if price.Cmp(feeCap) > 0 {
+		return new(big.Int).Set(feeCap)
+	}
+	return price
 }
 
 func (tx *Transaction) validateSigner(ctx *action.Context, signedTx action.SignedTx) error {
@@ -155,10 +235,6 @@ func (tx *Transaction) validateSigner(ctx *action.Context, signedTx action.Signe
 // validateEthTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (tx *Transaction) validateEthTx(keeper balance.AccountKeeper, ethTx *ethtypes.Transaction, minFee *big.Int, local bool) error {
-	// Accept only legacy transactions until EIP-2718/2930 activates.
-	if ethTx.Type() != ethtypes.LegacyTxType {
-		return ethtypes.ErrTxTypeNotSupported
-	}
 	// Reject transactions over defined size to prevent DOS attacks
 	if uint64(ethTx.Size()) > txMaxSize {
 		return ethcore.ErrOversizedData
@@ -218,13 +294,18 @@ func (otx olvmTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bool,
 		return false, err
 	}
 
+	err = tx.validateTxType(ctx, signedTx.Fee)
+	if err != nil {
+		return false, err
+	}
+
 	//validate basic signature
 	err = tx.validateSigner(ctx, signedTx)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -241,7 +322,7 @@ func (otx olvmTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bool,
 	err = tx.validateEthTx(
 		ctx.StateDB.GetAccountKeeper(),
 		tx.tmToEthTx(ctx, signedTx.RawTx),
-		ctx.FeePool.GetOpt().MinFee().Amount.BigInt(),
+		ctx.FeePool.MinFee().Amount.BigInt(),
 		true,
 	)
 	if err != nil {
@@ -273,6 +354,10 @@ func (otx olvmTx) ProcessDeliver(ctx *action.Context, rawTx action.RawTx) (ok bo
 }
 
 func (otx olvmTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (ok bool, result action.Response) {
+	tx := &Transaction{}
+	if err := tx.Unmarshal(signedTx.Data); err == nil {
+		signedTx.Fee.Price.Value = *balance.NewAmountFromBigInt(tx.gasPrice(ctx, signedTx.Fee))
+	}
 	ok, result = action.ContractFeeHandling(ctx, signedTx, gasUsed, start)
 	ctx.Logger.Detailf("Processing OLVM Transaction for BasicFeeHandling: status - %t\n", ok)
 	return ok, result
@@ -285,6 +370,12 @@ func runOLVM(ctx *action.Context, rawTx action.RawTx) (bool, action.Response) {
 		return ResponseFailed(tx.Tags(), err, action.WrongFee)
 	}
 
+	// the fee cap of a dynamic fee tx must cover the base fee of the block
+	price := tx.gasPrice(ctx, rawTx.Fee)
+	if tx.TxType == ethtypes.DynamicFeeTxType && price.Cmp(ctx.FeePool.MinFee().Amount.BigInt()) < 0 {
+		return ResponseFailed(tx.Tags(), ethcore.ErrFeeCapTooLow, action.WrongFee)
+	}
+
 	evmTx := vm.NewEVMTransaction(
 		ctx.StateDB,
 		new(ethcore.GasPool).AddGas(ctx.StateDB.GetAvailableGas()),
@@ -296,11 +387,12 @@ func runOLVM(ctx *action.Context, rawTx action.RawTx) (bool, action.Response) {
 		tx.Data,
 		tx.AccessList,
 		uint64(rawTx.Fee.Gas),
-		rawTx.Fee.Price.Value.BigInt(),
+		price,
 		false,
 	)
 
 	evmTx.SetVMDebug(enableVMDebug)
+	evmTx.SetNativeContracts(NativeContracts(ctx))
 
 	tags := tx.Tags()
 
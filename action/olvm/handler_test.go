@@ -21,14 +21,17 @@ import (
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/keys"
+	net_delg "github.com/Oneledger/protocol/data/network_delegation"
 	"github.com/Oneledger/protocol/data/rewards"
 	"github.com/Oneledger/protocol/log"
 	"github.com/Oneledger/protocol/storage"
 	"github.com/Oneledger/protocol/utils"
 	"github.com/Oneledger/protocol/vm"
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	ethparams "github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/assert"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
@@ -276,6 +279,64 @@ func assemblyExecuteData(from keys.Address, to *keys.Address, nonce uint64, valu
 	return signed
 }
 
+func assemblyTypedData(from keys.Address, chainID *big.Int, fromPrikey *ecdsa.PrivateKey, txData ethtypes.TxData, price *big.Int) action.SignedTx {
+	signer := ethtypes.NewLondonSigner(chainID)
+	tx, err := ethtypes.SignNewTx(fromPrikey, signer, txData)
+	if err != nil {
+		panic(err)
+	}
+
+	accesses := tx.AccessList()
+	av := &Transaction{
+		From:       from,
+		Amount:     action.Amount{Currency: "OLT", Value: *balance.NewAmountFromBigInt(tx.Value())},
+		Data:       tx.Data(),
+		Nonce:      tx.Nonce(),
+		ChainID:    tx.ChainId(),
+		TxType:     int64(tx.Type()),
+		AccessList: &accesses,
+	}
+	if tx.To() != nil {
+		to := keys.Address(tx.To().Bytes())
+		av.To = &to
+	}
+	if tx.Type() == ethtypes.DynamicFeeTxType {
+		av.GasFeeCap = tx.GasFeeCap()
+		av.GasTipCap = tx.GasTipCap()
+	}
+	data, _ := av.Marshal()
+	rawTx := action.RawTx{
+		Type: av.Type(),
+		Data: data,
+		Fee: action.Fee{
+			Price: action.Amount{Currency: "OLT", Value: *balance.NewAmountFromBigInt(price)},
+			Gas:   int64(tx.Gas()),
+		},
+		Memo: strconv.FormatUint(av.Nonce, 10),
+	}
+
+	// the typed transactions sign with the y parity only
+	V, R, S := tx.RawSignatureValues()
+	V = new(big.Int).Add(V, big.NewInt(27))
+	pub, err := utils.RecoverPlain(signer.Hash(tx), R, S, V, true)
+	if err != nil {
+		panic(err)
+	}
+	pubKey, err := keys.GetPublicKeyFromBytes(ethcrypto.CompressPubkey(pub), keys.ETHSECP)
+	if err != nil {
+		panic(err)
+	}
+	return action.SignedTx{
+		RawTx: rawTx,
+		Signatures: []action.Signature{
+			{
+				Signer: pubKey,
+				Signed: utils.ToUncompressedSig(R, S, V),
+			},
+		},
+	}
+}
+
 func wrapProcessDeliver(stx *olvmTx, txHash ethcmn.Hash, ctx *action.Context, signedTx action.SignedTx, f func(ctx *action.Context, tx action.RawTx) (bool, action.Response)) (bool, action.Response) {
 	bhash := ethcmn.BytesToHash(utils.SHA2([]byte(fmt.Sprintf("block"))))
 	ctx.StateDB.Prepare(txHash)
@@ -527,6 +588,210 @@ func TestRunner_Send(t *testing.T) {
 	})
 }
 
+func TestRunner_VestingLock(t *testing.T) {
+	ctx := assemblyCtxData("OLT", 18, true, false, false, nil)
+	currency, _ := ctx.Currencies.GetCurrencyByName("OLT")
+
+	chainID := utils.HashToBigInt(ctx.Header.ChainID)
+	txHash := ethcmn.BytesToHash(utils.SHA2([]byte("test")))
+
+	from, fromPubKey, fromPrikey := generateKeyPair()
+	to, _, _ := generateKeyPair()
+	newAcc(ctx, from, 10000)
+
+	// 9000 of the 10000 OLT of the sender are locked until the end of the vesting
+	locked := currency.NewCoinFromAmount(*balance.NewAmountFromBigInt(new(big.Int).Mul(big.NewInt(9000), etherDecimals)))
+	vesting, err := balance.NewVestingAccount(from, locked, 1, 0, 1000, balance.VestingLinear, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, ctx.Balances.SetVesting(vesting))
+	ctx.Balances.State.Commit()
+
+	stx := &olvmTx{}
+
+	t.Run("test send locked coins and it is error", func(t *testing.T) {
+		value := new(big.Int).Mul(big.NewInt(2000), etherDecimals)
+		nonce := getNonce(ctx, from.Bytes())
+		tx := assemblyExecuteData(from, &to, nonce, value, chainID, fromPubKey, fromPrikey, make([]byte, 0), vm.TxGas)
+
+		ok, _ := wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.False(t, ok)
+		assert.Equal(t, big.NewInt(0), ctx.StateDB.GetBalance(ethcmn.BytesToAddress(to.Bytes())))
+	})
+
+	t.Run("test send unlocked coins and it is OK", func(t *testing.T) {
+		value := new(big.Int).Mul(big.NewInt(500), etherDecimals)
+		nonce := getNonce(ctx, from.Bytes())
+		tx := assemblyExecuteData(from, &to, nonce, value, chainID, fromPubKey, fromPrikey, make([]byte, 0), vm.TxGas)
+
+		ok, resp := wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok, resp.Log)
+		assert.Equal(t, value, ctx.StateDB.GetBalance(ethcmn.BytesToAddress(to.Bytes())))
+	})
+}
+
+func TestRunner_TypedTx(t *testing.T) {
+	ctx := assemblyCtxData("OLT", 18, true, false, false, nil)
+	ctx.Forks = &config.ForkParams{Forks: []config.ForkHeight{{Name: config.TypedTxFork, Height: 1}}}
+
+	chainID := utils.HashToBigInt(ctx.Header.ChainID)
+	gasPrice := big.NewInt(vm.DefaultGasPrice.Int64())
+	txHash := ethcmn.BytesToHash(utils.SHA2([]byte("test")))
+
+	t.Run("test send with an access list tx and it is OK", func(t *testing.T) {
+		from, _, fromPrikey := generateKeyPair()
+		to, _, _ := generateKeyPair()
+		newAcc(ctx, from, 10000)
+
+		ethTo := ethcmn.BytesToAddress(to.Bytes())
+		value := big.NewInt(100)
+		tx := assemblyTypedData(from, chainID, fromPrikey, &ethtypes.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      getNonce(ctx, from),
+			GasPrice:   gasPrice,
+			Gas:        vm.TxGas + ethparams.TxAccessListAddressGas,
+			To:         &ethTo,
+			Value:      value,
+			AccessList: ethtypes.AccessList{{Address: ethTo, StorageKeys: []ethcmn.Hash{}}},
+		}, gasPrice)
+
+		stx := &olvmTx{}
+		ok, err := stx.Validate(ctx, tx)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, _ = wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok)
+		assert.Equal(t, value, ctx.StateDB.GetBalance(ethTo))
+		assert.Equal(t, 1, int(getNonce(ctx, from)))
+	})
+
+	// the dynamic fee txs pay the base fee and their tip
+	baseFee := ctx.FeePool.MinFee().Amount.BigInt()
+
+	t.Run("test send with a dynamic fee tx and it is OK", func(t *testing.T) {
+		from, _, fromPrikey := generateKeyPair()
+		to, _, _ := generateKeyPair()
+		newAcc(ctx, from, 10000)
+
+		ethTo := ethcmn.BytesToAddress(to.Bytes())
+		value := big.NewInt(100)
+		tx := assemblyTypedData(from, chainID, fromPrikey, &ethtypes.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     getNonce(ctx, from),
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
+			Gas:       vm.TxGas,
+			To:        &ethTo,
+			Value:     value,
+		}, new(big.Int).Add(baseFee, big.NewInt(1)))
+
+		stx := &olvmTx{}
+		ok, err := stx.Validate(ctx, tx)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, _ = wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok)
+		assert.Equal(t, value, ctx.StateDB.GetBalance(ethTo))
+	})
+
+	t.Run("test dynamic fee tx paying above its fee cap and it is error", func(t *testing.T) {
+		from, _, fromPrikey := generateKeyPair()
+		to, _, _ := generateKeyPair()
+		newAcc(ctx, from, 10000)
+
+		ethTo := ethcmn.BytesToAddress(to.Bytes())
+		tx := assemblyTypedData(from, chainID, fromPrikey, &ethtypes.DynamicFeeTx{
+			ChainID:   chainID,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: gasPrice,
+			Gas:       vm.TxGas,
+			To:        &ethTo,
+			Value:     big.NewInt(100),
+		}, new(big.Int).Add(gasPrice, big.NewInt(1)))
+
+		ok, err := (&olvmTx{}).Validate(ctx, tx)
+		assert.Equal(t, ethcore.ErrFeeCapTooLow, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("test dynamic fee tx with another fee price and it pays its effective gas price", func(t *testing.T) {
+		from, _, fromPrikey := generateKeyPair()
+		to, _, _ := generateKeyPair()
+		newAcc(ctx, from, 10000)
+		ethFrom := ethcmn.BytesToAddress(from.Bytes())
+		before := ctx.StateDB.GetBalance(ethFrom)
+
+		ethTo := ethcmn.BytesToAddress(to.Bytes())
+		value := big.NewInt(100)
+		tx := assemblyTypedData(from, chainID, fromPrikey, &ethtypes.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     getNonce(ctx, from),
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
+			Gas:       vm.TxGas,
+			To:        &ethTo,
+			Value:     value,
+		}, new(big.Int).Mul(gasPrice, big.NewInt(2)))
+
+		stx := &olvmTx{}
+		ok, err := stx.Validate(ctx, tx)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, _ = wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok)
+		cost := new(big.Int).Mul(new(big.Int).Add(baseFee, big.NewInt(1)), big.NewInt(int64(vm.TxGas)))
+		assert.Equal(t, new(big.Int).Sub(before, new(big.Int).Add(value, cost)), ctx.StateDB.GetBalance(ethFrom))
+	})
+
+	t.Run("test dynamic fee tx with a fee cap below the base fee and it is error", func(t *testing.T) {
+		from, _, fromPrikey := generateKeyPair()
+		to, _, _ := generateKeyPair()
+		newAcc(ctx, from, 10000)
+
+		ethTo := ethcmn.BytesToAddress(to.Bytes())
+		feeCap := new(big.Int).Sub(baseFee, big.NewInt(1))
+		tx := assemblyTypedData(from, chainID, fromPrikey, &ethtypes.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     getNonce(ctx, from),
+			GasTipCap: big.NewInt(0),
+			GasFeeCap: feeCap,
+			Gas:       vm.TxGas,
+			To:        &ethTo,
+			Value:     big.NewInt(100),
+		}, feeCap)
+
+		stx := &olvmTx{}
+		ok, _ := wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.False(t, ok)
+		assert.Equal(t, 0, int(getNonce(ctx, from)))
+	})
+
+	t.Run("test typed tx before the fork and it is error", func(t *testing.T) {
+		from, _, fromPrikey := generateKeyPair()
+		to, _, _ := generateKeyPair()
+		newAcc(ctx, from, 10000)
+
+		ethTo := ethcmn.BytesToAddress(to.Bytes())
+		tx := assemblyTypedData(from, chainID, fromPrikey, &ethtypes.AccessListTx{
+			ChainID:  chainID,
+			GasPrice: gasPrice,
+			Gas:      vm.TxGas,
+			To:       &ethTo,
+			Value:    big.NewInt(100),
+		}, gasPrice)
+
+		ctx.Forks = &config.ForkParams{}
+		defer func() {
+			ctx.Forks = &config.ForkParams{Forks: []config.ForkHeight{{Name: config.TypedTxFork, Height: 1}}}
+		}()
+		ok, err := (&olvmTx{}).Validate(ctx, tx)
+		assert.Equal(t, ethtypes.ErrTxTypeNotSupported, err)
+		assert.False(t, ok)
+	})
+}
+
 func TestRunner_ContractWithSuicide(t *testing.T) {
 	// // SPDX-License-Identifier: MIT
 	// pragma solidity ^0.8.4;
@@ -839,3 +1104,153 @@ func TestRunner_BaseSmartContract(t *testing.T) {
 		assert.True(t, ok)
 	})
 }
+
+func TestRunner_NativeContracts(t *testing.T) {
+	txHash := ethcmn.BytesToHash(utils.SHA2([]byte("test")))
+	vm.EnableNativeContracts()
+
+	setup := func() *action.Context {
+		ctx := assemblyCtxData("OLT", 18, true, false, false, nil)
+		ctx.Forks = &config.ForkParams{Forks: []config.ForkHeight{{Name: config.NativeContractsFork, Height: 1}}}
+		ctx.NetwkDelegators = net_delg.NewMasterStore("deleg", "delegRwz", ctx.State)
+		ctx.GovernanceStore.WithHeight(0).SetNetworkDelegOptions(net_delg.Options{RewardsMaturityTime: 10})
+		return ctx
+	}
+	pool := ethcmn.BytesToAddress([]byte(net_delg.DELEGATION_POOL_KEY))
+	value := big.NewInt(1000)
+
+	// delegatingContract returns the code of a contract delegating the value it receives to no validator, it reverts
+	// after the delegation when asked to
+	delegatingContract := func(revert bool) []byte {
+		selector := stakingABI.Methods["delegate"].ID
+		runtime := append([]byte{0x63}, selector...)
+		runtime = append(runtime,
+			0x60, 0xe0, 0x1b, 0x60, 0x00, 0x52, // mstore(0, shl(224, selector))
+			0x60, 0x00, 0x60, 0x00, 0x60, 0x24, 0x60, 0x00, 0x34, 0x61, 0x10, 0x00, 0x5a, 0xf1, // call(gas, 0x1000, callvalue, 0, 36, 0, 0)
+		)
+		if revert {
+			runtime = append(runtime, 0x60, 0x00, 0x60, 0x00, 0xfd)
+		} else {
+			runtime = append(runtime, 0x00)
+		}
+		size := byte(len(runtime))
+		code := []byte{0x60, size, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, size, 0x60, 0x00, 0xf3}
+		return append(code, runtime...)
+	}
+	deploy := func(ctx *action.Context, from keys.Address, fromPubKey *ecdsa.PublicKey, fromPrikey *ecdsa.PrivateKey, code []byte) keys.Address {
+		chainID := utils.HashToBigInt(ctx.Header.ChainID)
+		nonce := getNonce(ctx, from)
+		tx := assemblyExecuteData(from, nil, nonce, big.NewInt(0), chainID, fromPubKey, fromPrikey, code, 100000)
+		stx := &olvmTx{}
+		ok, _ := wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok)
+		return ethcrypto.CreateAddress(ethcmn.BytesToAddress(from), nonce).Bytes()
+	}
+
+	t.Run("test delegate by calling the staking contract and it is OK", func(t *testing.T) {
+		ctx := setup()
+		chainID := utils.HashToBigInt(ctx.Header.ChainID)
+		from, fromPubKey, fromPrikey := generateKeyPair()
+		newAcc(ctx, from, 10000)
+
+		to := keys.Address(StakingContractAddress.Bytes())
+		input, err := stakingABI.Pack("delegate", ethcmn.Address{})
+		assert.NoError(t, err)
+		tx := assemblyExecuteData(from, &to, getNonce(ctx, from), value, chainID, fromPubKey, fromPrikey, input, 100000)
+
+		stx := &olvmTx{}
+		ok, resp := wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok)
+		assert.Equal(t, ethtypes.ReceiptStatusSuccessful, getTxStatus(resp))
+
+		active, err := ctx.NetwkDelegators.Deleg.WithPrefix(net_delg.ActiveType).Get(from)
+		assert.NoError(t, err)
+		assert.Equal(t, value, active.Amount.BigInt())
+		assert.Equal(t, value, ctx.StateDB.GetBalance(pool))
+		assert.Equal(t, big.NewInt(0), ctx.StateDB.GetBalance(StakingContractAddress))
+	})
+
+	t.Run("test delegate from a contract and it is OK", func(t *testing.T) {
+		ctx := setup()
+		chainID := utils.HashToBigInt(ctx.Header.ChainID)
+		from, fromPubKey, fromPrikey := generateKeyPair()
+		newAcc(ctx, from, 10000)
+		contract := deploy(ctx, from, fromPubKey, fromPrikey, delegatingContract(false))
+
+		tx := assemblyExecuteData(from, &contract, getNonce(ctx, from), value, chainID, fromPubKey, fromPrikey, nil, 200000)
+		stx := &olvmTx{}
+		ok, resp := wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok)
+		assert.Equal(t, ethtypes.ReceiptStatusSuccessful, getTxStatus(resp))
+
+		active, err := ctx.NetwkDelegators.Deleg.WithPrefix(net_delg.ActiveType).Get(contract)
+		assert.NoError(t, err)
+		assert.Equal(t, value, active.Amount.BigInt())
+		assert.Equal(t, value, ctx.StateDB.GetBalance(pool))
+		assert.Equal(t, big.NewInt(0), ctx.StateDB.GetBalance(ethcmn.BytesToAddress(contract)))
+	})
+
+	t.Run("test delegate from a contract reverting and it is rolled back", func(t *testing.T) {
+		ctx := setup()
+		chainID := utils.HashToBigInt(ctx.Header.ChainID)
+		from, fromPubKey, fromPrikey := generateKeyPair()
+		newAcc(ctx, from, 10000)
+		contract := deploy(ctx, from, fromPubKey, fromPrikey, delegatingContract(true))
+
+		tx := assemblyExecuteData(from, &contract, getNonce(ctx, from), value, chainID, fromPubKey, fromPrikey, nil, 200000)
+		stx := &olvmTx{}
+		ok, resp := wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok)
+		assert.Equal(t, ethtypes.ReceiptStatusFailed, getTxStatus(resp))
+
+		active, err := ctx.NetwkDelegators.Deleg.WithPrefix(net_delg.ActiveType).Get(contract)
+		assert.NoError(t, err)
+		assert.True(t, active.Amount.IsZero())
+		assert.False(t, ctx.State.Exists(append(storage.StoreKey("deleg"), contract.String()...)))
+		assert.Equal(t, big.NewInt(0), ctx.StateDB.GetBalance(pool))
+	})
+
+	t.Run("test undelegate by calling the staking contract and it is OK", func(t *testing.T) {
+		ctx := setup()
+		chainID := utils.HashToBigInt(ctx.Header.ChainID)
+		from, fromPubKey, fromPrikey := generateKeyPair()
+		newAcc(ctx, from, 10000)
+
+		to := keys.Address(StakingContractAddress.Bytes())
+		input, _ := stakingABI.Pack("delegate", ethcmn.Address{})
+		tx := assemblyExecuteData(from, &to, getNonce(ctx, from), value, chainID, fromPubKey, fromPrikey, input, 100000)
+		stx := &olvmTx{}
+		wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+
+		input, _ = stakingABI.Pack("undelegate", big.NewInt(400))
+		tx = assemblyExecuteData(from, &to, getNonce(ctx, from), big.NewInt(0), chainID, fromPubKey, fromPrikey, input, 100000)
+		ok, resp := wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok)
+		assert.Equal(t, ethtypes.ReceiptStatusSuccessful, getTxStatus(resp))
+
+		active, _ := ctx.NetwkDelegators.Deleg.WithPrefix(net_delg.ActiveType).Get(from)
+		assert.Equal(t, big.NewInt(600), active.Amount.BigInt())
+		pending, _ := ctx.NetwkDelegators.Deleg.GetPendingAmount(from, ctx.Header.Height+10)
+		assert.Equal(t, big.NewInt(400), pending.Amount.BigInt())
+		assert.Equal(t, big.NewInt(600), ctx.StateDB.GetBalance(pool))
+	})
+
+	t.Run("test call the staking contract before the fork and it is an empty account", func(t *testing.T) {
+		ctx := setup()
+		ctx.Forks = &config.ForkParams{}
+		chainID := utils.HashToBigInt(ctx.Header.ChainID)
+		from, fromPubKey, fromPrikey := generateKeyPair()
+		newAcc(ctx, from, 10000)
+
+		to := keys.Address(StakingContractAddress.Bytes())
+		input, _ := stakingABI.Pack("delegate", ethcmn.Address{})
+		tx := assemblyExecuteData(from, &to, getNonce(ctx, from), value, chainID, fromPubKey, fromPrikey, input, 100000)
+		stx := &olvmTx{}
+		ok, resp := wrapProcessDeliver(stx, txHash, ctx, tx, stx.ProcessDeliver)
+		assert.True(t, ok)
+		assert.Equal(t, ethtypes.ReceiptStatusSuccessful, getTxStatus(resp))
+		assert.Less(t, resp.GasUsed, int64(100000))
+		assert.Equal(t, value, ctx.StateDB.GetBalance(StakingContractAddress))
+		assert.Equal(t, big.NewInt(0), ctx.StateDB.GetBalance(pool))
+	})
+}
@@ -0,0 +1,62 @@
+package olvm
+
+import (
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/vm"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// the governance contract reads the proposals and their funds
+var governanceABI = mustParseABI(`[
+	{"type": "function", "name": "proposal", "stateMutability": "view",
+		"inputs": [{"name": "proposalId", "type": "string"}],
+		"outputs": [{"name": "proposalType", "type": "uint8"}, {"name": "status", "type": "uint8"},
+			{"name": "outcome", "type": "uint8"}, {"name": "proposer", "type": "address"},
+			{"name": "funds", "type": "uint256"}, {"name": "fundingGoal", "type": "uint256"},
+			{"name": "fundingDeadline", "type": "int64"}, {"name": "votingDeadline", "type": "int64"}]},
+	{"type": "function", "name": "funds", "stateMutability": "view",
+		"inputs": [{"name": "proposalId", "type": "string"}, {"name": "funder", "type": "address"}],
+		"outputs": [{"name": "amount", "type": "uint256"}]}
+]`)
+
+var governanceMethods = map[string]nativeMethod{
+	"proposal": {run: governanceProposal},
+	"funds":    {run: governanceFunds},
+}
+
+// governanceProposal returns the proposal in any of the proposal stores with its current funds
+func governanceProposal(ctx *action.Context, _ *vm.NativeCall, args []interface{}) ([]interface{}, error) {
+	pms := ctx.ProposalMasterStore
+	proposalID := governance.ProposalID(args[0].(string))
+	proposal, _, err := pms.Proposal.QueryAllStores(proposalID)
+	if err != nil {
+		return nil, governance.ErrProposalNotExists
+	}
+
+	funds := pms.ProposalFund.GetCurrentFundsForProposal(proposalID)
+	fundingGoal := proposal.FundingGoal
+	if fundingGoal == nil {
+		fundingGoal = balance.NewAmount(0)
+	}
+	return []interface{}{
+		uint8(proposal.Type),
+		uint8(proposal.Status),
+		uint8(proposal.Outcome),
+		ethcmn.BytesToAddress(proposal.Proposer),
+		funds.BigInt(),
+		fundingGoal.BigInt(),
+		proposal.FundingDeadline,
+		proposal.VotingDeadline,
+	}, nil
+}
+
+// governanceFunds returns the funds given to the proposal by the funder
+func governanceFunds(ctx *action.Context, _ *vm.NativeCall, args []interface{}) ([]interface{}, error) {
+	proposalID := governance.ProposalID(args[0].(string))
+	funder := keys.Address(args[1].(ethcmn.Address).Bytes())
+	amount := ctx.ProposalMasterStore.ProposalFund.GetFundsForProposalByFunder(proposalID, funder)
+	return []interface{}{amount.BigInt()}, nil
+}
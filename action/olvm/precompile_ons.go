@@ -0,0 +1,51 @@
+package olvm
+
+import (
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/vm"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// the ons contract resolves the domains, the unknown domains and addresses resolve to zero values so the contracts
+// can check them without reverting
+var onsABI = mustParseABI(`[
+	{"type": "function", "name": "resolve", "stateMutability": "view",
+		"inputs": [{"name": "name", "type": "string"}],
+		"outputs": [{"name": "beneficiary", "type": "address"}, {"name": "owner", "type": "address"},
+			{"name": "active", "type": "bool"}]},
+	{"type": "function", "name": "reverseLookup", "stateMutability": "view",
+		"inputs": [{"name": "addr", "type": "address"}], "outputs": [{"name": "name", "type": "string"}]}
+]`)
+
+var onsMethods = map[string]nativeMethod{
+	"resolve":       {run: onsResolve},
+	"reverseLookup": {run: onsReverseLookup},
+}
+
+// onsResolve returns the beneficiary and the owner of the domain, and whether payments to it are accepted
+func onsResolve(ctx *action.Context, _ *vm.NativeCall, args []interface{}) ([]interface{}, error) {
+	d, err := ctx.Domains.Get(ons.GetNameFromString(args[0].(string)))
+	if err == ons.ErrDomainNotFound {
+		return []interface{}{ethcmn.Address{}, ethcmn.Address{}, false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{
+		ethcmn.BytesToAddress(d.Beneficiary),
+		ethcmn.BytesToAddress(d.Owner),
+		d.IsActive(ctx.Header.GetHeight()),
+	}, nil
+}
+
+// onsReverseLookup returns the primary domain of the address
+func onsReverseLookup(ctx *action.Context, _ *vm.NativeCall, args []interface{}) ([]interface{}, error) {
+	addr := keys.Address(args[0].(ethcmn.Address).Bytes())
+	d, err := ctx.Domains.GetPrimary(addr, ctx.Header.GetHeight())
+	if err != nil {
+		return []interface{}{""}, nil
+	}
+	return []interface{}{d.Name.String()}, nil
+}
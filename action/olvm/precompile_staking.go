@@ -0,0 +1,162 @@
+package olvm
+
+import (
+	"math/big"
+
+	"github.com/Oneledger/protocol/action"
+	net_delg_action "github.com/Oneledger/protocol/action/network_delegation"
+	"github.com/Oneledger/protocol/data/balance"
+	gov "github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+	net_delg "github.com/Oneledger/protocol/data/network_delegation"
+	"github.com/Oneledger/protocol/vm"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// the staking contract delegates the OLT of the calling contract to the network, the same way as the network
+// delegation txs, the undelegated amount is paid back to the contract once mature
+var stakingABI = mustParseABI(`[
+	{"type": "function", "name": "delegate", "stateMutability": "payable",
+		"inputs": [{"name": "validator", "type": "address"}], "outputs": []},
+	{"type": "function", "name": "undelegate", "stateMutability": "nonpayable",
+		"inputs": [{"name": "amount", "type": "uint256"}], "outputs": []},
+	{"type": "function", "name": "delegation", "stateMutability": "view",
+		"inputs": [{"name": "delegator", "type": "address"}],
+		"outputs": [{"name": "validator", "type": "address"}, {"name": "amount", "type": "uint256"}]}
+]`)
+
+var stakingMethods = map[string]nativeMethod{
+	"delegate":   {write: true, run: stakingDelegate},
+	"undelegate": {write: true, run: stakingUndelegate},
+	"delegation": {run: stakingDelegation},
+}
+
+// stakingDelegate adds the value sent to the active delegation of the caller, the zero validator address keeps the
+// validator of the active delegation, or delegates to no validator when nothing is delegated
+func stakingDelegate(ctx *action.Context, call *vm.NativeCall, args []interface{}) ([]interface{}, error) {
+	if call.Value.Sign() <= 0 {
+		return nil, action.ErrInvalidAmount
+	}
+	delegator := keys.Address(call.Caller.Bytes())
+	validator := nativeAddress(args[0].(ethcmn.Address))
+
+	olt, ok := ctx.Currencies.GetCurrencyByName("OLT")
+	if !ok {
+		return nil, errors.New("currency OLT does not exist in system")
+	}
+	coin := olt.NewCoinFromAmount(*balance.NewAmountFromBigInt(call.Value))
+
+	// the value was sent to the contract by the evm, it is moved to the pool through the state db so the balances
+	// it caches stay in sync
+	pool, err := ctx.GovernanceStore.GetPoolByName(gov.POOL_DELEGATION)
+	if err != nil {
+		return nil, action.ErrPoolDoesNotExist
+	}
+	call.StateDB.SubBalance(call.Contract, call.Value)
+	call.StateDB.AddBalance(ethcmn.BytesToAddress(pool), call.Value)
+
+	deleg := ctx.NetwkDelegators.Deleg
+	currentDelegation, _ := deleg.WithPrefix(net_delg.ActiveType).Get(delegator)
+
+	//Pick the validator of the delegation, it can only change once nothing is delegated, no validator keeps the current one
+	current, err := deleg.GetValidator(delegator)
+	if err != nil {
+		return nil, net_delg.ErrGettingActiveDelgAmount
+	}
+	keepValidator := len(validator) == 0 && !currentDelegation.Amount.IsZero()
+	if !keepValidator && !current.Equal(validator) {
+		if !currentDelegation.Amount.IsZero() {
+			return nil, net_delg.ErrValidatorMismatch
+		}
+		if len(validator) != 0 && (!net_delg_action.ValidatorDelegationActive(ctx) || !ctx.Validators.Exists(validator)) {
+			return nil, net_delg.ErrValidatorNotFound
+		}
+		err = deleg.SetValidator(delegator, validator)
+		if err != nil {
+			return nil, net_delg.ErrSettingActiveDelgAmount
+		}
+	}
+
+	newCoin := currentDelegation.Plus(coin)
+	err = deleg.WithPrefix(net_delg.ActiveType).Set(delegator, &newCoin)
+	if err != nil {
+		return nil, balance.ErrBalanceErrorAddFailed
+	}
+	err = net_delg_action.SetStake(ctx, delegator, currentDelegation, &newCoin)
+	if err != nil {
+		return nil, net_delg.ErrSettingActiveDelgAmount
+	}
+	return nil, nil
+}
+
+// stakingUndelegate moves the amount from the active delegation of the caller to its pending one, it is paid back
+// to the caller once mature
+func stakingUndelegate(ctx *action.Context, call *vm.NativeCall, args []interface{}) ([]interface{}, error) {
+	amount := args[0].(*big.Int)
+	if amount.Sign() <= 0 {
+		return nil, action.ErrInvalidAmount
+	}
+	delegator := keys.Address(call.Caller.Bytes())
+
+	deleg := ctx.NetwkDelegators.Deleg
+	delegationCoin, err := deleg.WithPrefix(net_delg.ActiveType).Get(delegator)
+	if err != nil {
+		return nil, net_delg.ErrGettingActiveDelgAmount
+	}
+	undelegateCoin := delegationCoin.Currency.NewCoinFromAmount(*balance.NewAmountFromBigInt(amount))
+	remainCoin, err := delegationCoin.Minus(undelegateCoin)
+	if err != nil {
+		return nil, net_delg.ErrDeductingActiveDelgAmount
+	}
+	err = deleg.Set(delegator, &remainCoin)
+	if err != nil {
+		return nil, net_delg.ErrSettingActiveDelgAmount
+	}
+	err = net_delg_action.SetStake(ctx, delegator, delegationCoin, &remainCoin)
+	if err != nil {
+		return nil, net_delg.ErrSettingActiveDelgAmount
+	}
+
+	delegationOptions, err := ctx.GovernanceStore.GetNetworkDelegOptions()
+	if err != nil {
+		return nil, net_delg.ErrGettingDelgOption
+	}
+	matureHeight := ctx.Header.GetHeight() + delegationOptions.RewardsMaturityTime
+	pendingCoin, err := deleg.GetPendingAmount(delegator, matureHeight)
+	if err != nil {
+		return nil, net_delg.ErrGettingPendingDelgAmount
+	}
+	newPendingCoin := pendingCoin.Plus(undelegateCoin)
+	err = deleg.SetPendingAmount(delegator, matureHeight, &newPendingCoin)
+	if err != nil {
+		return nil, net_delg.ErrSettingPendingDelgAmount
+	}
+
+	pool, err := ctx.GovernanceStore.GetPoolByName(gov.POOL_DELEGATION)
+	if err != nil {
+		return nil, action.ErrPoolDoesNotExist
+	}
+	poolAddress := ethcmn.BytesToAddress(pool)
+	if call.StateDB.GetBalance(poolAddress).Cmp(amount) < 0 {
+		return nil, balance.ErrBalanceErrorMinusFailed
+	}
+	call.StateDB.SubBalance(poolAddress, amount)
+	return nil, nil
+}
+
+// stakingDelegation returns the validator and the active delegation of the delegator
+func stakingDelegation(ctx *action.Context, _ *vm.NativeCall, args []interface{}) ([]interface{}, error) {
+	delegator := keys.Address(args[0].(ethcmn.Address).Bytes())
+
+	deleg := ctx.NetwkDelegators.Deleg
+	active, err := deleg.WithPrefix(net_delg.ActiveType).Get(delegator)
+	if err != nil {
+		return nil, net_delg.ErrGettingActiveDelgAmount
+	}
+	validator, err := deleg.GetValidator(delegator)
+	if err != nil {
+		return nil, net_delg.ErrGettingActiveDelgAmount
+	}
+	return []interface{}{ethcmn.BytesToAddress(validator), active.Amount.BigInt()}, nil
+}
@@ -0,0 +1,135 @@
+package olvm
+
+import (
+	"strings"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/vm"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// addresses reserved for the native contracts, after the ones of the ethereum precompiles
+var (
+	StakingContractAddress    = ethcmn.HexToAddress("0x0000000000000000000000000000000000001000")
+	ONSContractAddress        = ethcmn.HexToAddress("0x0000000000000000000000000000000000001001")
+	GovernanceContractAddress = ethcmn.HexToAddress("0x0000000000000000000000000000000000001002")
+)
+
+const (
+	// gas used by the native contract methods reading and writing the stores
+	nativeReadGas  = 5000
+	nativeWriteGas = 50000
+)
+
+var (
+	ErrNativeMethodNotFound = errors.New("native contract method not found")
+	ErrNativeNotPayable     = errors.New("native contract method is not payable")
+)
+
+func init() {
+	vm.RegisterNativeContract(StakingContractAddress)
+	vm.RegisterNativeContract(ONSContractAddress)
+	vm.RegisterNativeContract(GovernanceContractAddress)
+}
+
+// NativeContracts returns the native contracts using the stores of the context, none before the fork
+func NativeContracts(ctx *action.Context) map[ethcmn.Address]vm.NativeContract {
+	if !ctx.IsForkActive(config.NativeContractsFork) {
+		return nil
+	}
+	return map[ethcmn.Address]vm.NativeContract{
+		StakingContractAddress:    &nativeContract{ctx: ctx, abi: stakingABI, methods: stakingMethods},
+		ONSContractAddress:        &nativeContract{ctx: ctx, abi: onsABI, methods: onsMethods},
+		GovernanceContractAddress: &nativeContract{ctx: ctx, abi: governanceABI, methods: governanceMethods},
+	}
+}
+
+// nativeMethod runs a method of a native contract with the unpacked arguments and returns the values to pack, the
+// methods writing the stores are journaled and can't be called read only
+type nativeMethod struct {
+	write bool
+	run   func(ctx *action.Context, call *vm.NativeCall, args []interface{}) ([]interface{}, error)
+}
+
+// nativeContract dispatches the calls to its methods by the abi selector of the input
+type nativeContract struct {
+	ctx     *action.Context
+	abi     abi.ABI
+	methods map[string]nativeMethod
+}
+
+var _ vm.NativeContract = (*nativeContract)(nil)
+
+func (c *nativeContract) method(input []byte) (*abi.Method, nativeMethod, error) {
+	if len(input) < 4 {
+		return nil, nativeMethod{}, ErrNativeMethodNotFound
+	}
+	m, err := c.abi.MethodById(input[:4])
+	if err != nil {
+		return nil, nativeMethod{}, ErrNativeMethodNotFound
+	}
+	native, ok := c.methods[m.Name]
+	if !ok {
+		return nil, nativeMethod{}, ErrNativeMethodNotFound
+	}
+	return m, native, nil
+}
+
+func (c *nativeContract) RequiredGas(input []byte) uint64 {
+	_, native, err := c.method(input)
+	if err == nil && native.write {
+		return nativeWriteGas
+	}
+	return nativeReadGas
+}
+
+func (c *nativeContract) Run(call *vm.NativeCall, input []byte) ([]byte, error) {
+	m, native, err := c.method(input)
+	if err != nil {
+		return nil, err
+	}
+	if native.write && call.ReadOnly {
+		return nil, vm.ErrNativeReadOnly
+	}
+	if !m.IsPayable() && call.Value.Sign() != 0 {
+		return nil, ErrNativeNotPayable
+	}
+	args, err := m.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	var out []interface{}
+	if native.write {
+		err = call.StateDB.JournalState(c.ctx.State, func() (err error) {
+			out, err = native.run(c.ctx, call, args)
+			return
+		})
+	} else {
+		out, err = native.run(c.ctx, call, args)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.Outputs.Pack(out...)
+}
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// nativeAddress converts an abi address argument, the zero address is an empty address
+func nativeAddress(addr ethcmn.Address) keys.Address {
+	if addr == (ethcmn.Address{}) {
+		return nil
+	}
+	return keys.Address(addr.Bytes())
+}
@@ -90,14 +90,14 @@ func (domainCreateTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, e
 	}
 
 	//Validate whether signers match those of the transaction and verify the signed transaction.
-	err = action.ValidateBasic(tx.RawBytes(), create.Signers(), tx.Signatures)
+	err = action.ValidateBasic(ctx, tx.RawBytes(), create.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
 	//Verify fee currency is valid and the amount exceeds the minimum.
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 	if err != nil {
 		return false, err
 	}
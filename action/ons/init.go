@@ -19,6 +19,16 @@ func init() {
 	serialize.RegisterConcrete(new(DomainSend), "action_dsend")
 	serialize.RegisterConcrete(new(DomainPurchase), "action_dp")
 	serialize.RegisterConcrete(new(RenewDomain), "action_dr")
+	serialize.RegisterConcrete(new(DomainSetRecord), "action_dsr")
+
+	action.RegisterMsgType(action.DOMAIN_CREATE, func() action.Msg { return &DomainCreate{} })
+	action.RegisterMsgType(action.DOMAIN_UPDATE, func() action.Msg { return &DomainUpdate{} })
+	action.RegisterMsgType(action.DOMAIN_SELL, func() action.Msg { return &DomainSale{} })
+	action.RegisterMsgType(action.DOMAIN_PURCHASE, func() action.Msg { return &DomainPurchase{} })
+	action.RegisterMsgType(action.DOMAIN_SEND, func() action.Msg { return &DomainSend{} })
+	action.RegisterMsgType(action.DOMAIN_DELETE_SUB, func() action.Msg { return &DeleteSub{} })
+	action.RegisterMsgType(action.DOMAIN_RENEW, func() action.Msg { return &RenewDomain{} })
+	action.RegisterMsgType(action.DOMAIN_SET_RECORD, func() action.Msg { return &DomainSetRecord{} })
 }
 
 func EnableONS(r action.Router) error {
@@ -50,6 +60,10 @@ func EnableONS(r action.Router) error {
 	if err != nil {
 		return errors.Wrap(err, "deleteSubTx")
 	}
+	err = r.AddHandler(action.DOMAIN_SET_RECORD, domainSetRecordTx{})
+	if err != nil {
+		return errors.Wrap(err, "domainSetRecordTx")
+	}
 
 	return nil
 }
@@ -83,12 +83,12 @@ func (domainPurchaseTx) Validate(ctx *action.Context, tx action.SignedTx) (bool,
 	}
 
 	// validate basic signature
-	err = action.ValidateBasic(tx.RawBytes(), buy.Signers(), tx.Signatures)
+	err = action.ValidateBasic(ctx, tx.RawBytes(), buy.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -80,14 +80,14 @@ func (r RenewDomainTx) Validate(ctx *action.Context, signedTx action.SignedTx) (
 	}
 
 	//Validate whether signers match those of the transaction and verify the signed transaction.
-	err = action.ValidateBasic(signedTx.RawBytes(), renewDomain.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), renewDomain.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, errors.Wrap(err, err.Error())
 	}
 
 	//Verify fee currency is valid and the amount exceeds the minimum.
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		return false, errors.Wrap(err, err.Error())
 	}
@@ -96,12 +96,12 @@ func (domainSaleTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, err
 	}
 
 	// validate basic signature
-	err = action.ValidateBasic(tx.RawBytes(), sale.Signers(), tx.Signatures)
+	err = action.ValidateBasic(ctx, tx.RawBytes(), sale.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 	if err != nil {
 		return false, err
 	}
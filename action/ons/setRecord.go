@@ -0,0 +1,173 @@
+package ons
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/ons"
+)
+
+/*
+	DomainSetRecord
+
+This transaction sets a resolver record of the domain: the address on another chain, a text record or the content
+hash, an empty value removes the record. The primary record makes the domain the name returned by the reverse lookup
+of the owner, the domain must point to the owner for it.
+*/
+type DomainSetRecord struct {
+	Owner      action.Address `json:"owner"`
+	Name       ons.Name       `json:"name"`
+	RecordType ons.RecordType `json:"recordType"`
+	Key        string         `json:"key"`
+	Value      string         `json:"value"`
+}
+
+var _ Ons = &DomainSetRecord{}
+
+func (dsr DomainSetRecord) Marshal() ([]byte, error) {
+	return json.Marshal(dsr)
+}
+
+func (dsr *DomainSetRecord) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, dsr)
+}
+
+func (dsr DomainSetRecord) OnsName() string {
+	return dsr.Name.String()
+}
+
+func (dsr DomainSetRecord) Signers() []action.Address {
+	return []action.Address{dsr.Owner}
+}
+
+func (dsr DomainSetRecord) Type() action.Type {
+	return action.DOMAIN_SET_RECORD
+}
+
+func (dsr DomainSetRecord) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(dsr.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: dsr.Owner.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.domain"),
+		Value: []byte(dsr.Name.String()),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = domainSetRecordTx{}
+
+type domainSetRecordTx struct {
+}
+
+func (domainSetRecordTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	record := &DomainSetRecord{}
+	err := record.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateBasic(ctx, tx.RawBytes(), record.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if record.Owner == nil || len(record.Name) <= 0 {
+		return false, action.ErrMissingData
+	}
+
+	if !record.Name.IsValid() {
+		return false, ErrInvalidDomain
+	}
+
+	if !record.RecordType.IsValid() {
+		return false, ons.ErrInvalidRecordType
+	}
+
+	err = ons.ValidateRecord(record.RecordType, record.Key, record.Value)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (domainSetRecordTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runSetRecord(ctx, tx)
+}
+
+func (domainSetRecordTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runSetRecord(ctx, tx)
+}
+
+func (domainSetRecordTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runSetRecord(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	if !ctx.IsForkActive(config.DomainRecordsFork) {
+		return false, action.Response{Log: errors.Wrap(action.ErrWrongTxType, "domain records not supported yet").Error()}
+	}
+
+	record := &DomainSetRecord{}
+	err := record.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	d, err := ctx.Domains.Get(record.Name)
+	if err != nil {
+		return false, action.Response{Log: fmt.Sprintf("domain doesn't exist: %s", record.Name)}
+	}
+
+	if !bytes.Equal(d.Owner, record.Owner) {
+		return false, action.Response{Log: fmt.Sprintf("domain is not owned by: %s", record.Owner.String())}
+	}
+
+	if d.IsExpired(ctx.Header.Height) {
+		return false, action.Response{Log: fmt.Sprintf("domain is expired: %s", record.Name)}
+	}
+
+	if record.RecordType == ons.RecordPrimary {
+		if !d.IsActive(ctx.Header.Height) || !d.Beneficiary.Equal(record.Owner) {
+			return false, action.Response{Log: fmt.Sprintf("domain %s doesn't point to: %s", record.Name, record.Owner.String())}
+		}
+		err = ctx.Domains.SetPrimary(record.Owner, d.Name)
+		if err != nil {
+			return false, action.Response{Log: err.Error()}
+		}
+		return true, action.Response{Events: action.GetEvent(record.Tags(), "set_domain_record")}
+	}
+
+	err = d.SetRecord(record.RecordType, record.Key, record.Value)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	d.SetLastUpdatedHeight(ctx.Header.Height)
+
+	err = ctx.Domains.Set(d)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	return true, action.Response{Events: action.GetEvent(record.Tags(), "set_domain_record")}
+}
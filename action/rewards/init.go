@@ -6,6 +6,10 @@ import (
 	"github.com/Oneledger/protocol/action"
 )
 
+func init() {
+	action.RegisterMsgType(action.WITHDRAW_REWARD, func() action.Msg { return &Withdraw{} })
+}
+
 func EnableRewards(r action.Router) error {
 	err := r.AddHandler(action.WITHDRAW_REWARD, withdrawTx{})
 	if err != nil {
@@ -0,0 +1,148 @@
+package staking
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/identity"
+)
+
+var _ action.Msg = &EditValidator{}
+
+// EditValidator declares the commission the validator keeps from the rewards of the delegators who picked it. The max
+// rate and the max change rate are set by the first declaration, the rate can then change once a day by at most the
+// max change rate.
+type EditValidator struct {
+	ValidatorAddress keys.Address `json:"validatorAddress"`
+	StakeAddress     keys.Address `json:"stakeAddress"`
+	CommissionRate   int64        `json:"commissionRate"`
+	MaxRate          int64        `json:"maxRate"`
+	MaxChangeRate    int64        `json:"maxChangeRate"`
+}
+
+func (ev EditValidator) Marshal() ([]byte, error) {
+	return json.Marshal(ev)
+}
+
+func (ev *EditValidator) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, ev)
+}
+
+func (ev EditValidator) Signers() []action.Address {
+	return []action.Address{ev.StakeAddress.Bytes(), ev.ValidatorAddress.Bytes()}
+}
+
+func (ev EditValidator) Type() action.Type {
+	return action.EDIT_VALIDATOR
+}
+
+func (ev EditValidator) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(ev.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.validator"),
+		Value: ev.ValidatorAddress.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.commissionRate"),
+		Value: []byte(strconv.FormatInt(ev.CommissionRate, 10)),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+func (ev EditValidator) Commission() identity.Commission {
+	return identity.Commission{
+		Rate:          ev.CommissionRate,
+		MaxRate:       ev.MaxRate,
+		MaxChangeRate: ev.MaxChangeRate,
+	}
+}
+
+var _ action.Tx = editValidatorTx{}
+
+type editValidatorTx struct{}
+
+func (editValidatorTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	ev := &EditValidator{}
+	err := ev.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+	err = action.ValidateBasic(ctx, tx.RawBytes(), ev.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if err := ev.StakeAddress.Err(); err != nil {
+		return false, err
+	}
+
+	if err := ev.ValidatorAddress.Err(); err != nil {
+		return false, err
+	}
+
+	err = ev.Commission().Validate()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (editValidatorTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Edit Validator Transaction for CheckTx", tx)
+	return runEditValidator(ctx, tx)
+}
+
+func (editValidatorTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Edit Validator Transaction for DeliverTx", tx)
+	return runEditValidator(ctx, tx)
+}
+
+func (editValidatorTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 2)
+}
+
+func runEditValidator(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ev := &EditValidator{}
+	err := ev.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	if !ctx.IsForkActive(config.ValidatorDelegationFork) {
+		return false, action.Response{Log: errors.Wrap(action.ErrWrongTxType, "validator commissions not supported yet").Error()}
+	}
+
+	val, err := ctx.Validators.Get(ev.ValidatorAddress)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, ev.ValidatorAddress.String()).Error()}
+	}
+	if !val.StakeAddress.Equal(ev.StakeAddress) {
+		return false, action.Response{Log: action.ErrStakeAddressMismatch.Error()}
+	}
+
+	err = ctx.Validators.SetCommission(ev.ValidatorAddress, ev.Commission(), ctx.Header.Time.Unix())
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, ev.ValidatorAddress.String()).Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(ev.Tags(), "edit_validator")}
+}
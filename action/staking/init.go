@@ -11,6 +11,12 @@ func init() {
 	serialize.RegisterConcrete(new(Stake), "stake")
 	serialize.RegisterConcrete(new(Unstake), "unstake")
 	serialize.RegisterConcrete(new(Withdraw), "withdraw")
+	serialize.RegisterConcrete(new(EditValidator), "editValidator")
+
+	action.RegisterMsgType(action.STAKE, func() action.Msg { return &Stake{} })
+	action.RegisterMsgType(action.UNSTAKE, func() action.Msg { return &Unstake{} })
+	action.RegisterMsgType(action.WITHDRAW, func() action.Msg { return &Withdraw{} })
+	action.RegisterMsgType(action.EDIT_VALIDATOR, func() action.Msg { return &EditValidator{} })
 }
 
 func EnableStaking(r action.Router) error {
@@ -29,5 +35,10 @@ func EnableStaking(r action.Router) error {
 	if err != nil {
 		return errors.Wrap(err, "withdrawTx")
 	}
+
+	err = r.AddHandler(action.EDIT_VALIDATOR, editValidatorTx{})
+	if err != nil {
+		return errors.Wrap(err, "editValidatorTx")
+	}
 	return nil
 }
@@ -74,12 +74,12 @@ func (s stakeTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error)
 	if err != nil {
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
-	err = action.ValidateBasic(tx.RawBytes(), st.Signers(), tx.Signatures)
+	err = action.ValidateBasic(ctx, tx.RawBytes(), st.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -217,7 +217,7 @@ func runCheckStake(ctx *action.Context, tx action.RawTx) (bool, action.Response)
 
 	coin := st.Stake.ToCoinWithBase(ctx.Currencies)
 
-	err = ctx.Balances.MinusFromAddress(st.StakeAddress, coin)
+	err = ctx.Balances.DelegateFromAddress(st.StakeAddress, coin)
 	if err != nil {
 		return false, action.Response{Log: errors.Wrap(err, st.StakeAddress.String()).Error()}
 	}
@@ -70,12 +70,12 @@ func (us unstakeTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, err
 	if err != nil {
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
-	err = action.ValidateBasic(tx.RawBytes(), ust.Signers(), tx.Signatures)
+	err = action.ValidateBasic(ctx, tx.RawBytes(), ust.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -73,12 +73,12 @@ func (withdrawTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error
 	}
 
 	//validate basic signature
-	err = action.ValidateBasic(tx.RawBytes(), draw.Signers(), tx.Signatures)
+	err = action.ValidateBasic(ctx, tx.RawBytes(), draw.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -142,7 +142,7 @@ func runWithdraw(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 		return false, action.Response{Log: errors.Wrap(err, draw.StakeAddress.String()).Error()}
 	}
 
-	err = ctx.Balances.AddToAddress(draw.StakeAddress, coin)
+	err = ctx.Balances.UndelegateToAddress(draw.StakeAddress, coin)
 	if err != nil {
 		return false, action.Response{Log: errors.Wrap(err, "add to balance").Error()}
 	}
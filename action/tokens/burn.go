@@ -0,0 +1,136 @@
+package tokens
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/tokens"
+)
+
+var _ action.Msg = &BurnToken{}
+
+// BurnToken destroys tokens from the balance of the issuer, only the issuer of the token can burn it
+type BurnToken struct {
+	Issuer action.Address `json:"issuer"`
+	Amount action.Amount  `json:"amount"`
+}
+
+func (b BurnToken) Marshal() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+func (b *BurnToken) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, b)
+}
+
+func (b BurnToken) Signers() []action.Address {
+	return []action.Address{b.Issuer}
+}
+
+func (b BurnToken) Type() action.Type {
+	return action.TOKEN_BURN
+}
+
+func (b BurnToken) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(b.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.issuer"),
+		Value: b.Issuer.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.token"),
+		Value: []byte(b.Amount.Currency),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = burnTokenTx{}
+
+type burnTokenTx struct{}
+
+func (burnTokenTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	burn := &BurnToken{}
+	err := burn.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateBasic(ctx, tx.RawBytes(), burn.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if burn.Issuer.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+	if burn.Amount.Value.BigInt().Sign() <= 0 {
+		return false, errors.Wrap(action.ErrInvalidAmount, burn.Amount.String())
+	}
+	return true, nil
+}
+
+func (burnTokenTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Token Burn Transaction for CheckTx", tx)
+	return runBurnToken(ctx, tx)
+}
+
+func (burnTokenTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Token Burn Transaction for DeliverTx", tx)
+	return runBurnToken(ctx, tx)
+}
+
+func (burnTokenTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runBurnToken(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	burn := &BurnToken{}
+	err := burn.Unmarshal(tx.Data)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrUnserializable, burn.Tags(), err)
+	}
+
+	if !ctx.IsForkActive(config.TokenFactoryFork) {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrWrongTxType, burn.Tags(), errors.New("token factory not supported yet"))
+	}
+
+	token, err := ctx.Tokens.Get(burn.Amount.Currency)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, tokens.ErrTokenNotFound, burn.Tags(), err)
+	}
+	if !token.Issuer.Equal(burn.Issuer) {
+		return helpers.LogAndReturnFalse(ctx.Logger, tokens.ErrNotIssuer, burn.Tags(), errors.New(burn.Issuer.String()))
+	}
+
+	err = ctx.Balances.MinusFromAddress(burn.Issuer.Bytes(), token.Currency.NewCoinFromAmount(burn.Amount.Value))
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrBalanceErrorMinusFailed, burn.Tags(), err)
+	}
+	err = token.Burn(burn.Amount.Value)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, tokens.ErrInvalidToken, burn.Tags(), err)
+	}
+	err = ctx.Tokens.Set(token)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrUnserializable, burn.Tags(), err)
+	}
+	return helpers.LogAndReturnTrue(ctx.Logger, burn.Tags(), "token_burn")
+}
@@ -0,0 +1,156 @@
+package tokens
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/tokens"
+)
+
+var _ action.Msg = &CreateToken{}
+
+// CreateToken registers a new currency, the creator pays the creation fee set by the governance and the issuer
+// alone can mint the token up to its max supply. The currency can be used from the block after the creation.
+type CreateToken struct {
+	Creator   action.Address `json:"creator"`
+	Name      string         `json:"name"`
+	Unit      string         `json:"unit"`
+	Decimal   int64          `json:"decimal"`
+	Issuer    action.Address `json:"issuer"`
+	MaxSupply balance.Amount `json:"maxSupply"`
+}
+
+func (c CreateToken) Marshal() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func (c *CreateToken) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+func (c CreateToken) Signers() []action.Address {
+	return []action.Address{c.Creator}
+}
+
+func (c CreateToken) Type() action.Type {
+	return action.TOKEN_CREATE
+}
+
+func (c CreateToken) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(c.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.creator"),
+		Value: c.Creator.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.issuer"),
+		Value: c.Issuer.Bytes(),
+	}
+	tag4 := kv.Pair{
+		Key:   []byte("tx.token"),
+		Value: []byte(c.Name),
+	}
+
+	tags = append(tags, tag, tag2, tag3, tag4)
+	return tags
+}
+
+var _ action.Tx = createTokenTx{}
+
+type createTokenTx struct{}
+
+func (createTokenTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	create := &CreateToken{}
+	err := create.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateBasic(ctx, tx.RawBytes(), create.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if create.Creator.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+	_, err = tokens.NewToken(create.Name, create.Unit, create.Decimal, create.Issuer, create.MaxSupply, 0)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (createTokenTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Token Create Transaction for CheckTx", tx)
+	return runCreateToken(ctx, tx)
+}
+
+func (createTokenTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Token Create Transaction for DeliverTx", tx)
+	return runCreateToken(ctx, tx)
+}
+
+func (createTokenTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runCreateToken(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	create := &CreateToken{}
+	err := create.Unmarshal(tx.Data)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrUnserializable, create.Tags(), err)
+	}
+
+	if !ctx.IsForkActive(config.TokenFactoryFork) {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrWrongTxType, create.Tags(), errors.New("token factory not supported yet"))
+	}
+
+	options, err := ctx.GovernanceStore.GetTokenOptions()
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, tokens.ErrInvalidOptions, create.Tags(), err)
+	}
+	if !options.Enabled {
+		return helpers.LogAndReturnFalse(ctx.Logger, tokens.ErrCreationDisabled, create.Tags(), errors.New(create.Name))
+	}
+
+	token, err := tokens.NewToken(create.Name, create.Unit, create.Decimal, create.Issuer, create.MaxSupply, ctx.Header.Height)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, tokens.ErrInvalidToken, create.Tags(), err)
+	}
+
+	// the creation fee goes to the fee pool along with the tx fees
+	if !options.CreationFee.IsZero() {
+		fee := ctx.FeePool.GetOpt().FeeCurrency.NewCoinFromAmount(options.CreationFee)
+		err = ctx.Balances.MinusFromAddress(create.Creator.Bytes(), fee)
+		if err != nil {
+			return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrBalanceErrorMinusFailed, create.Tags(), err)
+		}
+		err = ctx.FeePool.AddToPool(fee)
+		if err != nil {
+			return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrBalanceErrorAddFailed, create.Tags(), err)
+		}
+	}
+
+	err = ctx.Tokens.Create(token, ctx.Currencies)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, tokens.ErrTokenExists, create.Tags(), err)
+	}
+	return helpers.LogAndReturnTrue(ctx.Logger, create.Tags(), "token_create")
+}
@@ -0,0 +1,34 @@
+package tokens
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+func init() {
+	serialize.RegisterConcrete(new(CreateToken), "action_token_create")
+	serialize.RegisterConcrete(new(MintToken), "action_token_mint")
+	serialize.RegisterConcrete(new(BurnToken), "action_token_burn")
+
+	action.RegisterMsgType(action.TOKEN_CREATE, func() action.Msg { return &CreateToken{} })
+	action.RegisterMsgType(action.TOKEN_MINT, func() action.Msg { return &MintToken{} })
+	action.RegisterMsgType(action.TOKEN_BURN, func() action.Msg { return &BurnToken{} })
+}
+
+func EnableTokens(r action.Router) error {
+	err := r.AddHandler(action.TOKEN_CREATE, createTokenTx{})
+	if err != nil {
+		return errors.Wrap(err, "createTokenTx")
+	}
+	err = r.AddHandler(action.TOKEN_MINT, mintTokenTx{})
+	if err != nil {
+		return errors.Wrap(err, "mintTokenTx")
+	}
+	err = r.AddHandler(action.TOKEN_BURN, burnTokenTx{})
+	if err != nil {
+		return errors.Wrap(err, "burnTokenTx")
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package tokens
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/tokens"
+)
+
+var _ action.Msg = &MintToken{}
+
+// MintToken credits the recipient with new tokens, only the issuer of the token can mint it
+type MintToken struct {
+	Issuer action.Address `json:"issuer"`
+	To     action.Address `json:"to"`
+	Amount action.Amount  `json:"amount"`
+}
+
+func (m MintToken) Marshal() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func (m *MintToken) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, m)
+}
+
+func (m MintToken) Signers() []action.Address {
+	return []action.Address{m.Issuer}
+}
+
+func (m MintToken) Type() action.Type {
+	return action.TOKEN_MINT
+}
+
+func (m MintToken) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(m.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.issuer"),
+		Value: m.Issuer.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.to"),
+		Value: m.To.Bytes(),
+	}
+	tag4 := kv.Pair{
+		Key:   []byte("tx.token"),
+		Value: []byte(m.Amount.Currency),
+	}
+
+	tags = append(tags, tag, tag2, tag3, tag4)
+	return tags
+}
+
+var _ action.Tx = mintTokenTx{}
+
+type mintTokenTx struct{}
+
+func (mintTokenTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	mint := &MintToken{}
+	err := mint.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateBasic(ctx, tx.RawBytes(), mint.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if mint.Issuer.Err() != nil || mint.To.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+	if mint.Amount.Value.BigInt().Sign() <= 0 {
+		return false, errors.Wrap(action.ErrInvalidAmount, mint.Amount.String())
+	}
+	return true, nil
+}
+
+func (mintTokenTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Token Mint Transaction for CheckTx", tx)
+	return runMintToken(ctx, tx)
+}
+
+func (mintTokenTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Token Mint Transaction for DeliverTx", tx)
+	return runMintToken(ctx, tx)
+}
+
+func (mintTokenTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runMintToken(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	mint := &MintToken{}
+	err := mint.Unmarshal(tx.Data)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrUnserializable, mint.Tags(), err)
+	}
+
+	if !ctx.IsForkActive(config.TokenFactoryFork) {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrWrongTxType, mint.Tags(), errors.New("token factory not supported yet"))
+	}
+
+	token, err := ctx.Tokens.Get(mint.Amount.Currency)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, tokens.ErrTokenNotFound, mint.Tags(), err)
+	}
+	if !token.Issuer.Equal(mint.Issuer) {
+		return helpers.LogAndReturnFalse(ctx.Logger, tokens.ErrNotIssuer, mint.Tags(), errors.New(mint.Issuer.String()))
+	}
+	err = token.Mint(mint.Amount.Value)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, tokens.ErrMaxSupplyExceeded, mint.Tags(), err)
+	}
+	err = ctx.Tokens.Set(token)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrUnserializable, mint.Tags(), err)
+	}
+
+	err = ctx.Balances.AddToAddress(mint.To.Bytes(), token.Currency.NewCoinFromAmount(mint.Amount.Value))
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrBalanceErrorAddFailed, mint.Tags(), err)
+	}
+	return helpers.LogAndReturnTrue(ctx.Logger, mint.Tags(), "token_mint")
+}
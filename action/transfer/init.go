@@ -11,6 +11,9 @@ func init() {
 
 	serialize.RegisterConcrete(new(Send), "action_send")
 
+	action.RegisterMsgType(action.SEND, func() action.Msg { return &Send{} })
+	action.RegisterMsgType(action.SENDPOOL, func() action.Msg { return &SendPool{} })
+
 }
 
 func EnableSend(r action.Router) error {
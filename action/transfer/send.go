@@ -68,12 +68,12 @@ func (sendTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
 	}
 
 	//validate basic signature
-	err = action.ValidateBasic(tx.RawBytes(), send.Signers(), tx.Signatures)
+	err = action.ValidateBasic(ctx, tx.RawBytes(), send.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -65,12 +65,12 @@ func (sendPoolTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bool,
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
 	//validate basic signature
-	err = action.ValidateBasic(signedTx.RawBytes(), sendPool.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), sendPool.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
 
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		return false, err
 	}
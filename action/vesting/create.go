@@ -0,0 +1,160 @@
+package vesting
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/helpers"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+)
+
+var _ action.Msg = &CreateVesting{}
+
+// CreateVesting sends Amount from the funder to the beneficiary, locked by a vesting schedule. Nothing vests before
+// the cliff height, a cliff height of 0 means no cliff, everything has vested at the end height.
+type CreateVesting struct {
+	Funder       action.Address          `json:"funder"`
+	Beneficiary  action.Address          `json:"beneficiary"`
+	Amount       action.Amount           `json:"amount"`
+	StartHeight  int64                   `json:"startHeight"`
+	CliffHeight  int64                   `json:"cliffHeight"`
+	EndHeight    int64                   `json:"endHeight"`
+	Schedule     balance.VestingSchedule `json:"schedule"`
+	PeriodLength int64                   `json:"periodLength,omitempty"`
+}
+
+func (c CreateVesting) Marshal() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func (c *CreateVesting) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+func (c CreateVesting) Signers() []action.Address {
+	return []action.Address{c.Funder}
+}
+
+func (c CreateVesting) Type() action.Type {
+	return action.CREATE_VESTING
+}
+
+func (c CreateVesting) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(c.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.funder"),
+		Value: c.Funder.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.beneficiary"),
+		Value: c.Beneficiary.Bytes(),
+	}
+	tag4 := kv.Pair{
+		Key:   []byte("tx.end_height"),
+		Value: []byte(strconv.FormatInt(c.EndHeight, 10)),
+	}
+
+	tags = append(tags, tag, tag2, tag3, tag4)
+	return tags
+}
+
+// vestingAccount returns the vesting account of the beneficiary the tx registers
+func (c CreateVesting) vestingAccount(currencies *balance.CurrencySet) (*balance.VestingAccount, error) {
+	if !c.Amount.IsValid(currencies) {
+		return nil, errors.Wrap(action.ErrInvalidAmount, c.Amount.String())
+	}
+	return balance.NewVestingAccount(c.Beneficiary, c.Amount.ToCoin(currencies), c.StartHeight, c.CliffHeight,
+		c.EndHeight, c.Schedule, c.PeriodLength)
+}
+
+var _ action.Tx = createVestingTx{}
+
+type createVestingTx struct{}
+
+func (createVestingTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	create := &CreateVesting{}
+	err := create.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateBasic(ctx, tx.RawBytes(), create.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool, tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if create.Funder.Err() != nil || create.Beneficiary.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+	_, err = create.vestingAccount(ctx.Currencies)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (createVestingTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Create Vesting Transaction for CheckTx", tx)
+	return runCreateVesting(ctx, tx)
+}
+
+func (createVestingTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Create Vesting Transaction for DeliverTx", tx)
+	return runCreateVesting(ctx, tx)
+}
+
+func (createVestingTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas, gasUsed action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runCreateVesting(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	create := &CreateVesting{}
+	err := create.Unmarshal(tx.Data)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrUnserializable, create.Tags(), err)
+	}
+
+	if !ctx.IsForkActive(config.VestingFork) {
+		return helpers.LogAndReturnFalse(ctx.Logger, action.ErrWrongTxType, create.Tags(), errors.New("vesting accounts not supported yet"))
+	}
+
+	vesting, err := create.vestingAccount(ctx.Currencies)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrInvalidVesting, create.Tags(), err)
+	}
+	// a schedule which has already ended would lock nothing
+	if vesting.EndHeight <= ctx.Header.Height {
+		return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrInvalidVesting, create.Tags(),
+			errors.Errorf("end height %d is not after the current height %d", vesting.EndHeight, ctx.Header.Height))
+	}
+
+	coin := create.Amount.ToCoin(ctx.Currencies)
+	err = ctx.Balances.MinusFromAddress(create.Funder.Bytes(), coin)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrBalanceErrorMinusFailed, create.Tags(), err)
+	}
+	err = ctx.Balances.SetVesting(vesting)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrVestingExists, create.Tags(), err)
+	}
+	err = ctx.Balances.AddToAddress(create.Beneficiary.Bytes(), coin)
+	if err != nil {
+		return helpers.LogAndReturnFalse(ctx.Logger, balance.ErrBalanceErrorAddFailed, create.Tags(), err)
+	}
+	return helpers.LogAndReturnTrue(ctx.Logger, create.Tags(), "create_vesting")
+}
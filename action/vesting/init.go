@@ -0,0 +1,22 @@
+package vesting
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+func init() {
+	serialize.RegisterConcrete(new(CreateVesting), "action_create_vesting")
+
+	action.RegisterMsgType(action.CREATE_VESTING, func() action.Msg { return &CreateVesting{} })
+}
+
+func EnableVesting(r action.Router) error {
+	err := r.AddHandler(action.CREATE_VESTING, createVestingTx{})
+	if err != nil {
+		return errors.Wrap(err, "createVestingTx")
+	}
+	return nil
+}
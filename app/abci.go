@@ -11,6 +11,12 @@ type txDeliverer func(RequestDeliverTx) ResponseDeliverTx
 type blockEnder func(RequestEndBlock) ResponseEndBlock
 type commitor func() ResponseCommit
 
+// State sync methods
+type snapshotLister func(RequestListSnapshots) ResponseListSnapshots
+type snapshotOfferer func(RequestOfferSnapshot) ResponseOfferSnapshot
+type snapshotChunkLoader func(RequestLoadSnapshotChunk) ResponseLoadSnapshotChunk
+type snapshotChunkApplier func(RequestApplySnapshotChunk) ResponseApplySnapshotChunk
+
 // abciController ensures that the implementing type can control an underlying ABCI app
 type abciController interface {
 	infoServer() infoServer
@@ -22,6 +28,11 @@ type abciController interface {
 	txDeliverer() txDeliverer
 	blockEnder() blockEnder
 	commitor() commitor
+
+	snapshotLister() snapshotLister
+	snapshotOfferer() snapshotOfferer
+	snapshotChunkLoader() snapshotChunkLoader
+	snapshotChunkApplier() snapshotChunkApplier
 }
 
 var _ ABCIApp = &ABCI{}
@@ -37,6 +48,11 @@ type ABCI struct {
 	txDeliverer      txDeliverer
 	blockEnder       blockEnder
 	commitor         commitor
+
+	snapshotLister       snapshotLister
+	snapshotOfferer      snapshotOfferer
+	snapshotChunkLoader  snapshotChunkLoader
+	snapshotChunkApplier snapshotChunkApplier
 }
 
 func (app *ABCI) Info(request RequestInfo) ResponseInfo {
@@ -74,3 +90,19 @@ func (app *ABCI) EndBlock(request RequestEndBlock) ResponseEndBlock {
 func (app *ABCI) Commit() ResponseCommit {
 	return app.commitor()
 }
+
+func (app *ABCI) ListSnapshots(request RequestListSnapshots) ResponseListSnapshots {
+	return app.snapshotLister(request)
+}
+
+func (app *ABCI) OfferSnapshot(request RequestOfferSnapshot) ResponseOfferSnapshot {
+	return app.snapshotOfferer(request)
+}
+
+func (app *ABCI) LoadSnapshotChunk(request RequestLoadSnapshotChunk) ResponseLoadSnapshotChunk {
+	return app.snapshotChunkLoader(request)
+}
+
+func (app *ABCI) ApplySnapshotChunk(request RequestApplySnapshotChunk) ResponseApplySnapshotChunk {
+	return app.snapshotChunkApplier(request)
+}
@@ -20,12 +20,15 @@ import (
 	"github.com/Oneledger/protocol/data/bitcoin"
 	"github.com/Oneledger/protocol/data/chain"
 	"github.com/Oneledger/protocol/data/ethereum"
+	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/data/tokens"
 	"github.com/Oneledger/protocol/event"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
 	"github.com/Oneledger/protocol/serialize"
 	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/vm"
 )
 
 // Ensure this App struct can control the underlying ABCI app
@@ -45,6 +48,11 @@ type App struct {
 
 	node       *consensus.Node
 	genesisDoc *config.GenesisDoc
+
+	// gas used by the transactions of the current block, it moves the base fee of the fee market
+	blockGasUsed int64
+	// gas prices paid by the transactions of the current block, kept in the fee history
+	blockGasPrices []fees.GasPrice
 }
 
 // New returns new app fresh and ready to start
@@ -99,6 +107,11 @@ func (app *App) setNewABCI() {
 		txDeliverer:      app.txDeliverer(),
 		blockEnder:       app.blockEnder(),
 		commitor:         app.commitor(),
+
+		snapshotLister:       app.snapshotLister(),
+		snapshotOfferer:      app.snapshotOfferer(),
+		snapshotChunkLoader:  app.snapshotChunkLoader(),
+		snapshotChunkApplier: app.snapshotChunkApplier(),
 	}
 }
 
@@ -166,6 +179,18 @@ func (app *App) setupState(stateBytes []byte) error {
 			return errors.Wrapf(err, "failed to register currency %s", currency.Name)
 		}
 	}
+	// the tokens created at runtime before the state was saved
+	for i := range initial.Tokens {
+		token := &initial.Tokens[i]
+		err := app.Context.tokens.WithState(app.Context.deliver).Set(token)
+		if err != nil {
+			return errors.Wrapf(err, "failed to set token %s", token.Currency.Name)
+		}
+		err = balanceCtx.Currencies().Register(token.Currency)
+		if err != nil {
+			return errors.Wrapf(err, "failed to register currency %s", token.Currency.Name)
+		}
+	}
 
 	err = app.Context.govern.WithHeight(app.header.Height).SetFeeOption(initial.Governance.FeeOption)
 	if err != nil {
@@ -173,6 +198,31 @@ func (app *App) setupState(stateBytes []byte) error {
 	}
 	app.Context.feePool.SetupOpt(&initial.Governance.FeeOption)
 
+	_, err = app.Context.govern.ValidateFeeMarket(&initial.Governance.FeeMarketOptions, app.blockMaxGas())
+	if err != nil {
+		return errors.Wrap(err, "invalid Fee Market Options")
+	}
+	err = app.Context.govern.WithHeight(app.header.Height).SetFeeMarketOptions(initial.Governance.FeeMarketOptions)
+	if err != nil {
+		return errors.Wrap(err, "Setup Fee Market Options")
+	}
+	app.Context.feePool.SetupMarketOpt(&initial.Governance.FeeMarketOptions)
+
+	// the genesis files written before the gas schedule was added to the governance use the default one
+	gasOpt := initial.Governance.GasOptions
+	if gasOpt.Schedule == (storage.GasSchedule{}) {
+		gasOpt = storage.DefaultGasOptions()
+	}
+	err = app.Context.govern.WithHeight(app.header.Height).SetGasOptions(gasOpt)
+	if err != nil {
+		return errors.Wrap(err, "Setup Gas Options")
+	}
+
+	err = app.Context.govern.WithHeight(app.header.Height).SetTokenOptions(initial.Governance.TokenOptions)
+	if err != nil {
+		return errors.Wrap(err, "Setup Token Options")
+	}
+
 	//TODO change back to genesis in future, right now network delegation option is hardcoded to avoid genesis deployment
 	hardCodedOption := network_delegation.Options{
 		RewardsMaturityTime: network_delegation.RewardsMaturityTime,
@@ -201,6 +251,13 @@ func (app *App) setupState(stateBytes []byte) error {
 			return errors.Wrap(err, "failed to set balance")
 		}
 	}
+	// the vesting accounts lock part of the balances set above
+	for i := range initial.Vesting {
+		err = balanceCtx.Store().WithState(app.Context.deliver).SetVesting(&initial.Vesting[i])
+		if err != nil {
+			return errors.Wrap(err, "failed to set vesting account")
+		}
+	}
 	for _, stake := range initial.Witness {
 		err = app.Context.witnesses.WithState(app.Context.deliver).AddWitness(chain.ETHEREUM, identity.Stake(stake))
 		if err != nil {
@@ -393,6 +450,15 @@ func (app *App) Prepare() error {
 
 		app.logger.Infof("Read currencies from db %#v", currencies)
 
+		// the currencies of the tokens created at runtime
+		app.Context.tokens.Iterate(func(token *tokens.Token) bool {
+			err = app.Context.currencies.Register(token.Currency)
+			return err != nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to register the token currencies")
+		}
+
 		feeOpt, err := app.Context.govern.WithHeight(app.header.Height).GetFeeOption()
 		if err != nil {
 			return err
@@ -400,6 +466,12 @@ func (app *App) Prepare() error {
 
 		app.Context.feePool.SetupOpt(feeOpt)
 
+		marketOpt, err := app.Context.govern.WithHeight(app.header.Height).GetFeeMarketOptions()
+		if err != nil {
+			return err
+		}
+		app.Context.feePool.SetupMarketOpt(marketOpt)
+
 		cdOpt, err := app.Context.govern.WithHeight(app.header.Height).GetETHChainDriverOption()
 		if err != nil {
 			return err
@@ -435,6 +507,12 @@ func (app *App) Prepare() error {
 		return errors.Wrap(err, "failed get genesisDoc")
 	}
 	app.genesisDoc = genesisDoc
+	app.Context.forks = genesisDoc.ForkParams
+	app.Context.balances.SetupForks(app.Context.forks)
+	if app.Context.forks.Height(config.NativeContractsFork) != 0 {
+		vm.EnableNativeContracts()
+	}
+	app.Context.feePool.SetupBlockGasLimit(app.blockMaxGas())
 
 	blockStoreChan := make(chan *store.BlockStore)
 	var wg sync.WaitGroup
@@ -542,7 +620,7 @@ func (app *App) rpcStarter() (func() error, error) {
 		return noop, err
 	}
 
-	services, err := app.Context.Services()
+	services, err := app.Context.Services(app.node)
 	if err != nil {
 		return noop, err
 	}
@@ -26,15 +26,20 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/batch"
 	"github.com/Oneledger/protocol/action/eth"
 	action_pen "github.com/Oneledger/protocol/action/evidence"
+	action_feegrant "github.com/Oneledger/protocol/action/feegrant"
 	action_gov "github.com/Oneledger/protocol/action/governance"
+	action_multisig "github.com/Oneledger/protocol/action/multisig"
 	action_netwkdeleg "github.com/Oneledger/protocol/action/network_delegation"
 	action_olvm "github.com/Oneledger/protocol/action/olvm"
 	action_ons "github.com/Oneledger/protocol/action/ons"
 	action_rewards "github.com/Oneledger/protocol/action/rewards"
 	"github.com/Oneledger/protocol/action/staking"
+	action_tokens "github.com/Oneledger/protocol/action/tokens"
 	"github.com/Oneledger/protocol/action/transfer"
+	action_vesting "github.com/Oneledger/protocol/action/vesting"
 	"github.com/Oneledger/protocol/app/node"
 	"github.com/Oneledger/protocol/client"
 	"github.com/Oneledger/protocol/config"
@@ -44,10 +49,13 @@ import (
 	"github.com/Oneledger/protocol/data/delegation"
 	"github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/evidence"
+	"github.com/Oneledger/protocol/data/feegrant"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/jobs"
+	"github.com/Oneledger/protocol/data/multisig"
 	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/data/tokens"
 	"github.com/Oneledger/protocol/event"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
@@ -71,6 +79,8 @@ type context struct {
 	chainstate *storage.ChainState
 	check      *storage.State
 	deliver    *storage.State
+	snapshots  *storage.SnapshotManager
+	archive    *storage.Archive
 
 	balances    *balance.Store
 	domains     *ons.DomainStore
@@ -94,6 +104,9 @@ type context struct {
 	netwkDelegators *netwkDeleg.MasterStore
 	evidenceStore   *evidence.EvidenceStore
 	rewardMaster    *rewards.RewardMasterStore
+	feeGrants       *feegrant.Store
+	multiSigs       *multisig.Store
+	tokens          *tokens.Store
 	transaction     *transactions.TransactionStore
 	logWriter       io.Writer
 	govupdate       *action.GovernaceUpdateAndValidate
@@ -107,6 +120,9 @@ type context struct {
 	accountKeeper balance.AccountKeeper
 	stateDB       *vm.CommitStateDB
 	blockStore    *store.BlockStore
+
+	// fork heights of the genesis
+	forks *config.ForkParams
 }
 
 func newContext(logWriter io.Writer, cfg config.Server, nodeCtx *node.Context) (context, error) {
@@ -131,6 +147,24 @@ func newContext(logWriter io.Writer, cfg config.Server, nodeCtx *node.Context) (
 	if errRotation != nil {
 		return ctx, errors.Wrap(errRotation, "error in loading chain state rotation config")
 	}
+	ctx.snapshots, err = storage.NewSnapshotManager(ctx.chainstate, ctx.dbDir(), ctx.cfg.Node.Snapshot)
+	if err != nil {
+		return ctx, errors.Wrap(err, "error in loading snapshot config")
+	}
+	if ctx.cfg.Node.Archive.Enabled {
+		archiveDB, err := storage.GetDatabase("archive", filepath.Join(ctx.dbDir(), ctx.cfg.Node.Archive.Dir), ctx.cfg.Node.DB)
+		if err != nil {
+			return ctx, errors.Wrap(err, "archive db failed")
+		}
+		ctx.archive, err = storage.NewArchive(archiveDB)
+		if err != nil {
+			return ctx, errors.Wrap(err, "error in loading the archive")
+		}
+		err = ctx.chainstate.SetupArchive(ctx.archive)
+		if err != nil {
+			return ctx, errors.Wrap(err, "error in archiving the chain state")
+		}
+	}
 	ctx.deliver = storage.NewState(ctx.chainstate)
 	ctx.check = storage.NewState(ctx.chainstate)
 
@@ -145,6 +179,9 @@ func newContext(logWriter io.Writer, cfg config.Server, nodeCtx *node.Context) (
 	ctx.netwkDelegators = netwkDeleg.NewMasterStore("deleg", "delegRwz", storage.NewState(ctx.chainstate))
 	ctx.evidenceStore = evidence.NewEvidenceStore("es", storage.NewState(ctx.chainstate))
 	ctx.rewardMaster = NewRewardMasterStore(ctx.chainstate)
+	ctx.feeGrants = feegrant.NewStore("fg", storage.NewState(ctx.chainstate))
+	ctx.multiSigs = multisig.NewStore("msa", storage.NewState(ctx.chainstate))
+	ctx.tokens = tokens.NewStore("tkn", storage.NewState(ctx.chainstate))
 	ctx.btcTrackers = bitcoin.NewTrackerStore("btct", storage.NewState(ctx.chainstate))
 	//Separate DB and chainstate
 	newDB := tmdb.NewDB("internaltxdb", tmdb.MemDBBackend, "")
@@ -212,6 +249,11 @@ func newContext(logWriter io.Writer, cfg config.Server, nodeCtx *node.Context) (
 	_ = action_gov.EnableInternalGovernance(ctx.internalRouter)
 	_ = staking.EnableStaking(ctx.actionRouter)
 	_ = action_pen.EnablePenalization(ctx.actionRouter)
+	_ = batch.EnableBatch(ctx.actionRouter)
+	_ = action_feegrant.EnableFeeGrant(ctx.actionRouter)
+	_ = action_multisig.EnableMultiSig(ctx.actionRouter)
+	_ = action_vesting.EnableVesting(ctx.actionRouter)
+	_ = action_tokens.EnableTokens(ctx.actionRouter)
 
 	return ctx, nil
 }
@@ -265,11 +307,86 @@ func (ctx *context) Action(header *Header, state *storage.State) *action.Context
 		ctx.extStores.WithState(state),
 		ctx.govupdate,
 		ctx.stateDB.WithState(state),
+		ctx.forks,
+		ctx.feeGrants.WithState(state),
+		ctx.multiSigs.WithState(state),
+		ctx.tokens.WithState(state),
 	)
 
 	return actionCtx
 }
 
+// IsolatedAction returns an action context with its own store instances bound to the given state, the stores
+// used by the block processing are left untouched so it is safe to use it outside of the consensus flow
+func (ctx *context) IsolatedAction(header *Header, state *storage.State) *action.Context {
+	feePool := fees.NewStore("f", state)
+	feePool.SetupOpt(ctx.feePool.GetOpt())
+	feePool.SetupMarketOpt(ctx.feePool.GetMarketOpt())
+	feePool.SetupBlockGasLimit(ctx.feePool.GetBlockGasLimit())
+
+	domains := ons.NewDomainStore("d", state)
+	domains.SetOptions(ctx.domains.GetOptions())
+
+	btcTrackers := bitcoin.NewTrackerStore("btct", state)
+	btcTrackers.SetConfig(ctx.btcTrackers.GetConfig())
+
+	ethTrackers := ethereum.NewTrackerStore("etht", "ethfailed", "ethsuccess", state)
+	ethTrackers.SetupOption(ctx.ethTrackers.GetOption())
+
+	proposalMaster := NewProposalMasterStore(ctx.chainstate)
+	proposalMaster.Proposal.SetOptions(ctx.proposalMaster.Proposal.GetOptions())
+
+	rewardMaster := NewRewardMasterStore(ctx.chainstate)
+	rewardMaster.SetOptions(ctx.rewardMaster.GetOptions())
+	rewardMaster.RewardCm.Init(ctx.blockStore)
+
+	balances := balance.NewStore("b", state)
+	balances.SetupForks(ctx.balances.GetForks())
+	contracts := evm.NewContractStore(state)
+	accountKeeper := balance.NewNesterAccountKeeper(state, balances, ctx.currencies)
+
+	logger := log.NewLoggerWithPrefix(ctx.logWriter, "action").WithLevel(log.Level(ctx.cfg.Node.LogLevel))
+	stateDB := vm.NewCommitStateDB(contracts, accountKeeper, logger)
+	stateDB.SetBlockStore(ctx.blockStore)
+
+	extStores, err := external_apps.NewExtStores(ctx.chainstate, state)
+	if err != nil {
+		logger.Error("failed to create external stores", "err", err)
+		extStores = data.NewStorageRouter()
+	}
+
+	return action.NewContext(
+		ctx.actionRouter,
+		header,
+		state,
+		ctx.accounts,
+		balances,
+		ctx.currencies,
+		feePool,
+		identity.NewValidatorStore("v", "purged", state),
+		identity.NewWitnessStore("w", state),
+		domains,
+		delegation.NewDelegationStore("st", state),
+		netwkDeleg.NewMasterStore("deleg", "delegRwz", state),
+		evidence.NewEvidenceStore("es", state),
+		btcTrackers,
+		ethTrackers,
+		ctx.jobStore,
+		ctx.lockScriptStore,
+		logger,
+		proposalMaster.WithState(state),
+		rewardMaster.WithState(state),
+		governance.NewStore("g", state),
+		extStores,
+		ctx.govupdate,
+		stateDB,
+		ctx.forks,
+		feegrant.NewStore("fg", state),
+		multisig.NewStore("msa", state),
+		tokens.NewStore("tkn", state),
+	)
+}
+
 func (ctx *context) ID() {}
 func (ctx *context) Accounts() accounts.Wallet {
 	return ctx.accounts
@@ -304,11 +421,12 @@ func (ctx *context) Web3Services(node *consensus.Node) map[string]web3types.Web3
 		&ctx.cfg,
 		ctx.chainstate,
 		ctx.currencies,
+		ctx.IsolatedAction,
 	)
 	return web3Ctx.ServiceList()
 }
 
-func (ctx *context) Services() (service.Map, error) {
+func (ctx *context) Services(node *consensus.Node) (service.Map, error) {
 	extSvcs, err := client.NewExtServiceContext(ctx.cfg.Network.RPCAddress, ctx.cfg.Network.SDKAddress)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to start service context")
@@ -319,6 +437,8 @@ func (ctx *context) Services() (service.Map, error) {
 
 	feePool := fees.NewStore("f", storage.NewState(ctx.chainstate))
 	feePool.SetupOpt(ctx.feePool.GetOpt())
+	feePool.SetupMarketOpt(ctx.feePool.GetMarketOpt())
+	feePool.SetupBlockGasLimit(ctx.feePool.GetBlockGasLimit())
 
 	ethTracker := ethereum.NewTrackerStore("etht", "ethfailed", "ethsuccess", storage.NewState(ctx.chainstate))
 	ethTracker.SetupOption(ctx.ethTrackers.GetOption())
@@ -359,7 +479,11 @@ func (ctx *context) Services() (service.Map, error) {
 		GovUpdate:       ctx.govupdate,
 		Contracts:       ctx.contracts,
 		AccountKeeper:   ctx.accountKeeper,
+		ChainState:      ctx.chainstate,
+		ActionCtx:       ctx.IsolatedAction,
 		StateDB:         ctx.stateDB,
+		MultiSigs:       multisig.NewStore("msa", storage.NewState(ctx.chainstate)),
+		Mempool:         node.Mempool(),
 	}
 
 	return service.NewMap(svcCtx)
@@ -403,6 +527,7 @@ type StorageCtx struct {
 	FeePool         *fees.Store
 	Govern          *governance.Store
 	Trackers        *ethereum.TrackerStore //TODO: Create struct to contain all tracker types including Bitcoin.
+	Tokens          *tokens.Store
 
 	Currencies *balance.CurrencySet
 	FeeOption  *fees.FeeOption
@@ -428,12 +553,16 @@ func (ctx *context) Storage() StorageCtx {
 		Currencies:      ctx.currencies,
 		FeeOption:       ctx.feePool.GetOpt(),
 		Trackers:        ctx.ethTrackers,
+		Tokens:          ctx.tokens,
 	}
 }
 
 // Close all things that need to be closed
 func (ctx *context) Close() {
 	closers := []closer{ctx.db, ctx.accounts, ctx.rpc, ctx.jobBus}
+	if ctx.archive != nil {
+		closers = append(closers, ctx.archive)
+	}
 	for _, closer := range closers {
 		err := closer.Close()
 		if err != nil {
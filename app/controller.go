@@ -12,7 +12,6 @@ import (
 	"github.com/tendermint/tendermint/libs/kv"
 	tmrpccore "github.com/tendermint/tendermint/rpc/core"
 
-	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/network_delegation"
 	"github.com/Oneledger/protocol/external_apps/common"
 
@@ -24,6 +23,7 @@ import (
 	abciTypes "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/app/forks"
 	ceth "github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/data/bitcoin"
@@ -64,10 +64,17 @@ func (app *App) infoServer() infoServer {
 }
 
 func (app *App) queryer() queryer {
-	return func(RequestQuery) ResponseQuery {
+	return func(req RequestQuery) ResponseQuery {
 		defer app.handlePanic()
-		// Do stuff
-		return ResponseQuery{}
+
+		path := splitQueryPath(req.Path)
+		if len(path) == 0 || path[0] != QueryPathStore {
+			return queryErrorResponse(req, newQueryError(CodeQueryUnknownPath, "unknown query path"))
+		}
+
+		result := app.queryStore(req, path[1:])
+		app.logger.Detail("Query:", req.Path, "height:", result.Height, "code:", result.Code)
+		return result
 	}
 }
 
@@ -127,43 +134,6 @@ func (app *App) chainInitializer() chainInitializer {
 	}
 }
 
-func (app *App) applyUpdate(req RequestBeginBlock) error {
-	height := req.Header.GetHeight()
-
-	if app.genesisDoc.ForkParams.IsFrankensteinBlock(height) {
-		govern := app.Context.govern.WithState(app.Context.deliver)
-
-		options, err := govern.GetStakingOptions()
-		if err != nil {
-			return err
-		}
-
-		options.TopValidatorCount = int64(64)
-		options.MinSelfDelegationAmount = *balance.NewAmountFromInt(500_000)
-
-		app.logger.Info("Updating top validator count to", options.TopValidatorCount)
-		app.logger.Info("Updating min self delegation amount to", options.MinSelfDelegationAmount)
-
-		err = govern.WithHeight(height).SetStakingOptions(*options)
-		if err != nil {
-			return errors.Wrap(err, "Setup Staking Options")
-		}
-		err = govern.WithHeight(height).SetLUH(governance.LAST_UPDATE_HEIGHT_STAKING)
-		if err != nil {
-			return errors.Wrap(err, "Unable to set last Update height")
-		}
-
-		app.logger.Info("Frankenstein applied at block", height)
-	}
-
-	// Update last block height and hash
-	if app.genesisDoc.ForkParams.IsFrankensteinUpdate(req.Header.GetHeight()) {
-		app.Context.stateDB.SetBlockHash(ethcmn.BytesToHash(req.GetHash()))
-	}
-
-	return nil
-}
-
 func (app *App) blockBeginner() blockBeginner {
 	return func(req RequestBeginBlock) ResponseBeginBlock {
 		defer app.handlePanic()
@@ -171,17 +141,30 @@ func (app *App) blockBeginner() blockBeginner {
 		gc := app.getGasCalculator()
 		app.Context.deliver = storage.NewState(app.Context.chainstate).WithGas(gc)
 
-		// Apply update at specific height
-		if err := app.applyUpdate(req); err != nil {
+		// Apply the changes of the active forks
+		if err := forks.BeginBlock(app.Context.Action(&req.Header, app.Context.deliver), req); err != nil {
 			panic(err)
 		}
 
+		// Stop at the height of the upgrade plan, or run its migration
+		if err := app.applyUpgrade(req); err != nil {
+			app.halt(err)
+		}
+
 		feeOpt, err := app.Context.govern.GetFeeOption()
 		if err != nil {
 			app.logger.Error("failed to get feeOption", err)
 		}
 		app.Context.feePool.SetupOpt(feeOpt)
 
+		marketOpt, err := app.Context.govern.GetFeeMarketOptions()
+		if err != nil {
+			app.logger.Error("failed to get fee market options", err)
+		}
+		app.Context.feePool.SetupMarketOpt(marketOpt)
+		app.blockGasUsed = 0
+		app.blockGasPrices = nil
+
 		err = ManageVotes(&req, &app.Context, app.logger)
 		if err != nil {
 			app.logger.Error("manage votes error", err)
@@ -265,6 +248,9 @@ func (app *App) txChecker() txChecker {
 		txCtx := app.Context.Action(&app.header, app.Context.check)
 		handler := txCtx.Router.Handler(tx.Type)
 
+		txCtx.State.SetTxType(int(tx.Type))
+		defer txCtx.State.SetTxType(storage.NO_TX_TYPE)
+
 		gas := txCtx.State.ConsumedGas()
 
 		ok, err := handler.Validate(txCtx, *tx)
@@ -276,6 +262,27 @@ func (app *App) txChecker() txChecker {
 			}
 		}
 
+		err = action.ValidateFeePayer(txCtx, *tx)
+		if err != nil {
+			app.logger.Debug("Check Tx invalid fee payer: ", err.Error())
+			return ResponseCheckTx{
+				Code: CodeNotOK.uint32(),
+				Log:  err.Error(),
+			}
+		}
+
+		withNonce := action.NonceRequired(txCtx, *tx)
+		if withNonce {
+			err := action.ValidateNonce(txCtx, *tx)
+			if err != nil {
+				app.logger.Debug("Check Tx invalid nonce: ", err.Error())
+				return ResponseCheckTx{
+					Code: CodeNotOK.uint32(),
+					Log:  err.Error(),
+				}
+			}
+		}
+
 		ok, response := handler.ProcessCheck(txCtx, tx.RawTx)
 
 		feeOk, feeResponse := handler.ProcessFee(txCtx, *tx, gas, storage.Gas(len(msg.Tx)), storage.Gas(response.GasUsed))
@@ -293,7 +300,16 @@ func (app *App) txChecker() txChecker {
 			Codespace: "",
 		}
 
-		if !(ok && feeOk) {
+		if ok && feeOk && withNonce {
+			err := action.IncrementNonce(txCtx, *tx)
+			if err != nil {
+				app.logger.Error("failed to increment account nonce", err)
+				result.Code = CodeNotOK.uint32()
+				result.Log = err.Error()
+			}
+		}
+
+		if result.Code != CodeOK.uint32() {
 			app.Context.check.DiscardTxSession()
 		} else {
 			app.Context.check.CommitTxSession()
@@ -319,8 +335,6 @@ func (app *App) txDeliverer() txDeliverer {
 			return cachedResponse
 		}
 
-		app.Context.deliver.BeginTxSession()
-
 		tx := &action.SignedTx{}
 
 		err := serialize.GetSerializer(serialize.NETWORK).Deserialize(msg.Tx, tx)
@@ -329,12 +343,14 @@ func (app *App) txDeliverer() txDeliverer {
 		}
 		txCtx := app.Context.Action(&app.header, app.Context.deliver)
 
-		handler := txCtx.Router.Handler(tx.Type)
-
-		gas := txCtx.State.ConsumedGas()
-
-		ok, response := handler.ProcessDeliver(txCtx, tx.RawTx)
-		feeOk, feeResponse := handler.ProcessFee(txCtx, *tx, gas, storage.Gas(len(msg.Tx)), storage.Gas(response.GasUsed))
+		ok, response, feeOk, feeResponse, err := action.DeliverTx(txCtx, tx, storage.Gas(len(msg.Tx)))
+		if err != nil {
+			app.logger.Detail("Deliver Tx invalid nonce: ", err.Error())
+			return ResponseDeliverTx{
+				Code: CodeNotOK.uint32(),
+				Log:  err.Error(),
+			}
+		}
 
 		logString := marshalLog(ok, response, feeResponse)
 
@@ -349,18 +365,19 @@ func (app *App) txDeliverer() txDeliverer {
 			Codespace: "",
 		}
 		app.logger.Detail("Deliver Tx: ", result)
-
-		app.Context.stateDB.Finality(response.Events)
-
-		if !(ok && feeOk) {
-			app.Context.deliver.DiscardTxSession()
-		} else {
-			app.Context.deliver.CommitTxSession()
-		}
+		app.addBlockGas(tx, feeOk, feeResponse.GasUsed)
 		return result
 	}
 }
 
+// addBlockGas adds the gas used by a transaction to the block statistics, along with its gas price once it is paid
+func (app *App) addBlockGas(tx *action.SignedTx, feeOk bool, gasUsed int64) {
+	app.blockGasUsed += gasUsed
+	if feeOk && gasUsed > 0 {
+		app.blockGasPrices = fees.AddGasPrice(app.blockGasPrices, tx.Fee.Price.Value, gasUsed)
+	}
+}
+
 func (app *App) blockEnder() blockEnder {
 	return func(req RequestEndBlock) ResponseEndBlock {
 		defer app.handlePanic()
@@ -399,16 +416,26 @@ func (app *App) blockEnder() blockEnder {
 			}
 		}
 
-		if app.genesisDoc.ForkParams.IsFrankensteinUpdate(req.GetHeight()) {
-			// getting bloom if exist
-			bloomEvt := app.Context.stateDB.GetBloomEvent()
-			if bloomEvt != nil {
-				events = append(events, *bloomEvt)
+		// keep the gas usage of the block for eth_feeHistory, before the base fee moves to the next block
+		if app.Context.forks != nil && app.Context.forks.IsActive(config.FeeHistoryFork, req.Height) {
+			err = app.Context.feePool.WithState(app.Context.deliver).RecordBlockFee(req.Height, app.blockGasUsed, app.blockMaxGas(), app.blockGasPrices)
+			if err != nil {
+				app.logger.Error("failed to record the block fee", err)
 			}
-			// Reset all cache after account data has been committed, that make sure node state consistent
-			app.Context.stateDB.Reset()
 		}
 
+		// move the base fee of the fee market toward the target gas usage
+		err = app.Context.feePool.WithState(app.Context.deliver).UpdateBaseFee(req.Height, app.blockGasUsed, app.blockMaxGas())
+		if err != nil {
+			app.logger.Error("failed to update the base fee", err)
+		}
+
+		forkEvents, err := forks.EndBlock(app.Context.Action(&app.header, app.Context.deliver), req)
+		if err != nil {
+			panic(err)
+		}
+		events = append(events, forkEvents...)
+
 		result := ResponseEndBlock{
 			ValidatorUpdates: updates,
 			Events:           events,
@@ -425,6 +452,9 @@ func (app *App) commitor() commitor {
 		hash, ver := app.Context.deliver.Commit()
 		app.logger.Detailf("Committed New Block height[%d], hash[%s], versions[%d]", app.header.Height, hex.EncodeToString(hash), ver)
 
+		// snapshot the committed version for state sync, if it falls on the interval
+		app.Context.snapshots.Take(ver)
+
 		// update check state by deliver state
 		gc := app.getGasCalculator()
 		app.Context.check = storage.NewState(app.Context.chainstate).WithGas(gc)
@@ -459,18 +489,30 @@ func (app *App) handlePanic() {
 	}
 }
 
-func (app *App) getGasCalculator() storage.GasCalculator {
-	limit := int64(0)
-	if app.genesisDoc.ConsensusParams != nil {
-		limit = app.genesisDoc.ConsensusParams.Block.MaxGas
+// blockMaxGas returns the gas limit of the blocks set by the genesis, -1 when they are unlimited and 0 when the
+// genesis has no consensus params
+func (app *App) blockMaxGas() int64 {
+	if app.genesisDoc.ConsensusParams == nil {
+		return 0
 	}
+	return app.genesisDoc.ConsensusParams.Block.MaxGas
+}
+
+// getGasCalculator returns a calculator pricing the operations with the gas schedule of the last committed block
+func (app *App) getGasCalculator() storage.GasCalculator {
+	limit := app.blockMaxGas()
 	gas := storage.Gas(0)
 	if limit < 0 {
 		gas = math.MaxInt64
 	} else {
 		gas = storage.Gas(limit)
 	}
-	return storage.NewGasCalculator(gas)
+	gasOpt, err := app.Context.govern.GetGasOptions()
+	if err != nil {
+		app.logger.Error("failed to get gas options", err)
+		return storage.NewGasCalculator(gas)
+	}
+	return storage.NewGasCalculatorWithOptions(gas, *gasOpt)
 }
 
 func doTransitions(js *jobs.JobStore, ts *bitcoin.TrackerStore, validators *identity.ValidatorStore) {
@@ -593,19 +635,15 @@ func handleDelegationRewards(delegCtx *network_delegation.DelegationRewardCtx, a
 		return
 	}
 
-	//Distribute Rewards to Each Delegator
-	networkDelegators.Deleg.IterateActiveAmounts(func(addr *keys.Address, coin *balance.Coin) bool {
-		//Calculate reward portion for each delegator based on delegated amount
-		numerator := big.NewInt(0).Mul(resp.DelegationRewards.BigInt(), coin.Amount.BigInt())
-		delegatorReward := balance.NewAmountFromBigInt(big.NewInt(0).Div(numerator, delegCtx.DelegationPower))
-
-		//Add reward to address
-		err := networkDelegators.Rewards.AddRewardsBalance(*addr, delegatorReward)
+	//Distribute Rewards to the delegators through the reward index, they are settled when the delegators use them
+	if appCtx.forks != nil && appCtx.forks.IsActive(config.LazyRewardsFork, delegCtx.Height) {
+		err = networkDelegators.Rewards.AccumulateRewards(resp.DelegationRewards, delegCtx.DelegationPower)
 		if err != nil {
-			return true
+			return
 		}
-		return false
-	})
+	} else {
+		distributeDelegationRewards(networkDelegators, resp.DelegationRewards, delegCtx.DelegationPower)
+	}
 
 	//Create Event for Proposer Reward
 	proposerKey := "proposer_" + delegCtx.ProposerAddress.String()
@@ -623,6 +661,54 @@ func handleDelegationRewards(delegCtx *network_delegation.DelegationRewardCtx, a
 	return
 }
 
+// handleValidatorDelegationRewards shares the rewards of the delegators who picked the validator with the commission
+// declared by the validator, the validators which didn't declare any take the commission of the pool. It returns the
+// commission of the validator and the rewards of its delegators.
+func handleValidatorDelegationRewards(appCtx *context, val *identity.Validator, power *big.Int, totalPower *big.Int,
+	totalRewards *balance.Amount, kvMap map[string]kv.Pair) (commission *balance.Amount, delegatorsRewards *balance.Amount, err error) {
+	networkDelegators := appCtx.netwkDelegators.WithState(appCtx.deliver)
+
+	//Get Total Rewards of the delegators of the validator and cut the commission of the validator
+	rewards := getRewardForValidator(totalPower, power, totalRewards)
+	rate := val.CommissionRate(network_delegation.COMMISSION_PERCENTAGE)
+	numerator := big.NewInt(0).Mul(big.NewInt(rate), rewards.BigInt())
+	commission = balance.NewAmountFromBigInt(big.NewInt(0).Div(numerator, big.NewInt(100)))
+	delegatorsRewards, err = rewards.Minus(*commission)
+	if err != nil {
+		return
+	}
+
+	err = networkDelegators.Rewards.AccumulateValidatorRewards(val.Address, delegatorsRewards, power)
+	if err != nil {
+		return
+	}
+
+	//Create Event for the Rewards of the delegators of the validator
+	delegatorsKey := "delegators_" + val.Address.String()
+	kvMap[delegatorsKey] = kv.Pair{
+		Key:   []byte(delegatorsKey),
+		Value: []byte(delegatorsRewards.String()),
+	}
+	return
+}
+
+// distributeDelegationRewards adds the share of the rewards to the balance of every active delegator, it is how the
+// rewards were distributed before the reward index
+func distributeDelegationRewards(networkDelegators *network_delegation.MasterStore, rewards *balance.Amount, power *big.Int) {
+	networkDelegators.Deleg.IterateActiveAmounts(func(addr *keys.Address, coin *balance.Coin) bool {
+		//Calculate reward portion for each delegator based on delegated amount
+		numerator := big.NewInt(0).Mul(rewards.BigInt(), coin.Amount.BigInt())
+		delegatorReward := balance.NewAmountFromBigInt(big.NewInt(0).Div(numerator, power))
+
+		//Add reward to address
+		err := networkDelegators.Rewards.AddRewardsBalance(*addr, delegatorReward)
+		if err != nil {
+			return true
+		}
+		return false
+	})
+}
+
 func handleBlockRewards(appCtx *context, block RequestBeginBlock, logger *log.Logger) abciTypes.Event {
 	votes := block.LastCommitInfo.Votes
 	lastHeight := block.GetHeader().Height
@@ -682,6 +768,21 @@ func handleBlockRewards(appCtx *context, block RequestBeginBlock, logger *log.Lo
 	delegationPower := delegationPoolCoin.Amount.BigInt()
 	totalPower.Add(totalPower, delegationPower)
 
+	//Split the Delegation Power between the validators picked by the delegators and the pool
+	validatorDelegationPower := make(map[string]*big.Int)
+	poolPower := big.NewInt(0).Set(delegationPower)
+	if appCtx.forks != nil && appCtx.forks.IsActive(config.LazyRewardsFork, lastHeight) &&
+		appCtx.forks.IsActive(config.ValidatorDelegationFork, lastHeight) {
+		appCtx.netwkDelegators.WithState(appCtx.deliver).Deleg.IterateValidatorPowers(func(validator *keys.Address, coin *balance.Coin) bool {
+			validatorDelegationPower[validator.String()] = coin.Amount.BigInt()
+			poolPower.Sub(poolPower, coin.Amount.BigInt())
+			return false
+		})
+		if poolPower.Sign() < 0 {
+			poolPower.SetInt64(0)
+		}
+	}
+
 	//get total rewards for the block
 	rewardPoolCoin, err := appCtx.balances.WithState(appCtx.deliver).GetBalanceForCurr(poolList["RewardsPool"], &curr)
 	if err != nil {
@@ -694,10 +795,10 @@ func handleBlockRewards(appCtx *context, block RequestBeginBlock, logger *log.Lo
 
 	totalConsumed := balance.NewAmount(0)
 	delegationResp := &network_delegation.DelegationRewardResponse{}
-	if delegationPower.Cmp(big.NewInt(0)) > 0 {
+	if poolPower.Cmp(big.NewInt(0)) > 0 {
 		delegationCtx := &network_delegation.DelegationRewardCtx{
 			TotalRewards:    totalRewards,
-			DelegationPower: delegationPower,
+			DelegationPower: poolPower,
 			TotalPower:      totalPower,
 			Height:          lastHeight,
 			ProposerAddress: keys.Address(block.Header.ProposerAddress),
@@ -723,7 +824,7 @@ func handleBlockRewards(appCtx *context, block RequestBeginBlock, logger *log.Lo
 			//Get Commission and Reward Amounts for Validator
 			rewardAmount := getRewardForValidator(totalPower, validatorPowerMap[valAddress.String()], totalRewards)
 			commissionAmount := balance.NewAmount(0)
-			if delegationPower.Cmp(big.NewInt(0)) > 0 {
+			if poolPower.Cmp(big.NewInt(0)) > 0 {
 				commissionAmount = getRewardForValidator(totValPower, validatorPowerMap[valAddress.String()], delegationResp.Commission)
 
 				if valAddress.String() == keys.Address(block.Header.ProposerAddress).String() {
@@ -732,6 +833,18 @@ func handleBlockRewards(appCtx *context, block RequestBeginBlock, logger *log.Lo
 			}
 			//Add Commission from Delegation rewards
 			amount := rewardAmount.Plus(*commissionAmount)
+
+			//Add Commission from the rewards of the delegators who picked the validator
+			if power, ok := validatorDelegationPower[valAddress.String()]; ok && power.Sign() > 0 {
+				validatorCommission, delegatorsRewards, err := handleValidatorDelegationRewards(appCtx, val, power, totalPower, totalRewards, kvMap)
+				if err != nil {
+					// the rewards of the delegators stay in the rewards pool
+					logger.Error("failed to distribute the rewards of the delegators of validator", valAddress.String(), err)
+				} else {
+					amount = amount.Plus(*validatorCommission)
+					totalConsumed = totalConsumed.Plus(*delegatorsRewards)
+				}
+			}
 			//Add Amount to reward store
 			err = rewardMaster.Reward.AddToAddress(valAddress, lastHeight, amount)
 			if err != nil {
@@ -872,7 +985,7 @@ func addMaturedAmountsToBalance(ctx *context, logger *log.Logger, req *RequestBe
 	// put all the pending amounts at this height directly to delegator's balance
 	delegStore.IteratePendingAmounts(height, func(addr *keys.Address, coin *balance.Coin) bool {
 		//Add each of them to user's address
-		err := balanceStore.AddToAddress(*addr, *coin)
+		err := balanceStore.UndelegateToAddress(*addr, *coin)
 		if err != nil {
 			logger.Errorf("failed to add pending undelegation amount at height: %d to address: %s", height, addr.String())
 			panic(err)
@@ -0,0 +1,80 @@
+package forks
+
+import (
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/config"
+)
+
+// Fork is a network upgrade, it activates at the height set for its name in the genesis fork params. The changes
+// to the transactions are gated by action.Context.IsForkActive, the changes to the block processing by the hooks.
+type Fork struct {
+	Name string
+
+	// BeginBlock runs at the beginning of every block once the fork is active, activation is set on the first one
+	BeginBlock func(ctx *action.Context, req abci.RequestBeginBlock, activation bool) error
+
+	// EndBlock runs at the end of every block once the fork is active, the events are added to the block
+	EndBlock func(ctx *action.Context, req abci.RequestEndBlock, activation bool) ([]abci.Event, error)
+}
+
+// registry of the forks, the hooks run in this order
+var registry = []*Fork{
+	frankenstein,
+	{Name: config.NonceFork},
+	{Name: config.DomainRecordsFork},
+	lazyRewards,
+	{Name: config.ValidatorDelegationFork},
+	{Name: config.DelegatorVotingFork},
+	{Name: config.BatchFork},
+	{Name: config.FeeGrantFork},
+	{Name: config.MultiSigFork},
+	{Name: config.VestingFork},
+	tokenFactory,
+	{Name: config.TypedTxFork},
+	{Name: config.NativeContractsFork},
+	{Name: config.FeeHistoryFork},
+}
+
+// Get returns the fork registered with the name
+func Get(name string) (*Fork, bool) {
+	for _, fork := range registry {
+		if fork.Name == name {
+			return fork, true
+		}
+	}
+	return nil, false
+}
+
+// BeginBlock runs the begin block hooks of the forks active at the height of the block
+func BeginBlock(ctx *action.Context, req abci.RequestBeginBlock) error {
+	height := req.Header.GetHeight()
+	for _, fork := range registry {
+		if fork.BeginBlock == nil || !ctx.IsForkActive(fork.Name) {
+			continue
+		}
+		err := fork.BeginBlock(ctx, req, ctx.Forks.Height(fork.Name) == height)
+		if err != nil {
+			return errors.Wrapf(err, "fork %s failed at begin block %d", fork.Name, height)
+		}
+	}
+	return nil
+}
+
+// EndBlock runs the end block hooks of the forks active at the height of the block and returns their events
+func EndBlock(ctx *action.Context, req abci.RequestEndBlock) ([]abci.Event, error) {
+	events := make([]abci.Event, 0)
+	for _, fork := range registry {
+		if fork.EndBlock == nil || !ctx.IsForkActive(fork.Name) {
+			continue
+		}
+		forkEvents, err := fork.EndBlock(ctx, req, ctx.Forks.Height(fork.Name) == req.Height)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fork %s failed at end block %d", fork.Name, req.Height)
+		}
+		events = append(events, forkEvents...)
+	}
+	return events, nil
+}
@@ -0,0 +1,47 @@
+package forks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/config"
+)
+
+func TestGet(t *testing.T) {
+	fork, ok := Get(config.FrankensteinFork)
+	assert.True(t, ok)
+	assert.NotNil(t, fork.BeginBlock)
+
+	_, ok = Get("unknown")
+	assert.False(t, ok)
+}
+
+func TestBeginBlock(t *testing.T) {
+	defer func(forks []*Fork) { registry = forks }(registry)
+
+	activations := make([]bool, 0)
+	registry = []*Fork{{
+		Name: "test",
+		BeginBlock: func(ctx *action.Context, req abci.RequestBeginBlock, activation bool) error {
+			activations = append(activations, activation)
+			return nil
+		},
+		EndBlock: func(ctx *action.Context, req abci.RequestEndBlock, activation bool) ([]abci.Event, error) {
+			return []abci.Event{{Type: "test"}}, nil
+		},
+	}}
+
+	forks := &config.ForkParams{Forks: []config.ForkHeight{{Name: "test", Height: 2}}}
+	for height := int64(1); height <= 3; height++ {
+		ctx := &action.Context{Header: &abci.Header{Height: height}, Forks: forks}
+		assert.NoError(t, BeginBlock(ctx, abci.RequestBeginBlock{Header: *ctx.Header}))
+
+		events, err := EndBlock(ctx, abci.RequestEndBlock{Height: height})
+		assert.NoError(t, err)
+		assert.Equal(t, height >= 2, len(events) == 1)
+	}
+	assert.Equal(t, []bool{true, false}, activations)
+}
@@ -0,0 +1,62 @@
+package forks
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/governance"
+)
+
+// frankenstein brings the evm, it also raises the number of validators and the min self delegation
+var frankenstein = &Fork{
+	Name: config.FrankensteinFork,
+
+	BeginBlock: func(ctx *action.Context, req abci.RequestBeginBlock, activation bool) error {
+		if activation {
+			height := req.Header.GetHeight()
+			govern := ctx.GovernanceStore
+
+			options, err := govern.GetStakingOptions()
+			if err != nil {
+				return err
+			}
+
+			options.TopValidatorCount = int64(64)
+			options.MinSelfDelegationAmount = *balance.NewAmountFromInt(500_000)
+
+			ctx.Logger.Info("Updating top validator count to", options.TopValidatorCount)
+			ctx.Logger.Info("Updating min self delegation amount to", options.MinSelfDelegationAmount)
+
+			err = govern.WithHeight(height).SetStakingOptions(*options)
+			if err != nil {
+				return errors.Wrap(err, "Setup Staking Options")
+			}
+			err = govern.WithHeight(height).SetLUH(governance.LAST_UPDATE_HEIGHT_STAKING)
+			if err != nil {
+				return errors.Wrap(err, "Unable to set last Update height")
+			}
+
+			ctx.Logger.Info("Frankenstein applied at block", height)
+		}
+
+		// Update last block height and hash
+		ctx.StateDB.SetBlockHash(ethcmn.BytesToHash(req.GetHash()))
+		return nil
+	},
+
+	EndBlock: func(ctx *action.Context, req abci.RequestEndBlock, activation bool) ([]abci.Event, error) {
+		events := make([]abci.Event, 0)
+		// getting bloom if exist
+		bloomEvt := ctx.StateDB.GetBloomEvent()
+		if bloomEvt != nil {
+			events = append(events, *bloomEvt)
+		}
+		// Reset all cache after account data has been committed, that make sure node state consistent
+		ctx.StateDB.Reset()
+		return events, nil
+	},
+}
@@ -0,0 +1,28 @@
+package forks
+
+import (
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/config"
+)
+
+// lazyRewards stops crediting every network delegator at each block, the rewards are accumulated in a reward index
+// and settled when the delegators change their stake or use their rewards
+var lazyRewards = &Fork{
+	Name: config.LazyRewardsFork,
+
+	BeginBlock: func(ctx *action.Context, req abci.RequestBeginBlock, activation bool) error {
+		if !activation {
+			return nil
+		}
+		// the balances credited so far stay, the active delegations become the stakes of the reward index
+		err := ctx.NetwkDelegators.Rewards.MigrateToRewardIndex(ctx.NetwkDelegators.Deleg)
+		if err != nil {
+			return errors.Wrap(err, "failed to migrate the delegation rewards")
+		}
+		ctx.Logger.Info("Lazy delegation rewards applied at block", req.Header.GetHeight())
+		return nil
+	},
+}
@@ -0,0 +1,34 @@
+package forks
+
+import (
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/config"
+)
+
+// tokenFactory registers the currencies of the tokens created in the block at its end, so they can be used from the
+// next block on
+var tokenFactory = &Fork{
+	Name: config.TokenFactoryFork,
+
+	EndBlock: func(ctx *action.Context, req abci.RequestEndBlock, activation bool) ([]abci.Event, error) {
+		names, err := ctx.Tokens.CreatedAt(req.Height)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			token, err := ctx.Tokens.Get(name)
+			if err != nil {
+				return nil, err
+			}
+			err = ctx.Currencies.Register(token.Currency)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to register the currency of token %s", name)
+			}
+			ctx.Logger.Info("Token currency registered", name, "at block", req.Height)
+		}
+		return nil, nil
+	},
+}
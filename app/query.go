@@ -0,0 +1,245 @@
+package app
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/merkle"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/identity"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// ABCI query paths served against the chain state, all of them honour req.Height and req.Prove
+//
+//	/store/balance/<addr>[/<currency>]	raw balance amount of an address, OLT if currency is omitted
+//	/store/ons/<name>					raw domain object
+//	/store/validators					json list of all validators (no proof)
+//	/store/validators/<addr>			raw validator object
+//	/store/raw/<hex key>				raw value under any chain state key
+const (
+	QueryPathStore = "store"
+
+	QueryStoreBalance    = "balance"
+	QueryStoreONS        = "ons"
+	QueryStoreValidators = "validators"
+	QueryStoreRaw        = "raw"
+)
+
+const (
+	CodeQueryUnknownPath    Code = 0x10
+	CodeQueryInvalidRequest Code = 0x11
+	CodeQueryHeightNotFound Code = 0x12
+	CodeQueryFailed         Code = 0x13
+)
+
+// queryError is returned by the query handlers to choose the response code
+type queryError struct {
+	code Code
+	err  error
+}
+
+func (e *queryError) Error() string {
+	return e.err.Error()
+}
+
+func newQueryError(code Code, format string, args ...interface{}) *queryError {
+	return &queryError{code: code, err: errors.Errorf(format, args...)}
+}
+
+// queryStore routes a "/store/..." query to the key it refers to and reads it from the chain state, the read lock
+// keeps Commit from saving or deleting a version of the tree while it is read
+func (app *App) queryStore(req RequestQuery, path []string) ResponseQuery {
+	cs := app.Context.chainstate
+	cs.RLock()
+	defer cs.RUnlock()
+
+	height := req.Height
+	if height == 0 {
+		height = cs.Version
+	}
+	if height < 0 || !cs.Readable(height) {
+		return queryErrorResponse(req, newQueryError(CodeQueryHeightNotFound,
+			"height %d is not available, latest: %d", height, cs.Version))
+	}
+	// the archive keeps the values of the deleted versions, not their merkle trees
+	if req.Prove && !cs.VersionExists(height) {
+		return queryErrorResponse(req, newQueryError(CodeQueryHeightNotFound,
+			"height %d is only archived, it can't be proven", height))
+	}
+
+	if len(path) == 0 {
+		return queryErrorResponse(req, newQueryError(CodeQueryUnknownPath, "empty store path"))
+	}
+
+	// the validator list is the only range query, everything else resolves to a single key
+	if path[0] == QueryStoreValidators && len(path) == 1 {
+		value, err := app.queryValidatorList(height)
+		if err != nil {
+			return queryErrorResponse(req, err)
+		}
+		return ResponseQuery{
+			Code:   CodeOK.uint32(),
+			Key:    app.Context.validators.GetPrefix(),
+			Value:  value,
+			Height: height,
+		}
+	}
+
+	key, err := app.queryStoreKey(path)
+	if err != nil {
+		return queryErrorResponse(req, err)
+	}
+
+	var value []byte
+	var proof *iavl.RangeProof
+	if cs.VersionExists(height) {
+		value, proof, err = cs.GetVersionedWithProof(height, key)
+		if err != nil {
+			return queryErrorResponse(req, newQueryError(CodeQueryFailed, "failed to read key %s: %s", key.String(), err))
+		}
+	} else {
+		_, value = cs.GetVersioned(height, key)
+	}
+
+	resp := ResponseQuery{
+		Code:   CodeOK.uint32(),
+		Key:    key,
+		Value:  value,
+		Height: height,
+	}
+	if len(value) == 0 {
+		resp.Log = "does not exist"
+	}
+	if req.Prove {
+		resp.Proof = newStoreProof(key, value, proof)
+	}
+	return resp
+}
+
+func (app *App) queryStoreKey(path []string) (storage.StoreKey, error) {
+	switch path[0] {
+	case QueryStoreBalance:
+		if len(path) < 2 || len(path) > 3 {
+			return nil, newQueryError(CodeQueryInvalidRequest, "expected /store/balance/<addr>[/<currency>]")
+		}
+		addr := keys.Address{}
+		if err := addr.UnmarshalText([]byte(path[1])); err != nil {
+			return nil, newQueryError(CodeQueryInvalidRequest, "invalid address %s: %s", path[1], err)
+		}
+		currName := "OLT"
+		if len(path) == 3 {
+			currName = path[2]
+		}
+		curr, ok := app.Context.currencies.GetCurrencyByName(currName)
+		if !ok {
+			return nil, newQueryError(CodeQueryInvalidRequest, "currency %s not supported", currName)
+		}
+		coin := curr.NewCoinFromAmount(*balance.NewAmount(0))
+		return app.Context.balances.BuildKey(addr, &coin), nil
+
+	case QueryStoreONS:
+		if len(path) != 2 {
+			return nil, newQueryError(CodeQueryInvalidRequest, "expected /store/ons/<name>")
+		}
+		name := ons.GetNameFromString(path[1])
+		if !name.IsValid() {
+			return nil, newQueryError(CodeQueryInvalidRequest, "invalid domain name %s", path[1])
+		}
+		return app.Context.domains.BuildKey(name), nil
+
+	case QueryStoreValidators:
+		if len(path) != 2 {
+			return nil, newQueryError(CodeQueryInvalidRequest, "expected /store/validators[/<addr>]")
+		}
+		addr := keys.Address{}
+		if err := addr.UnmarshalText([]byte(path[1])); err != nil {
+			return nil, newQueryError(CodeQueryInvalidRequest, "invalid address %s: %s", path[1], err)
+		}
+		return app.Context.validators.BuildKey(addr), nil
+
+	case QueryStoreRaw:
+		if len(path) != 2 {
+			return nil, newQueryError(CodeQueryInvalidRequest, "expected /store/raw/<hex key>")
+		}
+		key, err := hex.DecodeString(strings.TrimPrefix(path[1], "0x"))
+		if err != nil || len(key) == 0 {
+			return nil, newQueryError(CodeQueryInvalidRequest, "invalid hex key %s", path[1])
+		}
+		return key, nil
+	}
+
+	return nil, newQueryError(CodeQueryUnknownPath, "unknown store path %s", path[0])
+}
+
+func (app *App) queryValidatorList(height int64) ([]byte, error) {
+	prefix := app.Context.validators.GetPrefix()
+	validators := make([]identity.Validator, 0)
+
+	var decodeErr error
+	_, err := app.Context.chainstate.IterateRangeVersioned(height, prefix, storage.Rangefix(string(prefix)), true,
+		func(key, value []byte) bool {
+			validator, err := (&identity.Validator{}).FromBytes(value)
+			if err != nil {
+				decodeErr = errors.Wrapf(err, "key %s", hex.EncodeToString(key))
+				return true
+			}
+			validators = append(validators, *validator)
+			return false
+		})
+	if err != nil {
+		return nil, newQueryError(CodeQueryHeightNotFound, "failed to read validators at height %d: %s", height, err)
+	}
+	if decodeErr != nil {
+		return nil, newQueryError(CodeQueryFailed, "failed to decode validator: %s", decodeErr)
+	}
+
+	value, err := serialize.GetSerializer(serialize.JSON).Serialize(validators)
+	if err != nil {
+		return nil, newQueryError(CodeQueryFailed, "failed to serialize validators: %s", err)
+	}
+	return value, nil
+}
+
+// newStoreProof wraps the IAVL range proof into a merkle proof op, the value op proves existence of the
+// key and the absence op proves it is not set
+func newStoreProof(key, value []byte, proof *iavl.RangeProof) *merkle.Proof {
+	if proof == nil {
+		return nil
+	}
+	var op merkle.ProofOp
+	if len(value) > 0 {
+		op = iavl.NewValueOp(key, proof).ProofOp()
+	} else {
+		op = iavl.NewAbsenceOp(key, proof).ProofOp()
+	}
+	return &merkle.Proof{Ops: []merkle.ProofOp{op}}
+}
+
+func queryErrorResponse(req RequestQuery, err error) ResponseQuery {
+	code := CodeNotOK
+	if qErr, ok := err.(*queryError); ok {
+		code = qErr.code
+	}
+	return ResponseQuery{
+		Code:   code.uint32(),
+		Log:    fmt.Sprintf("query %s: %s", req.Path, err),
+		Height: req.Height,
+	}
+}
+
+// splitQueryPath splits "/store/balance/0x.." into its segments
+func splitQueryPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return []string{}
+	}
+	return strings.Split(path, "/")
+}
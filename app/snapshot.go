@@ -0,0 +1,137 @@
+package app
+
+import (
+	"github.com/Oneledger/protocol/storage"
+)
+
+// state sync connection: serves chain state snapshots to peers and restores from one of theirs
+
+func (app *App) snapshotLister() snapshotLister {
+	return func(req RequestListSnapshots) ResponseListSnapshots {
+		defer app.handlePanic()
+
+		result := ResponseListSnapshots{}
+		snapshots, err := app.Context.snapshots.List()
+		if err != nil {
+			app.logger.Error("failed to list snapshots", "err", err)
+			return result
+		}
+		for _, s := range snapshots {
+			metadata, err := s.Metadata()
+			if err != nil {
+				app.logger.Error("failed to read snapshot metadata", "height", s.Height, "err", err)
+				continue
+			}
+			result.Snapshots = append(result.Snapshots, &Snapshot{
+				Height:   uint64(s.Height),
+				Format:   s.Format,
+				Chunks:   s.Chunks,
+				Hash:     s.Hash,
+				Metadata: metadata,
+			})
+		}
+		return result
+	}
+}
+
+func (app *App) snapshotOfferer() snapshotOfferer {
+	return func(req RequestOfferSnapshot) ResponseOfferSnapshot {
+		defer app.handlePanic()
+
+		if req.Snapshot == nil {
+			return ResponseOfferSnapshot{Result: OfferSnapshotReject}
+		}
+		if req.Snapshot.Format != storage.SnapshotFormat {
+			return ResponseOfferSnapshot{Result: OfferSnapshotRejectFormat}
+		}
+
+		snapshot := &storage.Snapshot{
+			Height:  int64(req.Snapshot.Height),
+			Format:  req.Snapshot.Format,
+			Chunks:  req.Snapshot.Chunks,
+			Hash:    req.Snapshot.Hash,
+			AppHash: req.AppHash,
+		}
+		err := snapshot.SetMetadata(req.Snapshot.Metadata)
+		if err != nil {
+			app.logger.Info("rejected snapshot with invalid metadata", "height", snapshot.Height, "err", err)
+			return ResponseOfferSnapshot{Result: OfferSnapshotReject}
+		}
+
+		err = app.Context.snapshots.Offer(snapshot, req.AppHash)
+		switch err {
+		case nil:
+			app.logger.Info("accepted snapshot", "height", snapshot.Height, "chunks", snapshot.Chunks)
+			return ResponseOfferSnapshot{Result: OfferSnapshotAccept}
+		case storage.ErrSnapshotStateNotNull:
+			app.logger.Error("can't restore snapshot", "err", err)
+			return ResponseOfferSnapshot{Result: OfferSnapshotAbort}
+		default:
+			app.logger.Info("rejected snapshot", "height", snapshot.Height, "err", err)
+			return ResponseOfferSnapshot{Result: OfferSnapshotReject}
+		}
+	}
+}
+
+func (app *App) snapshotChunkLoader() snapshotChunkLoader {
+	return func(req RequestLoadSnapshotChunk) ResponseLoadSnapshotChunk {
+		defer app.handlePanic()
+
+		chunk, err := app.Context.snapshots.LoadChunk(int64(req.Height), req.Format, req.Chunk)
+		if err != nil {
+			app.logger.Error("failed to load snapshot chunk", "height", req.Height, "chunk", req.Chunk, "err", err)
+			return ResponseLoadSnapshotChunk{}
+		}
+		return ResponseLoadSnapshotChunk{Chunk: chunk}
+	}
+}
+
+func (app *App) snapshotChunkApplier() snapshotChunkApplier {
+	return func(req RequestApplySnapshotChunk) ResponseApplySnapshotChunk {
+		defer app.handlePanic()
+
+		done, err := app.Context.snapshots.ApplyChunk(req.Index, req.Chunk)
+		switch err {
+		case nil:
+		case storage.ErrSnapshotChunkHash:
+			// the chunk was corrupted or forged, get it again from someone else
+			app.logger.Info("snapshot chunk hash mismatch", "chunk", req.Index, "sender", req.Sender)
+			return ResponseApplySnapshotChunk{
+				Result:        ApplySnapshotChunkRetry,
+				RefetchChunks: []uint32{req.Index},
+				RejectSenders: []string{req.Sender},
+			}
+		case storage.ErrSnapshotChunkOrder:
+			return ResponseApplySnapshotChunk{Result: ApplySnapshotChunkRetry}
+		case storage.ErrSnapshotNoRestore:
+			return ResponseApplySnapshotChunk{Result: ApplySnapshotChunkAbort}
+		default:
+			app.logger.Error("failed to apply snapshot chunk", "chunk", req.Index, "err", err)
+			return ResponseApplySnapshotChunk{Result: ApplySnapshotChunkRejectSnapshot}
+		}
+
+		if done {
+			app.resetStates()
+			app.logger.Info("state sync finished", "height", app.Context.chainstate.Version)
+		}
+		return ResponseApplySnapshotChunk{Result: ApplySnapshotChunkAccept}
+	}
+}
+
+// RestoreSnapshot restores the chain state of a node which never ran from the snapshot copied into dir, the app hash
+// is the one of the block after the snapshot height, verified by the caller
+func (app *App) RestoreSnapshot(dir string, appHash []byte) (*storage.Snapshot, error) {
+	snapshot, err := app.Context.snapshots.Restore(dir, appHash)
+	if err != nil {
+		return nil, err
+	}
+	app.resetStates()
+	app.logger.Info("restored chain state from snapshot", "height", snapshot.Height, "chunks", snapshot.Chunks)
+	return snapshot, nil
+}
+
+// resetStates makes the restored chain state the new starting point, stores will read it from now on
+func (app *App) resetStates() {
+	app.Context.deliver = storage.NewState(app.Context.chainstate)
+	app.Context.check = storage.NewState(app.Context.chainstate).WithGas(app.getGasCalculator())
+}
@@ -35,3 +35,68 @@ type Header = abci.Header
 type Validator = abci.Validator
 
 type ABCIApp = abci.Application
+
+// State sync messages, these follow the ABCI state sync calls of Tendermint v0.34 which are not part of the
+// v0.33 Application interface yet, until then a joining node restores a snapshot with "olfullnode restore_snapshot"
+type Snapshot struct {
+	Height   uint64
+	Format   uint32
+	Chunks   uint32
+	Hash     []byte
+	Metadata []byte
+}
+
+type RequestListSnapshots struct{}
+type ResponseListSnapshots struct {
+	Snapshots []*Snapshot
+}
+
+type RequestOfferSnapshot struct {
+	Snapshot *Snapshot
+	AppHash  []byte
+}
+type ResponseOfferSnapshot struct {
+	Result OfferSnapshotResult
+}
+
+type RequestLoadSnapshotChunk struct {
+	Height uint64
+	Format uint32
+	Chunk  uint32
+}
+type ResponseLoadSnapshotChunk struct {
+	Chunk []byte
+}
+
+type RequestApplySnapshotChunk struct {
+	Index  uint32
+	Chunk  []byte
+	Sender string
+}
+type ResponseApplySnapshotChunk struct {
+	Result        ApplySnapshotChunkResult
+	RefetchChunks []uint32
+	RejectSenders []string
+}
+
+type OfferSnapshotResult int32
+
+const (
+	OfferSnapshotUnknown      OfferSnapshotResult = 0
+	OfferSnapshotAccept       OfferSnapshotResult = 1
+	OfferSnapshotAbort        OfferSnapshotResult = 2
+	OfferSnapshotReject       OfferSnapshotResult = 3
+	OfferSnapshotRejectFormat OfferSnapshotResult = 4
+	OfferSnapshotRejectSender OfferSnapshotResult = 5
+)
+
+type ApplySnapshotChunkResult int32
+
+const (
+	ApplySnapshotChunkUnknown        ApplySnapshotChunkResult = 0
+	ApplySnapshotChunkAccept         ApplySnapshotChunkResult = 1
+	ApplySnapshotChunkAbort          ApplySnapshotChunkResult = 2
+	ApplySnapshotChunkRetry          ApplySnapshotChunkResult = 3
+	ApplySnapshotChunkRetrySnapshot  ApplySnapshotChunkResult = 4
+	ApplySnapshotChunkRejectSnapshot ApplySnapshotChunkResult = 5
+)
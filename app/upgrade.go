@@ -0,0 +1,32 @@
+package app
+
+import (
+	"os"
+
+	"github.com/Oneledger/protocol/app/upgrades"
+	"github.com/Oneledger/protocol/version"
+)
+
+// UpgradeHaltExitCode is the exit code of a node stopped at the height of an upgrade plan
+const UpgradeHaltExitCode = 3
+
+// applyUpgrade stops the node at the height of the pending upgrade plan, unless it is running the version
+// named by the plan, in which case the registered migration is applied and the plan is cleared
+func (app *App) applyUpgrade(req RequestBeginBlock) error {
+	plan, err := upgrades.Apply(app.Context.Action(&req.Header, app.Context.deliver), version.Fullnode.String())
+	if err != nil {
+		return err
+	}
+	if plan != nil {
+		app.logger.Info("Upgrade", plan.Name, "applied at block", plan.Height)
+	}
+	return nil
+}
+
+// halt stops the node before the block is processed, the databases are closed so nothing committed is lost. On
+// restart Tendermint replays the block, with the new binary for an upgrade.
+func (app *App) halt(err error) {
+	app.logger.Error("Halting node:", err)
+	app.Context.Close()
+	os.Exit(UpgradeHaltExitCode)
+}
@@ -0,0 +1,16 @@
+package upgrades
+
+import (
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/version"
+)
+
+// the upgrade to the running release, it has no state migration. A release which changes the state registers its
+// migration under its own version instead.
+func init() {
+	Register(version.Fullnode.String(), func(ctx *action.Context, plan governance.UpgradePlan) error {
+		ctx.Logger.Info("Upgrade", plan.Name, "has no state migration")
+		return nil
+	})
+}
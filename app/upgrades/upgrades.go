@@ -0,0 +1,72 @@
+package upgrades
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/governance"
+)
+
+var ErrUpgradeNeeded = errors.New("upgrade needed")
+
+// Handler migrates the state when the height of an upgrade plan is reached, it runs at the beginning of that block,
+// before any transaction of the new version is processed
+type Handler func(ctx *action.Context, plan governance.UpgradePlan) error
+
+// registry of the upgrade handlers by the name of their upgrade
+var registry = map[string]Handler{}
+
+// Register registers the state migration of the upgrade with the given name, the name is the version of the node
+// which applies the upgrade
+func Register(name string, handler Handler) {
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("upgrade handler %s already registered", name))
+	}
+	registry[name] = handler
+}
+
+// Get returns the handler registered for the upgrade with the name
+func Get(name string) (Handler, bool) {
+	handler, ok := registry[name]
+	return handler, ok
+}
+
+// Apply runs the pending upgrade plan at its height. The node has to stop with ErrUpgradeNeeded unless it runs the
+// version named by the plan, in which case the registered migration is applied and the plan is cleared. The applied
+// plan is returned, nil at the other heights.
+func Apply(ctx *action.Context, running string) (*governance.UpgradePlan, error) {
+	height := ctx.Header.GetHeight()
+	govern := ctx.GovernanceStore
+
+	plan, err := govern.GetUpgradePlan()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get upgrade plan")
+	}
+	if plan == nil || plan.Height != height {
+		return nil, nil
+	}
+
+	if plan.Name != running {
+		return nil, errors.Wrapf(ErrUpgradeNeeded, "UPGRADE \"%s\" NEEDED at height %d, running %s, binary checksum %s",
+			plan.Name, plan.Height, running, plan.Checksum)
+	}
+
+	if handler, ok := registry[plan.Name]; ok {
+		err = handler(ctx, *plan)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to apply upgrade %s", plan.Name)
+		}
+	}
+
+	err = govern.SetUpgradeDone(plan.Name, height)
+	if err != nil {
+		return nil, err
+	}
+	err = govern.ClearUpgradePlan()
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
@@ -0,0 +1,93 @@
+package upgrades
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/version"
+)
+
+const testChecksum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func newContext(height int64) *action.Context {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	return &action.Context{
+		Header:          &abci.Header{Height: height},
+		State:           state,
+		GovernanceStore: governance.NewStore("g", state),
+	}
+}
+
+func TestRegister(t *testing.T) {
+	_, ok := Get(version.Fullnode.String())
+	assert.True(t, ok)
+
+	assert.Panics(t, func() {
+		Register(version.Fullnode.String(), func(ctx *action.Context, plan governance.UpgradePlan) error { return nil })
+	})
+}
+
+func TestApply(t *testing.T) {
+	defer func(handlers map[string]Handler) { registry = handlers }(registry)
+	registry = map[string]Handler{}
+
+	applied := 0
+	Register("v0.19.0", func(ctx *action.Context, plan governance.UpgradePlan) error {
+		applied++
+		return nil
+	})
+
+	ctx := newContext(100)
+	plan := governance.UpgradePlan{Name: "v0.19.0", Height: 100, Checksum: testChecksum}
+	assert.NoError(t, ctx.GovernanceStore.SetUpgradePlan(plan))
+
+	// the node stops at the plan height unless it runs the upgrade
+	_, err := Apply(ctx, "v0.18.14")
+	assert.True(t, errors.Is(err, ErrUpgradeNeeded))
+	assert.Equal(t, 0, applied)
+
+	// nothing happens before the plan height
+	ctx.Header.Height = 99
+	done, err := Apply(ctx, "v0.18.14")
+	assert.NoError(t, err)
+	assert.Nil(t, done)
+
+	ctx.Header.Height = 100
+	done, err = Apply(ctx, "v0.19.0")
+	assert.NoError(t, err)
+	assert.Equal(t, plan, *done)
+	assert.Equal(t, 1, applied)
+
+	pending, err := ctx.GovernanceStore.GetUpgradePlan()
+	assert.NoError(t, err)
+	assert.Nil(t, pending)
+	height, err := ctx.GovernanceStore.GetUpgradeHeight("v0.19.0")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), height)
+}
+
+func TestApply_MigrationFailure(t *testing.T) {
+	defer func(handlers map[string]Handler) { registry = handlers }(registry)
+	registry = map[string]Handler{}
+
+	Register("v0.19.0", func(ctx *action.Context, plan governance.UpgradePlan) error {
+		return errors.New("migration failed")
+	})
+
+	ctx := newContext(100)
+	plan := governance.UpgradePlan{Name: "v0.19.0", Height: 100, Checksum: testChecksum}
+	assert.NoError(t, ctx.GovernanceStore.SetUpgradePlan(plan))
+
+	_, err := Apply(ctx, "v0.19.0")
+	assert.Error(t, err)
+	pending, err := ctx.GovernanceStore.GetUpgradePlan()
+	assert.NoError(t, err)
+	assert.NotNil(t, pending)
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/feegrant"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+//-------------tx
+type FeeGrantRequest struct {
+	Granter        keys.Address   `json:"granter"`
+	Grantee        keys.Address   `json:"grantee"`
+	SpendLimit     *action.Amount `json:"spendLimit,omitempty"`
+	Expiry         int64          `json:"expiry,omitempty"`
+	AllowedTxTypes []action.Type  `json:"allowedTxTypes,omitempty"`
+	GasPrice       action.Amount  `json:"gasPrice"`
+	Gas            int64          `json:"gas"`
+}
+
+type FeeRevokeRequest struct {
+	Granter  keys.Address  `json:"granter"`
+	Grantee  keys.Address  `json:"grantee"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
+// SetFeePayerRequest sets the fee payer of a raw tx created by the other tx services, before it is signed
+type SetFeePayerRequest struct {
+	RawTx    []byte       `json:"rawTx"`
+	FeePayer keys.Address `json:"feePayer"`
+}
+
+//-------------query
+type ListFeeAllowancesRequest struct {
+	Granter keys.Address `json:"granter"`
+	// the allowance of the granter to the grantee only, all the allowances of the granter when empty
+	Grantee keys.Address `json:"grantee,omitempty"`
+}
+
+type ListFeeAllowancesReply struct {
+	Allowances []*feegrant.Allowance `json:"allowances"`
+	Height     int64                 `json:"height"`
+}
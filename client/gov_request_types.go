@@ -8,29 +8,34 @@ import (
 )
 
 type CreateProposalRequest struct {
-	ProposalID      string          `json:"proposalId"`
-	ProposalType    string          `json:"proposalType"`
-	Headline        string          `json:"headline"`
-	Description     string          `json:"description"`
-	Proposer        keys.Address    `json:"proposer"`
-	InitialFunding  action.Amount   `json:"initialFunding"`
-	GasPrice        action.Amount   `json:"gasPrice"`
-	Gas             int64           `json:"gas"`
-	FundingDeadline int64           `json:"fundingDeadline"`
-	FundingGoal     *balance.Amount `json:"fundingGoal"`
-	VotingDeadline  int64           `json:"votingDeadline"`
-	PassPercentage  int             `json:"passPercentage"`
-	ConfigUpdate    string          `json:"configUpdate"`
+	ProposalID      string                  `json:"proposalId"`
+	ProposalType    string                  `json:"proposalType"`
+	Headline        string                  `json:"headline"`
+	Description     string                  `json:"description"`
+	Proposer        keys.Address            `json:"proposer"`
+	InitialFunding  action.Amount           `json:"initialFunding"`
+	GasPrice        action.Amount           `json:"gasPrice"`
+	Gas             int64                   `json:"gas"`
+	FundingDeadline int64                   `json:"fundingDeadline"`
+	FundingGoal     *balance.Amount         `json:"fundingGoal"`
+	VotingDeadline  int64                   `json:"votingDeadline"`
+	PassPercentage  int                     `json:"passPercentage"`
+	ConfigUpdate    string                  `json:"configUpdate"`
+	Upgrade         *governance.UpgradePlan `json:"upgrade,omitempty"`
 }
 
 type ListProposalRequest struct {
 	ProposalId governance.ProposalID `json:"proposalId"`
+	// The height of the state the query reads, the latest one if zero
+	Height int64 `json:"height,omitempty"`
 }
 
 type ListProposalsRequest struct {
 	State        governance.ProposalState `json:"state"`
 	Proposer     keys.Address             `json:"proposer"`
 	ProposalType governance.ProposalType  `json:"proposalType"`
+	// The height of the state the query reads, the latest one if zero
+	Height int64 `json:"height,omitempty"`
 }
 
 type ProposalStat struct {
@@ -44,6 +49,27 @@ type ListProposalsReply struct {
 	Height        int64          `json:"height"`
 }
 
+type ListProposalVotesRequest struct {
+	ProposalId governance.ProposalID `json:"proposalId"`
+	// The height of the state the query reads, the latest one if zero
+	Height int64 `json:"height,omitempty"`
+}
+
+// ValidatorVoteStat is the vote of a validator, the inherited power is the power of its delegators who didn't vote
+type ValidatorVoteStat struct {
+	Validator      keys.Address           `json:"validator"`
+	Opinion        governance.VoteOpinion `json:"opinion"`
+	Power          int64                  `json:"power"`
+	InheritedPower int64                  `json:"inheritedPower"`
+}
+
+type ListProposalVotesReply struct {
+	ValidatorVotes []ValidatorVoteStat         `json:"validatorVotes"`
+	DelegatorVotes []*governance.DelegatorVote `json:"delegatorVotes"`
+	Votes          governance.VoteStatus       `json:"votes"`
+	Height         int64                       `json:"height"`
+}
+
 type LastUpdateHeights struct {
 	Proposal int64 `json:"proposal"`
 	Rewards  int64 `json:"rewards"`
@@ -56,6 +82,8 @@ type LastUpdateHeights struct {
 	Evidence int64 `json:"evidence"`
 }
 type GovernanceOptionsRequest struct {
+	// The height of the state the query reads, the latest one if zero
+	Height int64 `json:"height,omitempty"`
 }
 type GovernanceOptionsReply struct {
 	GovOptions       governance.GovernanceState `json:"govOptions"`
@@ -68,6 +96,8 @@ type VoteProposalRequest struct {
 	Address    keys.Address           `json:"address"`
 	GasPrice   action.Amount          `json:"gasPrice"`
 	Gas        int64                  `json:"gas"`
+	// vote as the network delegator at the address instead of the validator of the node
+	Delegator bool `json:"delegator,omitempty"`
 }
 
 type VoteProposalReply struct {
@@ -117,8 +147,16 @@ type GetProposalOptionsReply struct {
 type GetFundsForProposalByFunderRequest struct {
 	ProposalId governance.ProposalID `json:"proposalId"`
 	Funder     keys.Address          `json:"funderAddress"`
+	// The height of the state the query reads, the latest one if zero
+	Height int64 `json:"height,omitempty"`
 }
 
 type GetFundsForProposalByFunderReply struct {
 	Amount balance.Amount `json:"amount"`
+	Height int64          `json:"height"`
+}
+
+type GetUpgradePlanReply struct {
+	Plan   *governance.UpgradePlan `json:"plan"`
+	Height int64                   `json:"height"`
 }
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
+)
+
+//-------------tx
+type MultiSigAccountCreateRequest struct {
+	Creator   keys.Address   `json:"creator"`
+	Threshold int            `json:"threshold"`
+	Members   []keys.Address `json:"members"`
+	GasPrice  action.Amount  `json:"gasPrice"`
+	Gas       int64          `json:"gas"`
+}
+
+//-------------owner
+// SignMultiSigRequest adds the signature of the member account of the node wallet to the signature bundle of the raw
+// tx, the bundle carries the threshold policy of the multisig account and the signatures of the other members
+type SignMultiSigRequest struct {
+	RawTx   []byte        `json:"rawTx"`
+	Address keys.Address  `json:"address"`
+	Bundle  keys.MultiSig `json:"bundle"`
+}
+
+type CombineMultiSigRequest struct {
+	Bundles []keys.MultiSig `json:"bundles"`
+}
+
+type MultiSigReply struct {
+	Bundle keys.MultiSig `json:"bundle"`
+	// tells whether the bundle has enough signatures to be broadcast
+	Complete bool `json:"complete"`
+}
+
+//-------------query
+type MultiSigAccountRequest struct {
+	Address keys.Address `json:"address"`
+}
+
+type MultiSigAccountReply struct {
+	Account *multisig.Account `json:"account"`
+	Height  int64             `json:"height"`
+}
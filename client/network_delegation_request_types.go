@@ -63,8 +63,9 @@ type ListDelegationReply struct {
 }
 
 type DelegStats struct {
-	Active  balance.Amount `json:"active"`
-	Pending balance.Amount `json:"pending"`
+	Active    balance.Amount `json:"active"`
+	Pending   balance.Amount `json:"pending"`
+	Validator keys.Address   `json:"validator,omitempty"`
 }
 
 type DelegRewardsStats struct {
@@ -82,6 +83,7 @@ type FullDelegStats struct {
 type NetworkDelegateRequest struct {
 	DelegationAddress keys.Address  `json:"delegationAddress"`
 	Amount            action.Amount `json:"amount"`
+	Validator         keys.Address  `json:"validator,omitempty"`
 	GasPrice          action.Amount `json:"gasPrice"`
 	Gas               int64         `json:"gas"`
 }
@@ -81,11 +81,23 @@ type ONSDeleteSubRequest struct {
 	Gas      int64         `json:"gas"`
 }
 
+type ONSSetRecordRequest struct {
+	Owner      keys.Address   `json:"owner"`
+	Name       string         `json:"name"`
+	RecordType ons.RecordType `json:"recordType"`
+	Key        string         `json:"key"`
+	Value      string         `json:"value"`
+	GasPrice   action.Amount  `json:"gasPrice"`
+	Gas        int64          `json:"gas"`
+}
+
 type ONSGetDomainsRequest struct {
 	Name        string       `json:"name"`
 	Owner       keys.Address `json:"owner"`
 	OnSale      bool         `json:"onSale"`
 	Beneficiary keys.Address `json:"beneficiary"`
+	// The height of the state the query reads, the latest one if zero
+	Height int64 `json:"height,omitempty"`
 }
 
 type ONSGetDomainsReply struct {
@@ -93,6 +105,23 @@ type ONSGetDomainsReply struct {
 	Height  int64        `json:"height"`
 }
 
+type ONSGetRecordsReply struct {
+	Name    string       `json:"name"`
+	Records *ons.Records `json:"records"`
+	Height  int64        `json:"height"`
+}
+
+type ONSReverseLookupRequest struct {
+	Address keys.Address `json:"address"`
+	// The height of the state the query reads, the latest one if zero
+	Height int64 `json:"height,omitempty"`
+}
+
+type ONSReverseLookupReply struct {
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
+}
+
 type ONSGetOptionsReply struct {
 	ons.Options `json:"options"`
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/Oneledger/protocol/data/delegation"
 	"github.com/Oneledger/protocol/data/evidence"
 
+	"github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/bytes"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 
@@ -42,10 +43,14 @@ Services:
 /* Blockchain service  */
 type BalanceRequest struct {
 	Address keys.Address `json:"address"`
+	// The height of the state the query reads, the latest one if zero
+	Height int64 `json:"height,omitempty"`
 }
 
 type BalancePoolRequest struct {
 	Poolname string `json:"poolname"`
+	// The height of the state the query reads, the latest one if zero
+	Height int64 `json:"height,omitempty"`
 }
 type BalanceReply struct {
 	// The balance of the account. Returns an empty balance
@@ -55,6 +60,34 @@ type BalanceReply struct {
 	Height int64 `json:"height"`
 }
 
+type NonceRequest struct {
+	Address keys.Address `json:"address"`
+}
+type NonceReply struct {
+	// The nonce the next transaction of the account must carry,
+	// transactions waiting in the mempool are counted in
+	Nonce uint64 `json:"nonce"`
+	// The height of the committed account sequence
+	Height int64 `json:"height"`
+}
+
+type SimulateTxRequest struct {
+	// The serialized action.RawTx, it doesn't need to be signed
+	RawTx []byte `json:"rawTx"`
+	// The height of the state the transaction is run against, the latest one if zero
+	Height int64 `json:"height,omitempty"`
+}
+type SimulateTxReply struct {
+	OK      bool          `json:"ok"`
+	GasUsed int64         `json:"gasUsed"`
+	Events  []types.Event `json:"events"`
+	// The status code of the failure, zero when the transaction succeeded
+	Code int    `json:"code"`
+	Log  string `json:"log"`
+	// The height of the state the transaction was run against
+	Height int64 `json:"height"`
+}
+
 type VoteRequestRequest struct {
 	Address keys.Address `json:"address"`
 }
@@ -72,6 +105,8 @@ type VoteRequestReply struct {
 
 type ValidatorStatusRequest struct {
 	Address keys.Address `json:"address"`
+	// The height of the state the query reads, the latest one if zero
+	Height int64 `json:"height,omitempty"`
 }
 
 type ValidatorStatusReply struct {
@@ -86,6 +121,8 @@ type ValidatorStatusReply struct {
 
 type DelegationStatusRequest struct {
 	Address keys.Address `json:"address"`
+	// The height of the state the query reads, the latest one if zero
+	Height int64 `json:"height,omitempty"`
 }
 
 type DelegationStatusReply struct {
@@ -93,6 +130,8 @@ type DelegationStatusReply struct {
 	EffectiveDelegationAmount string                   `json:"effectiveDelegationAmount"`
 	WithdrawableAmount        string                   `json:"withdrawableAmount"`
 	MaturedAmounts            []*delegation.MatureData `json:"maturedAmount"`
+	// The height the delegation status was read at
+	Height int64 `json:"height"`
 }
 
 /* Tx Service */
@@ -166,6 +205,24 @@ type UnstakeReply struct {
 	RawTx []byte `json:"rawTx"`
 }
 
+type EditValidatorRequest struct {
+	Address        keys.Address `json:"address"`
+	CommissionRate int64        `json:"commissionRate"`
+	MaxRate        int64        `json:"maxRate"`
+	MaxChangeRate  int64        `json:"maxChangeRate"`
+}
+
+type EditValidatorReply struct {
+	RawTx []byte `json:"rawTx"`
+}
+
+// BatchRequest combines the raw transactions, created by the other tx services, into a single batch transaction
+type BatchRequest struct {
+	RawTxs   [][]byte      `json:"rawTxs"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
 type WithdrawRequest struct {
 	Address keys.Address   `json:"address"`
 	Amount  balance.Amount `json:"amount"`
@@ -248,6 +305,8 @@ type BroadcastRequest struct {
 	RawTx     []byte         `json:"rawTx"`
 	Signature []byte         `json:"signature"`
 	PublicKey keys.PublicKey `json:"publicKey"`
+	// signature of the fee payer set in the raw tx, when it sponsors the tx without an allowance
+	FeePayerSignature *action.Signature `json:"feePayerSignature,omitempty"`
 }
 
 type BroadcastReply struct {
@@ -262,8 +321,9 @@ type BroadcastReply struct {
 }
 
 type BroadcastMtSigRequest struct {
-	RawTx      []byte             `json:"rawTx"`
-	Signatures []action.Signature `json:"signatures"`
+	RawTx             []byte             `json:"rawTx"`
+	Signatures        []action.Signature `json:"signatures"`
+	FeePayerSignature *action.Signature  `json:"feePayerSignature,omitempty"`
 }
 
 func (reply *BroadcastReply) FromResultBroadcastTx(result *ctypes.ResultBroadcastTx) {
@@ -354,6 +414,8 @@ type ETHLockRequest struct {
 type CurrencyBalanceRequest struct {
 	Currency string       `json:"currency"`
 	Address  keys.Address `json:"address"`
+	// The height of the state the query reads, the latest one if zero
+	Height int64 `json:"height,omitempty"`
 }
 type CurrencyBalanceReply struct {
 	Currency string `json:"currency"`
@@ -373,6 +435,15 @@ type FeeOptionsReply struct {
 	FeeOption fees.FeeOption `json:"feeOption"`
 }
 
+type BaseFeeReply struct {
+	// The minimum gas price of the next block, the base fee when the fee market is enabled
+	MinFee balance.Coin `json:"minFee"`
+	// The base fee of the next block, zero when the fee market is disabled
+	BaseFee          balance.Coin       `json:"baseFee"`
+	FeeMarketOptions fees.MarketOptions `json:"feeMarketOptions"`
+	Height           int64              `json:"height"`
+}
+
 type ListTxTypesRequest struct{}
 type ListTxTypesReply struct {
 	TxTypes []action.TxTypeDescribe `json:"txTypes"`
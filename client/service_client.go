@@ -21,20 +21,45 @@ func NewServiceClient(conn string) (*ServiceClient, error) {
 }
 
 func (c *ServiceClient) Balance(addr keys.Address) (out BalanceReply, err error) {
+	return c.BalanceAt(addr, 0)
+}
+
+// BalanceAt returns the balance of the address at the height, the latest one if zero
+func (c *ServiceClient) BalanceAt(addr keys.Address, height int64) (out BalanceReply, err error) {
 	/*if len(request) <= 20 {
 		return out, errors.New("address has insufficient length")
 	}*/
-	request := BalanceRequest{addr}
+	request := BalanceRequest{Address: addr, Height: height}
 	err = c.Call("query.Balance", &request, &out)
 	return
 }
 
 func (c *ServiceClient) BalancePool(poolname string) (out BalanceReply, err error) {
-	request := BalancePoolRequest{poolname}
+	return c.BalancePoolAt(poolname, 0)
+}
+
+func (c *ServiceClient) BalancePoolAt(poolname string, height int64) (out BalanceReply, err error) {
+	request := BalancePoolRequest{Poolname: poolname, Height: height}
 	err = c.Call("query.BalancePool", &request, &out)
 	return
 }
 
+func (c *ServiceClient) Nonce(addr keys.Address) (out NonceReply, err error) {
+	request := NonceRequest{addr}
+	err = c.Call("query.Nonce", &request, &out)
+	return
+}
+
+func (c *ServiceClient) SimulateTx(request SimulateTxRequest) (out SimulateTxReply, err error) {
+	err = c.Call("query.SimulateTx", &request, &out)
+	return
+}
+
+func (c *ServiceClient) BaseFee() (out BaseFeeReply, err error) {
+	err = c.Call("query.BaseFee", struct{}{}, &out)
+	return
+}
+
 func (c *ServiceClient) ValidatorStatus(request ValidatorStatusRequest) (out ValidatorStatusReply, err error) {
 	err = c.Call("query.ValidatorStatus", &request, &out)
 	return
@@ -46,10 +71,14 @@ func (c *ServiceClient) DelegationStatus(request DelegationStatusRequest) (out D
 }
 
 func (c *ServiceClient) CurrBalance(addr keys.Address, currency string) (out CurrencyBalanceReply, err error) {
+	return c.CurrBalanceAt(addr, currency, 0)
+}
+
+func (c *ServiceClient) CurrBalanceAt(addr keys.Address, currency string, height int64) (out CurrencyBalanceReply, err error) {
 	/*if len(request) <= 20 {
 		return out, errors.New("address has insufficient length")
 	}*/
-	request := CurrencyBalanceRequest{currency, addr}
+	request := CurrencyBalanceRequest{Currency: currency, Address: addr, Height: height}
 	err = c.Call("query.CurrencyBalance", &request, &out)
 	return
 }
@@ -98,6 +127,16 @@ func (c *ServiceClient) ListAccountAddresses() (out ListAccountAddressesReply, e
 	return
 }
 
+func (c *ServiceClient) SignMultiSig(req SignMultiSigRequest) (out MultiSigReply, err error) {
+	err = c.Call("owner.SignMultiSig", req, &out)
+	return
+}
+
+func (c *ServiceClient) CombineMultiSig(req CombineMultiSigRequest) (out MultiSigReply, err error) {
+	err = c.Call("owner.CombineMultiSig", req, &out)
+	return
+}
+
 func (c *ServiceClient) Release(req ReleaseRequest) (out ReleaseReply, err error) {
 	err = c.Call("tx.Release", req, &out)
 	return
@@ -123,6 +162,56 @@ func (c *ServiceClient) Unstake(req UnstakeRequest) (out UnstakeReply, err error
 	return
 }
 
+func (c *ServiceClient) EditValidator(req EditValidatorRequest) (out EditValidatorReply, err error) {
+	err = c.Call("tx.EditValidator", req, &out)
+	return
+}
+
+func (c *ServiceClient) CreateRawBatch(req BatchRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.CreateRawBatch", req, &out)
+	return
+}
+
+func (c *ServiceClient) FeeGrant(req FeeGrantRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.FeeGrant", req, &out)
+	return
+}
+
+func (c *ServiceClient) FeeRevoke(req FeeRevokeRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.FeeRevoke", req, &out)
+	return
+}
+
+func (c *ServiceClient) MultiSigAccountCreate(req MultiSigAccountCreateRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.MultiSigAccountCreate", req, &out)
+	return
+}
+
+func (c *ServiceClient) CreateVesting(req CreateVestingRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.CreateVesting", req, &out)
+	return
+}
+
+func (c *ServiceClient) TokenCreate(req TokenCreateRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.TokenCreate", req, &out)
+	return
+}
+
+func (c *ServiceClient) TokenMint(req TokenMintRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.TokenMint", req, &out)
+	return
+}
+
+func (c *ServiceClient) TokenBurn(req TokenBurnRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.TokenBurn", req, &out)
+	return
+}
+
+func (c *ServiceClient) SetFeePayer(req SetFeePayerRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.SetFeePayer", req, &out)
+	return
+}
+
 func (c *ServiceClient) Withdraw(req WithdrawRequest) (out WithdrawReply, err error) {
 	err = c.Call("tx.Withdraw", req, &out)
 	return
@@ -151,6 +240,21 @@ func (c *ServiceClient) ONS_CreateRawSend(req ONSSendRequest) (out CreateTxReply
 	return
 }
 
+func (c *ServiceClient) ONS_CreateRawSetRecord(req ONSSetRecordRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.ONS_CreateRawSetRecord", req, &out)
+	return
+}
+
+func (c *ServiceClient) ONS_GetDomainRecords(req ONSGetDomainsRequest) (out ONSGetRecordsReply, err error) {
+	err = c.Call("query.ONS_GetDomainRecords", req, &out)
+	return
+}
+
+func (c *ServiceClient) ONS_ReverseLookup(req ONSReverseLookupRequest) (out ONSReverseLookupReply, err error) {
+	err = c.Call("query.ONS_ReverseLookup", req, &out)
+	return
+}
+
 func (c *ServiceClient) CreateRawSend(req SendTxRequest) (out *CreateTxReply, err error) {
 	err = c.Call("tx.CreateRawSend", req, &out)
 	return
@@ -192,11 +296,46 @@ func (c *ServiceClient) ListProposal(req ListProposalRequest) (out *ListProposal
 	return
 }
 
+func (c *ServiceClient) GetUpgradePlan() (out *GetUpgradePlanReply, err error) {
+	err = c.Call("query.GetUpgradePlan", struct{}{}, &out)
+	return
+}
+
 func (c *ServiceClient) ListProposals(req ListProposalsRequest) (out *ListProposalsReply, err error) {
 	err = c.Call("query.ListProposals", req, &out)
 	return
 }
 
+func (c *ServiceClient) ListProposalVotes(req ListProposalVotesRequest) (out *ListProposalVotesReply, err error) {
+	err = c.Call("query.ListProposalVotes", req, &out)
+	return
+}
+
+func (c *ServiceClient) ListFeeAllowances(req ListFeeAllowancesRequest) (out *ListFeeAllowancesReply, err error) {
+	err = c.Call("query.ListFeeAllowances", req, &out)
+	return
+}
+
+func (c *ServiceClient) GetMultiSigAccount(req MultiSigAccountRequest) (out *MultiSigAccountReply, err error) {
+	err = c.Call("query.GetMultiSigAccount", req, &out)
+	return
+}
+
+func (c *ServiceClient) GetVestingAccount(req VestingAccountRequest) (out *VestingAccountReply, err error) {
+	err = c.Call("query.GetVestingAccount", req, &out)
+	return
+}
+
+func (c *ServiceClient) GetToken(req TokenRequest) (out *TokenReply, err error) {
+	err = c.Call("query.GetToken", req, &out)
+	return
+}
+
+func (c *ServiceClient) ListTokens() (out *ListTokensReply, err error) {
+	err = c.Call("query.ListTokens", struct{}{}, &out)
+	return
+}
+
 func (c *ServiceClient) ListRewards(req RewardsRequest) (out *ListRewardsReply, err error) {
 	err = c.Call("query.ListRewardsForValidator", req, &out)
 	return
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/tokens"
+)
+
+//-------------tx
+type TokenCreateRequest struct {
+	Creator   keys.Address   `json:"creator"`
+	Name      string         `json:"name"`
+	Unit      string         `json:"unit"`
+	Decimal   int64          `json:"decimal"`
+	Issuer    keys.Address   `json:"issuer"`
+	MaxSupply balance.Amount `json:"maxSupply"`
+	GasPrice  action.Amount  `json:"gasPrice"`
+	Gas       int64          `json:"gas"`
+}
+
+type TokenMintRequest struct {
+	Issuer   keys.Address  `json:"issuer"`
+	To       keys.Address  `json:"to"`
+	Amount   action.Amount `json:"amount"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
+type TokenBurnRequest struct {
+	Issuer   keys.Address  `json:"issuer"`
+	Amount   action.Amount `json:"amount"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
+//-------------query
+type TokenRequest struct {
+	Name string `json:"name"`
+}
+
+type TokenReply struct {
+	Token  *tokens.Token `json:"token"`
+	Height int64         `json:"height"`
+}
+
+type ListTokensReply struct {
+	Tokens []tokens.Token `json:"tokens"`
+	Height int64          `json:"height"`
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+//-------------tx
+type CreateVestingRequest struct {
+	Funder       keys.Address            `json:"funder"`
+	Beneficiary  keys.Address            `json:"beneficiary"`
+	Amount       action.Amount           `json:"amount"`
+	StartHeight  int64                   `json:"startHeight"`
+	CliffHeight  int64                   `json:"cliffHeight"`
+	EndHeight    int64                   `json:"endHeight"`
+	Schedule     balance.VestingSchedule `json:"schedule"`
+	PeriodLength int64                   `json:"periodLength"`
+	GasPrice     action.Amount           `json:"gasPrice"`
+	Gas          int64                   `json:"gas"`
+}
+
+//-------------query
+type VestingAccountRequest struct {
+	Address  keys.Address `json:"address"`
+	Currency string       `json:"currency"`
+}
+
+type VestingAccountReply struct {
+	Vesting *balance.VestingAccount `json:"vesting"`
+	// the part of the balance which can't be spent yet
+	Locked string `json:"locked"`
+	Height int64  `json:"height"`
+}
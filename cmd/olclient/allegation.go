@@ -117,6 +117,10 @@ func allegationExec(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, out.RawTx)
+	}
+
 	if !wallet.Open(usrAddress, allegationArgs.Password) {
 		ctx.logger.Error("failed to open secure wallet")
 		return err
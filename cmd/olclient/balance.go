@@ -32,6 +32,7 @@ type Balance struct {
 	accountKey   []byte
 	currencyName string
 	poolName     string
+	height       int64
 }
 
 var balArgs = &Balance{}
@@ -47,6 +48,8 @@ func init() {
 
 	balanceCmd.Flags().StringVar(&balArgs.poolName, "poolname", "", "poolname")
 
+	balanceCmd.Flags().Int64Var(&balArgs.height, "height", 0, "height to read the balance at, the latest one if 0")
+
 }
 
 // IssueRequest sends out a sendTx to all of the nodes in the chain
@@ -70,7 +73,7 @@ func BalanceNode(cmd *cobra.Command, args []string) {
 
 	// assuming we have public key
 	if balArgs.currencyName == "" && balArgs.poolName == "" {
-		bal, err := fullnode.Balance(balArgs.accountKey)
+		bal, err := fullnode.BalanceAt(balArgs.accountKey, balArgs.height)
 		if err != nil {
 			logger.Fatal("error in getting balance", err)
 		}
@@ -78,7 +81,7 @@ func BalanceNode(cmd *cobra.Command, args []string) {
 		printBalance(nodeName.Name, balArgs.accountKey, bal)
 
 	} else if balArgs.currencyName == "" && len(balArgs.accountKey) == 0 {
-		bal, err := fullnode.BalancePool(balArgs.poolName)
+		bal, err := fullnode.BalancePoolAt(balArgs.poolName, balArgs.height)
 		if err != nil {
 			logger.Fatal("error in getting balance", err)
 		}
@@ -86,7 +89,7 @@ func BalanceNode(cmd *cobra.Command, args []string) {
 		printBalance(nodeName.Name, balArgs.accountKey, bal)
 
 	} else if balArgs.poolName == "" {
-		bal, err := fullnode.CurrBalance(balArgs.accountKey, balArgs.currencyName)
+		bal, err := fullnode.CurrBalanceAt(balArgs.accountKey, balArgs.currencyName, balArgs.height)
 		if err != nil {
 			logger.Fatal("error in getting balance", err)
 		}
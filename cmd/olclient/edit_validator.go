@@ -0,0 +1,147 @@
+/*
+	Copyright 2017-2018 OneLedger
+
+	Cli to interact with a with the chain.
+*/
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/Oneledger/protocol/action"
+	accounts2 "github.com/Oneledger/protocol/data/accounts"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/config"
+)
+
+type EditValidatorArguments struct {
+	Address        []byte `json:"address"`
+	CommissionRate int64  `json:"commissionRate"`
+	MaxRate        int64  `json:"maxRate"`
+	MaxChangeRate  int64  `json:"maxChangeRate"`
+	Password       string `json:"password"`
+}
+
+func (args *EditValidatorArguments) ClientRequest() client.EditValidatorRequest {
+	return client.EditValidatorRequest{
+		Address:        args.Address,
+		CommissionRate: args.CommissionRate,
+		MaxRate:        args.MaxRate,
+		MaxChangeRate:  args.MaxChangeRate,
+	}
+}
+
+func init() {
+	DelegationCmd.AddCommand(editValidatorCmd)
+	setEditValidatorArgs()
+}
+
+var editValidatorCmd = &cobra.Command{
+	Use:   "edit-validator",
+	Short: "Declare the commission of the validator on the rewards of its delegators",
+	RunE:  editValidator,
+}
+
+var editValidatorArgs = &EditValidatorArguments{}
+
+func setEditValidatorArgs() {
+	// Transaction Parameters
+	editValidatorCmd.Flags().Int64Var(&editValidatorArgs.CommissionRate, "commission-rate", 0, "percentage of the delegators rewards kept by the validator")
+	editValidatorCmd.Flags().Int64Var(&editValidatorArgs.MaxRate, "max-rate", 0, "maximum commission rate, it can't be changed later")
+	editValidatorCmd.Flags().Int64Var(&editValidatorArgs.MaxChangeRate, "max-change-rate", 0, "maximum daily change of the commission rate, it can't be changed later")
+	editValidatorCmd.Flags().BytesHexVar(&editValidatorArgs.Address, "address", []byte{}, "stake address of the validator, it pays the fee")
+	editValidatorCmd.Flags().StringVar(&editValidatorArgs.Password, "password", "", "password to access secure wallet")
+}
+
+// editValidator sends out an EDIT_VALIDATOR tx signed by the node validator key and the stake address
+func editValidator(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	ctx.logger.Debug("Have Edit Validator Request", "editValidatorArgs", editValidatorArgs)
+
+	rootPath, err := filepath.Abs(rootArgs.rootDir)
+	if err != nil {
+		return err
+	}
+
+	cfg := &config.Server{}
+
+	//Prompt for password
+	if len(editValidatorArgs.Password) == 0 {
+		editValidatorArgs.Password = PromptForPassword()
+	}
+
+	//Create new Wallet and User Address
+	wallet, err := accounts2.NewWalletKeyStore(keyStorePath)
+	if err != nil {
+		ctx.logger.Error("failed to create secure wallet", err)
+		return err
+	}
+	//Verify User Password
+	usrAddress := keys.Address(editValidatorArgs.Address)
+	authenticated, err := wallet.VerifyPassphrase(usrAddress, editValidatorArgs.Password)
+	if !authenticated {
+		ctx.logger.Error("authentication error", err)
+		return err
+	}
+
+	err = cfg.ReadFile(cfgPath(rootPath))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read configuration file at at %s", cfgPath(rootPath))
+	}
+
+	// Create message
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	out, err := fullnode.EditValidator(editValidatorArgs.ClientRequest())
+	if err != nil {
+		ctx.logger.Error("Error in applying ", err.Error())
+		return err
+	}
+
+	//Sign Transaction with secure wallet, then append signature to signedTx.
+	signedTx := &action.SignedTx{}
+	err = serialize.GetSerializer(serialize.NETWORK).Deserialize(out.RawTx, signedTx)
+	if err != nil {
+		return errors.New("error de-serializing signedTx")
+	}
+
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, out.RawTx)
+	}
+
+	if !wallet.Open(usrAddress, editValidatorArgs.Password) {
+		ctx.logger.Error("failed to open secure wallet")
+		return errors.New("failed to open secure wallet")
+	}
+
+	//Sign Raw "Send" Transaction Using Secure Wallet.
+	pub, signature, err := wallet.SignWithAddress(signedTx.RawTx.RawBytes(), usrAddress)
+	if err != nil {
+		ctx.logger.Error("error signing transaction", err)
+		return err
+	}
+
+	signedTx.Signatures = append([]action.Signature{{Signer: pub, Signed: signature}}, signedTx.Signatures...)
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(signedTx)
+	if packet == nil || err != nil {
+		return errors.New("error serializing packet: " + err.Error())
+	}
+
+	result, err := ctx.clCtx.BroadcastTxSync(packet)
+	if err != nil {
+		ctx.logger.Error("error in BroadcastTxSync", err)
+	}
+
+	if BroadcastStatusSync(ctx, result) {
+		PollTxResult(ctx, result.Hash.String())
+	}
+
+	return nil
+}
@@ -29,6 +29,7 @@ type VoteArguments struct {
 	Password   string `json:"password"`
 	GasPrice   string `json:"gasPrice"`
 	Gas        int64  `json:"gas"`
+	Delegator  bool   `json:"delegator"`
 }
 
 // Arguments to list proposals
@@ -82,6 +83,7 @@ func (args *VoteArguments) ClientRequest(currencies *balance.CurrencySet) (clien
 		Opinion:    opin,
 		GasPrice:   action.Amount{Currency: "OLT", Value: *amt},
 		Gas:        args.Gas,
+		Delegator:  args.Delegator,
 	}, nil
 }
 
@@ -93,6 +95,7 @@ func setVoteArgs() {
 	voteProposalCmd.Flags().StringVar(&voteArgs.Password, "password", "", "password to access secure wallet.")
 	voteProposalCmd.Flags().StringVar(&voteArgs.GasPrice, "gasprice", "0", "include a gas price in OLT")
 	voteProposalCmd.Flags().Int64Var(&voteArgs.Gas, "gas", 40000, "gas limit")
+	voteProposalCmd.Flags().BoolVar(&voteArgs.Delegator, "delegator", false, "vote with the network delegation of the address instead of the node validator")
 }
 
 func setListArgs() {
@@ -162,6 +165,10 @@ func voteProposal(cmd *cobra.Command, args []string) error {
 		return errors.New("error de-serializing rawTx")
 	}
 
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, reply.RawTx)
+	}
+
 	// Open secure wallet
 	if !wallet.Open(usrAddress, voteArgs.Password) {
 		ctx.logger.Error("failed to open secure wallet")
@@ -177,8 +184,11 @@ func voteProposal(cmd *cobra.Command, args []string) error {
 	}
 	sigW := action.Signature{Signer: pub, Signed: signed}
 
-	// Organize signatures and packet
-	signedTx.Signatures = append(signedTx.Signatures, sigW, sigV)
+	// Organize signatures and packet, a network delegator signs alone
+	signedTx.Signatures = append(signedTx.Signatures, sigW)
+	if !voteArgs.Delegator {
+		signedTx.Signatures = append(signedTx.Signatures, sigV)
+	}
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(signedTx)
 	if packet == nil || err != nil {
 		return errors.New("error serializing packet: " + err.Error())
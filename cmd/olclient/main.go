@@ -134,6 +134,27 @@ func BroadcastStatusSync(ctx *Context, result *ctypes.ResultBroadcastTx) bool {
 	}
 }
 
+// SimulateTx runs the unsigned transaction on the fullnode and prints the gas it uses, the gas limit
+// of the transaction should leave room for the signatures which aren't part of the simulation
+func SimulateTx(ctx *Context, rawTx []byte) error {
+	fullnode := ctx.clCtx.FullNodeClient()
+	reply, err := fullnode.SimulateTx(client.SimulateTxRequest{RawTx: rawTx})
+	if err != nil {
+		return err
+	}
+	if !reply.OK {
+		return fmt.Errorf("transaction fails at height %d with code %d: %s", reply.Height, reply.Code, reply.Log)
+	}
+	fmt.Println("Gas used:", reply.GasUsed, "at height", reply.Height)
+	for _, event := range reply.Events {
+		fmt.Println("Event:", event.Type)
+		for _, attr := range event.Attributes {
+			fmt.Printf("	%s: %s\n", attr.Key, attr.Value)
+		}
+	}
+	return nil
+}
+
 func checkTransactionResult(ctx *Context, hash string, prove bool) (*ctypes.ResultTx, bool) {
 	fullnode := ctx.clCtx.FullNodeClient()
 	result, err := fullnode.CheckCommitResult(hash, prove)
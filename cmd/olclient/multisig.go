@@ -0,0 +1,458 @@
+/*
+   ____             _              _                      _____           _                  _
+  / __ \           | |            | |                    |  __ \         | |                | |
+ | |  | |_ __   ___| |     ___  __| | __ _  ___ _ __     | |__) | __ ___ | |_ ___   ___ ___ | |
+ | |  | | '_ \ / _ \ |    / _ \/ _` |/ _` |/ _ \ '__|    |  ___/ '__/ _ \| __/ _ \ / __/ _ \| |
+ | |__| | | | |  __/ |___|  __/ (_| | (_| |  __/ |       | |   | | | (_) | || (_) | (_| (_) | |
+  \____/|_| |_|\___|______\___|\__,_|\__, |\___|_|       |_|   |_|  \___/ \__\___/ \___\___/|_|
+                                      __/ |
+                                     |___/
+
+
+Copyright 2017 - 2020 OneLedger
+*/
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/accounts"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+type MultiSigCreateArguments struct {
+	Creator   []byte   `json:"creator"`
+	Threshold int      `json:"threshold"`
+	Members   []string `json:"members"`
+	Fee       string   `json:"fee"`
+	Gas       int64    `json:"gas"`
+	Password  string   `json:"password"`
+}
+
+type MultiSigShowArguments struct {
+	Account []byte `json:"account"`
+}
+
+type MultiSigBuildSendArguments struct {
+	Account  []byte `json:"account"`
+	To       []byte `json:"to"`
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+	Fee      string `json:"fee"`
+	Gas      int64  `json:"gas"`
+}
+
+type MultiSigSignArguments struct {
+	RawTx    string `json:"rawTx"`
+	Account  []byte `json:"account"`
+	Address  []byte `json:"address"`
+	Bundle   string `json:"bundle"`
+	Password string `json:"password"`
+}
+
+type MultiSigBroadcastArguments struct {
+	RawTx   string   `json:"rawTx"`
+	Bundles []string `json:"bundles"`
+}
+
+var (
+	MultiSigCmd = &cobra.Command{
+		Use:   "multisig",
+		Short: "OneLedger multisig accounts",
+		Long: "Create k-of-n multisig accounts and sign their transactions: a tx of the account is built, signed " +
+			"separately by its members, then the signature bundles are combined and broadcast",
+	}
+
+	multiSigCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Register a multisig account with the threshold of its members",
+		RunE:  multiSigCreate,
+	}
+
+	multiSigShowCmd = &cobra.Command{
+		Use:   "show",
+		Short: "Show the threshold policy of a multisig account",
+		RunE:  multiSigShow,
+	}
+
+	multiSigBuildSendCmd = &cobra.Command{
+		Use:   "build-send",
+		Short: "Build a raw send transaction from a multisig account, to be signed by its members",
+		RunE:  multiSigBuildSend,
+	}
+
+	multiSigSignCmd = &cobra.Command{
+		Use:   "sign",
+		Short: "Sign a raw transaction of a multisig account and print the partially signed bundle",
+		RunE:  multiSigSign,
+	}
+
+	multiSigCombineCmd = &cobra.Command{
+		Use:   "combine",
+		Short: "Combine the signature bundles of the members into one",
+		RunE:  multiSigCombine,
+	}
+
+	multiSigBroadcastCmd = &cobra.Command{
+		Use:   "broadcast",
+		Short: "Broadcast a raw transaction of a multisig account with the signature bundles of its members",
+		RunE:  multiSigBroadcast,
+	}
+
+	multiSigCreateArgs    = &MultiSigCreateArguments{}
+	multiSigBuildSendArgs = &MultiSigBuildSendArguments{}
+	multiSigShowArgs      = &MultiSigShowArguments{}
+	multiSigSignArgs      = &MultiSigSignArguments{}
+	multiSigCombineArgs   = &MultiSigBroadcastArguments{}
+	multiSigBroadcastArgs = &MultiSigBroadcastArguments{}
+)
+
+func init() {
+	RootCmd.AddCommand(MultiSigCmd)
+	MultiSigCmd.AddCommand(multiSigCreateCmd, multiSigShowCmd, multiSigBuildSendCmd, multiSigSignCmd,
+		multiSigCombineCmd, multiSigBroadcastCmd)
+
+	multiSigCreateCmd.Flags().BytesHexVar(&multiSigCreateArgs.Creator, "creator", []byte{}, "address paying the fee of the account creation")
+	multiSigCreateCmd.Flags().IntVar(&multiSigCreateArgs.Threshold, "threshold", 1, "number of members which must sign a transaction")
+	multiSigCreateCmd.Flags().StringSliceVar(&multiSigCreateArgs.Members, "members", []string{}, "addresses of the members, comma separated")
+	multiSigCreateCmd.Flags().StringVar(&multiSigCreateArgs.Fee, "fee", "0", "include a fee in OLT")
+	multiSigCreateCmd.Flags().Int64Var(&multiSigCreateArgs.Gas, "gas", 20000, "gas limit")
+	multiSigCreateCmd.Flags().StringVar(&multiSigCreateArgs.Password, "password", "", "password to access secure wallet")
+
+	multiSigShowCmd.Flags().BytesHexVar(&multiSigShowArgs.Account, "account", []byte{}, "address of the multisig account")
+
+	multiSigBuildSendCmd.Flags().BytesHexVar(&multiSigBuildSendArgs.Account, "account", []byte{}, "address of the multisig account")
+	multiSigBuildSendCmd.Flags().BytesHexVar(&multiSigBuildSendArgs.To, "to", []byte{}, "send recipient")
+	multiSigBuildSendCmd.Flags().StringVar(&multiSigBuildSendArgs.Amount, "amount", "0", "specify an amount")
+	multiSigBuildSendCmd.Flags().StringVar(&multiSigBuildSendArgs.Currency, "currency", "OLT", "the currency")
+	multiSigBuildSendCmd.Flags().StringVar(&multiSigBuildSendArgs.Fee, "fee", "0", "include a fee in OLT")
+	multiSigBuildSendCmd.Flags().Int64Var(&multiSigBuildSendArgs.Gas, "gas", 20000, "gas limit")
+
+	multiSigSignCmd.Flags().StringVar(&multiSigSignArgs.RawTx, "rawtx", "", "raw transaction to sign, hex encoded")
+	multiSigSignCmd.Flags().BytesHexVar(&multiSigSignArgs.Account, "account", []byte{}, "address of the multisig account")
+	multiSigSignCmd.Flags().BytesHexVar(&multiSigSignArgs.Address, "address", []byte{}, "address of the signing member")
+	multiSigSignCmd.Flags().StringVar(&multiSigSignArgs.Bundle, "bundle", "", "file of a bundle already signed by other members, optional")
+	multiSigSignCmd.Flags().StringVar(&multiSigSignArgs.Password, "password", "", "password to access secure wallet")
+
+	multiSigCombineCmd.Flags().StringSliceVar(&multiSigCombineArgs.Bundles, "bundles", []string{}, "files of the bundles to combine, comma separated")
+
+	multiSigBroadcastCmd.Flags().StringVar(&multiSigBroadcastArgs.RawTx, "rawtx", "", "raw transaction to broadcast, hex encoded")
+	multiSigBroadcastCmd.Flags().StringSliceVar(&multiSigBroadcastArgs.Bundles, "bundles", []string{}, "files of the signature bundles, comma separated")
+}
+
+func multiSigCreate(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	members := make([]keys.Address, len(multiSigCreateArgs.Members))
+	for i, m := range multiSigCreateArgs.Members {
+		err := members[i].UnmarshalText([]byte(m))
+		if err != nil {
+			return errors.Wrap(err, "invalid member address")
+		}
+	}
+	currencies, err := fullnode.ListCurrencies()
+	if err != nil {
+		ctx.logger.Error("failed to get currencies", err)
+		return err
+	}
+	gasPrice, err := feeAmount(currencies.Currencies.GetCurrencySet(), multiSigCreateArgs.Fee)
+	if err != nil {
+		return err
+	}
+
+	//Prompt for password
+	if len(multiSigCreateArgs.Password) == 0 {
+		multiSigCreateArgs.Password = PromptForPassword()
+	}
+
+	//Create new Wallet and User Address
+	wallet, err := accounts.NewWalletKeyStore(keyStorePath)
+	if err != nil {
+		ctx.logger.Error("failed to create secure wallet", err)
+		return err
+	}
+
+	//Verify User Password
+	usrAddress := keys.Address(multiSigCreateArgs.Creator)
+	authenticated, err := wallet.VerifyPassphrase(usrAddress, multiSigCreateArgs.Password)
+	if !authenticated {
+		ctx.logger.Error("authentication error", err)
+		return err
+	}
+
+	out, err := fullnode.MultiSigAccountCreate(client.MultiSigAccountCreateRequest{
+		Creator:   usrAddress,
+		Threshold: multiSigCreateArgs.Threshold,
+		Members:   members,
+		GasPrice:  gasPrice,
+		Gas:       multiSigCreateArgs.Gas,
+	})
+	if err != nil {
+		ctx.logger.Error("failed to create multisig account tx", err)
+		return err
+	}
+	fmt.Println("Multisig account address:", multisig.AccountAddress(multiSigCreateArgs.Threshold, members).String())
+
+	rawTx := &action.RawTx{}
+	err = serialize.GetSerializer(serialize.NETWORK).Deserialize(out.RawTx, rawTx)
+	if err != nil {
+		ctx.logger.Error("failed to deserialize RawTx", err)
+		return err
+	}
+
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, out.RawTx)
+	}
+
+	if !wallet.Open(usrAddress, multiSigCreateArgs.Password) {
+		ctx.logger.Error("failed to open secure wallet")
+		return errors.New("failed to open secure wallet")
+	}
+
+	pub, signature, err := wallet.SignWithAddress(out.RawTx, usrAddress)
+	if err != nil {
+		ctx.logger.Error("error signing transaction", err)
+		return err
+	}
+
+	signedTx := &action.SignedTx{
+		RawTx:      *rawTx,
+		Signatures: []action.Signature{{Signer: pub, Signed: signature}},
+	}
+	return broadcastSignedTx(ctx, signedTx)
+}
+
+func multiSigShow(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	out, err := fullnode.GetMultiSigAccount(client.MultiSigAccountRequest{Address: multiSigShowArgs.Account})
+	if err != nil {
+		ctx.logger.Error("failed to get multisig account", err)
+		return err
+	}
+	fmt.Println("Address:", out.Account.Address.String())
+	fmt.Println("Threshold:", out.Account.Threshold, "of", len(out.Account.Signers))
+	for i, signer := range out.Account.Signers {
+		fmt.Println("Member", strconv.Itoa(i)+":", signer.String())
+	}
+	return nil
+}
+
+func multiSigBuildSend(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+	currencies, err := fullnode.ListCurrencies()
+	if err != nil {
+		ctx.logger.Error("failed to get currencies", err)
+		return err
+	}
+
+	send := &SendArguments{
+		Party:        multiSigBuildSendArgs.Account,
+		CounterParty: multiSigBuildSendArgs.To,
+		Amount:       multiSigBuildSendArgs.Amount,
+		Currency:     multiSigBuildSendArgs.Currency,
+		Fee:          multiSigBuildSendArgs.Fee,
+		Gas:          multiSigBuildSendArgs.Gas,
+	}
+	req, err := send.ClientRequest(currencies.Currencies.GetCurrencySet())
+	if err != nil {
+		ctx.logger.Error("failed to get request", err)
+		return err
+	}
+
+	reply, err := fullnode.CreateRawSend(req)
+	if err != nil {
+		ctx.logger.Error("failed to create SendTx", err)
+		return err
+	}
+
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, reply.RawTx)
+	}
+	fmt.Println(hex.EncodeToString(reply.RawTx))
+	return nil
+}
+
+func multiSigSign(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	rawTx, err := hex.DecodeString(multiSigSignArgs.RawTx)
+	if err != nil {
+		return errors.Wrap(err, "invalid raw tx")
+	}
+
+	out, err := fullnode.GetMultiSigAccount(client.MultiSigAccountRequest{Address: multiSigSignArgs.Account})
+	if err != nil {
+		ctx.logger.Error("failed to get multisig account", err)
+		return err
+	}
+	bundle, err := out.Account.NewBundle(rawTx)
+	if err != nil {
+		return err
+	}
+	usrAddress := keys.Address(multiSigSignArgs.Address)
+	index, err := bundle.GetSignerIndex(usrAddress)
+	if err != nil {
+		return errors.Wrap(err, "not a member of the multisig account")
+	}
+
+	//Prompt for password
+	if len(multiSigSignArgs.Password) == 0 {
+		multiSigSignArgs.Password = PromptForPassword()
+	}
+
+	wallet, err := accounts.NewWalletKeyStore(keyStorePath)
+	if err != nil {
+		ctx.logger.Error("failed to create secure wallet", err)
+		return err
+	}
+	if !wallet.Open(usrAddress, multiSigSignArgs.Password) {
+		ctx.logger.Error("failed to open secure wallet")
+		return errors.New("failed to open secure wallet")
+	}
+
+	pub, signature, err := wallet.SignWithAddress(rawTx, usrAddress)
+	if err != nil {
+		ctx.logger.Error("error signing transaction", err)
+		return err
+	}
+	wallet.Close()
+
+	err = bundle.AddSignature(keys.Signature{Index: index, PubKey: pub, Signed: signature})
+	if err != nil {
+		return err
+	}
+
+	if len(multiSigSignArgs.Bundle) != 0 {
+		signed, err := readBundles([]string{multiSigSignArgs.Bundle})
+		if err != nil {
+			return err
+		}
+		bundle, err = multisig.Combine(append(signed, *bundle)...)
+		if err != nil {
+			return err
+		}
+	}
+	return printBundle(bundle)
+}
+
+func multiSigCombine(cmd *cobra.Command, args []string) error {
+	bundles, err := readBundles(multiSigCombineArgs.Bundles)
+	if err != nil {
+		return err
+	}
+	bundle, err := multisig.Combine(bundles...)
+	if err != nil {
+		return err
+	}
+	return printBundle(bundle)
+}
+
+func multiSigBroadcast(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+
+	rawTxBytes, err := hex.DecodeString(multiSigBroadcastArgs.RawTx)
+	if err != nil {
+		return errors.Wrap(err, "invalid raw tx")
+	}
+	rawTx := &action.RawTx{}
+	err = serialize.GetSerializer(serialize.NETWORK).Deserialize(rawTxBytes, rawTx)
+	if err != nil {
+		ctx.logger.Error("failed to deserialize RawTx", err)
+		return err
+	}
+
+	bundles, err := readBundles(multiSigBroadcastArgs.Bundles)
+	if err != nil {
+		return err
+	}
+	bundle, err := multisig.Combine(bundles...)
+	if err != nil {
+		return err
+	}
+	if !bundle.IsValid() {
+		return errors.New("not enough signatures to reach the threshold of the multisig account")
+	}
+	// the bundle is verified against the raw tx of the signed tx, no need to send the message twice
+	bundle.Msg = nil
+
+	signedTx := &action.SignedTx{
+		RawTx:      *rawTx,
+		Signatures: []action.Signature{{MultiSig: bundle}},
+	}
+	return broadcastSignedTx(ctx, signedTx)
+}
+
+func broadcastSignedTx(ctx *Context, signedTx *action.SignedTx) error {
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(signedTx)
+	if err != nil {
+		ctx.logger.Error("failed to serialize signedTx", err)
+		return err
+	}
+
+	result, err := ctx.clCtx.BroadcastTxSync(packet)
+	if err != nil {
+		ctx.logger.Error("error in BroadcastTxSync", err)
+		return err
+	}
+
+	if BroadcastStatusSync(ctx, result) {
+		PollTxResult(ctx, result.Hash.String())
+	}
+	return nil
+}
+
+// feeAmount returns the gas price of the fee given in OLT
+func feeAmount(currencies *balance.CurrencySet, fee string) (action.Amount, error) {
+	_, err := strconv.ParseFloat(fee, 64)
+	if err != nil {
+		return action.Amount{}, err
+	}
+	olt, ok := currencies.GetCurrencyByName("OLT")
+	if !ok {
+		return action.Amount{}, errors.New("currency not support: OLT")
+	}
+	feeAmt := olt.NewCoinFromString(padZero(fee)).Amount
+	return action.Amount{Currency: "OLT", Value: *feeAmt}, nil
+}
+
+func readBundles(files []string) ([]keys.MultiSig, error) {
+	bundles := make([]keys.MultiSig, len(files))
+	for i, file := range files {
+		dat, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read bundle %s", file)
+		}
+		err = json.Unmarshal(dat, &bundles[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid bundle %s", file)
+		}
+	}
+	return bundles, nil
+}
+
+func printBundle(bundle *keys.MultiSig) error {
+	dat, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(dat))
+	return nil
+}
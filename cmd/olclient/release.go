@@ -106,6 +106,10 @@ func releaseExec(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, out.RawTx)
+	}
+
 	if !wallet.Open(usrAddress, releaseArgs.Password) {
 		ctx.logger.Error("failed to open secure wallet")
 		return err
@@ -31,6 +31,7 @@ var rootArgs = rootArguments{}
 
 type rootArguments struct {
 	rootDir string
+	dryRun  bool
 }
 
 // Initialize Cobra, config global arguments
@@ -38,6 +39,8 @@ func init() {
 
 	RootCmd.PersistentFlags().StringVar(&rootArgs.rootDir, "root",
 		"./", "Set root directory")
+	RootCmd.PersistentFlags().BoolVar(&rootArgs.dryRun, "dry-run", false,
+		"Simulate the transaction and print the gas it uses instead of broadcasting it")
 
 	/*
 		RootCmd.PersistentFlags().StringVar(&global.Current.NodeName, "node",
@@ -193,6 +193,14 @@ func IssueRequest(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	if rootArgs.dryRun {
+		err = SimulateTx(ctx, reply.RawTx)
+		if err != nil {
+			ctx.logger.Error("failed to simulate SendTx", err)
+		}
+		return
+	}
+
 	if !wallet.Open(usrAddress, sendargs.Password) {
 		ctx.logger.Error("failed to open secure wallet")
 		return
@@ -204,7 +212,7 @@ func IssueRequest(cmd *cobra.Command, args []string) {
 		ctx.logger.Error("error signing transaction", err)
 	}
 
-	signatures := []action.Signature{{pub, signature}}
+	signatures := []action.Signature{{Signer: pub, Signed: signature}}
 	signedTx := &action.SignedTx{
 		RawTx:      *rawTx,
 		Signatures: signatures,
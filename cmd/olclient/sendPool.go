@@ -100,6 +100,10 @@ func sendFundsPool(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, reply.RawTx)
+	}
+
 	if !wallet.Open(usrAddress, sendpoolargs.Password) {
 		ctx.logger.Error("failed to open secure wallet")
 		return err
@@ -111,7 +115,7 @@ func sendFundsPool(cmd *cobra.Command, args []string) error {
 		ctx.logger.Error("error signing transaction", err)
 	}
 
-	signatures := []action.Signature{{pub, signature}}
+	signatures := []action.Signature{{Signer: pub, Signed: signature}}
 	signedTx := &action.SignedTx{
 		RawTx:      *rawTx,
 		Signatures: signatures,
@@ -110,6 +110,10 @@ func delegateStake(cmd *cobra.Command, args []string) error {
 		return errors.New("error de-serializing signedTx")
 	}
 
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, out.RawTx)
+	}
+
 	if !wallet.Open(usrAddress, stakeArgs.Password) {
 		ctx.logger.Error("failed to open secure wallet")
 		return errors.New("failed to open secure wallet")
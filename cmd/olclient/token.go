@@ -0,0 +1,288 @@
+/*
+   ____             _              _                      _____           _                  _
+  / __ \           | |            | |                    |  __ \         | |                | |
+ | |  | |_ __   ___| |     ___  __| | __ _  ___ _ __     | |__) | __ ___ | |_ ___   ___ ___ | |
+ | |  | | '_ \ / _ \ |    / _ \/ _` |/ _` |/ _ \ '__|    |  ___/ '__/ _ \| __/ _ \ / __/ _ \| |
+ | |__| | | | |  __/ |___|  __/ (_| | (_| |  __/ |       | |   | | | (_) | || (_) | (_| (_) | |
+  \____/|_| |_|\___|______\___|\__,_|\__, |\___|_|       |_|   |_|  \___/ \__\___/ \___\___/|_|
+                                      __/ |
+                                     |___/
+
+
+Copyright 2017 - 2020 OneLedger
+*/
+
+
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/accounts"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+type TokenCreateArguments struct {
+	Creator   []byte `json:"creator"`
+	Name      string `json:"name"`
+	Unit      string `json:"unit"`
+	Decimal   int64  `json:"decimal"`
+	Issuer    []byte `json:"issuer"`
+	MaxSupply string `json:"maxSupply"`
+	Fee       string `json:"fee"`
+	Gas       int64  `json:"gas"`
+	Password  string `json:"password"`
+}
+
+type TokenMintArguments struct {
+	Issuer   []byte `json:"issuer"`
+	To       []byte `json:"to"`
+	Name     string `json:"name"`
+	Amount   string `json:"amount"`
+	Fee      string `json:"fee"`
+	Gas      int64  `json:"gas"`
+	Password string `json:"password"`
+}
+
+var (
+	TokenCmd = &cobra.Command{
+		Use:   "token",
+		Short: "OneLedger token factory",
+		Long:  "Create new currencies, and mint or burn them as their issuer",
+	}
+
+	tokenCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Create a new token by paying the creation fee",
+		RunE:  tokenCreate,
+	}
+
+	tokenMintCmd = &cobra.Command{
+		Use:   "mint",
+		Short: "Mint tokens to an address, signed by the issuer",
+		RunE:  tokenMint,
+	}
+
+	tokenBurnCmd = &cobra.Command{
+		Use:   "burn",
+		Short: "Burn tokens of the issuer",
+		RunE:  tokenBurn,
+	}
+
+	tokenShowCmd = &cobra.Command{
+		Use:   "show",
+		Short: "Show a token, or all the tokens without a name",
+		RunE:  tokenShow,
+	}
+
+	tokenCreateArgs = &TokenCreateArguments{}
+	tokenMintArgs   = &TokenMintArguments{}
+	tokenShowName   string
+)
+
+func init() {
+	RootCmd.AddCommand(TokenCmd)
+	TokenCmd.AddCommand(tokenCreateCmd, tokenMintCmd, tokenBurnCmd, tokenShowCmd)
+
+	tokenCreateCmd.Flags().BytesHexVar(&tokenCreateArgs.Creator, "creator", []byte{}, "address paying the creation fee")
+	tokenCreateCmd.Flags().StringVar(&tokenCreateArgs.Name, "name", "", "currency name of the token")
+	tokenCreateCmd.Flags().StringVar(&tokenCreateArgs.Unit, "unit", "", "name of the smallest unit of the token")
+	tokenCreateCmd.Flags().Int64Var(&tokenCreateArgs.Decimal, "decimal", 18, "number of decimals of the token")
+	tokenCreateCmd.Flags().BytesHexVar(&tokenCreateArgs.Issuer, "issuer", []byte{}, "address allowed to mint and burn the token")
+	tokenCreateCmd.Flags().StringVar(&tokenCreateArgs.MaxSupply, "max-supply", "0", "max supply, in the smallest unit")
+	tokenCreateCmd.Flags().StringVar(&tokenCreateArgs.Fee, "fee", "0", "include a fee in OLT")
+	tokenCreateCmd.Flags().Int64Var(&tokenCreateArgs.Gas, "gas", 20000, "gas limit")
+	tokenCreateCmd.Flags().StringVar(&tokenCreateArgs.Password, "password", "", "password to access secure wallet")
+
+	for _, cmd := range []*cobra.Command{tokenMintCmd, tokenBurnCmd} {
+		cmd.Flags().BytesHexVar(&tokenMintArgs.Issuer, "issuer", []byte{}, "issuer of the token")
+		cmd.Flags().StringVar(&tokenMintArgs.Name, "name", "", "currency name of the token")
+		cmd.Flags().StringVar(&tokenMintArgs.Amount, "amount", "0", "amount, in the smallest unit")
+		cmd.Flags().StringVar(&tokenMintArgs.Fee, "fee", "0", "include a fee in OLT")
+		cmd.Flags().Int64Var(&tokenMintArgs.Gas, "gas", 20000, "gas limit")
+		cmd.Flags().StringVar(&tokenMintArgs.Password, "password", "", "password to access secure wallet")
+	}
+	tokenMintCmd.Flags().BytesHexVar(&tokenMintArgs.To, "to", []byte{}, "address receiving the minted tokens")
+
+	tokenShowCmd.Flags().StringVar(&tokenShowName, "name", "", "currency name of the token")
+}
+
+// tokenAmount parses an amount given in the smallest unit of the token
+func tokenAmount(value string) (*balance.Amount, error) {
+	amt, err := balance.NewAmountFromString(value, 10)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid amount "+value)
+	}
+	return amt, nil
+}
+
+func tokenCreate(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	maxSupply, err := tokenAmount(tokenCreateArgs.MaxSupply)
+	if err != nil {
+		return err
+	}
+	gasPrice, err := tokenFee(fullnode, tokenCreateArgs.Fee)
+	if err != nil {
+		return err
+	}
+
+	out, err := fullnode.TokenCreate(client.TokenCreateRequest{
+		Creator:   tokenCreateArgs.Creator,
+		Name:      tokenCreateArgs.Name,
+		Unit:      tokenCreateArgs.Unit,
+		Decimal:   tokenCreateArgs.Decimal,
+		Issuer:    tokenCreateArgs.Issuer,
+		MaxSupply: *maxSupply,
+		GasPrice:  gasPrice,
+		Gas:       tokenCreateArgs.Gas,
+	})
+	if err != nil {
+		ctx.logger.Error("failed to create token tx", err)
+		return err
+	}
+	return tokenSignAndBroadcast(ctx, out, tokenCreateArgs.Creator, tokenCreateArgs.Password)
+}
+
+func tokenMint(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	amt, err := tokenAmount(tokenMintArgs.Amount)
+	if err != nil {
+		return err
+	}
+	gasPrice, err := tokenFee(fullnode, tokenMintArgs.Fee)
+	if err != nil {
+		return err
+	}
+
+	out, err := fullnode.TokenMint(client.TokenMintRequest{
+		Issuer:   tokenMintArgs.Issuer,
+		To:       tokenMintArgs.To,
+		Amount:   action.Amount{Currency: tokenMintArgs.Name, Value: *amt},
+		GasPrice: gasPrice,
+		Gas:      tokenMintArgs.Gas,
+	})
+	if err != nil {
+		ctx.logger.Error("failed to create mint tx", err)
+		return err
+	}
+	return tokenSignAndBroadcast(ctx, out, tokenMintArgs.Issuer, tokenMintArgs.Password)
+}
+
+func tokenBurn(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	amt, err := tokenAmount(tokenMintArgs.Amount)
+	if err != nil {
+		return err
+	}
+	gasPrice, err := tokenFee(fullnode, tokenMintArgs.Fee)
+	if err != nil {
+		return err
+	}
+
+	out, err := fullnode.TokenBurn(client.TokenBurnRequest{
+		Issuer:   tokenMintArgs.Issuer,
+		Amount:   action.Amount{Currency: tokenMintArgs.Name, Value: *amt},
+		GasPrice: gasPrice,
+		Gas:      tokenMintArgs.Gas,
+	})
+	if err != nil {
+		ctx.logger.Error("failed to create burn tx", err)
+		return err
+	}
+	return tokenSignAndBroadcast(ctx, out, tokenMintArgs.Issuer, tokenMintArgs.Password)
+}
+
+func tokenShow(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	if tokenShowName == "" {
+		out, err := fullnode.ListTokens()
+		if err != nil {
+			ctx.logger.Error("failed to list tokens", err)
+			return err
+		}
+		for _, token := range out.Tokens {
+			fmt.Println(token.Currency.Name, "id", token.Currency.Id, "supply", token.Supply.String()+"/"+token.MaxSupply.String(),
+				"issuer", token.Issuer.String())
+		}
+		fmt.Println("Height:", strconv.FormatInt(out.Height, 10))
+		return nil
+	}
+
+	out, err := fullnode.GetToken(client.TokenRequest{Name: tokenShowName})
+	if err != nil {
+		ctx.logger.Error("failed to get token", err)
+		return err
+	}
+	t := out.Token
+	fmt.Println("Name:", t.Currency.Name, "Unit:", t.Currency.Unit, "Decimal:", t.Currency.Decimal, "Id:", t.Currency.Id)
+	fmt.Println("Issuer:", t.Issuer.String())
+	fmt.Println("Supply:", t.Supply.String(), "Max supply:", t.MaxSupply.String())
+	fmt.Println("Created at height:", t.Height)
+	return nil
+}
+
+func tokenFee(fullnode *client.ServiceClient, fee string) (action.Amount, error) {
+	currencies, err := fullnode.ListCurrencies()
+	if err != nil {
+		return action.Amount{}, errors.Wrap(err, "failed to get currencies")
+	}
+	return feeAmount(currencies.Currencies.GetCurrencySet(), fee)
+}
+
+func tokenSignAndBroadcast(ctx *Context, out client.CreateTxReply, address keys.Address, password string) error {
+	rawTx := &action.RawTx{}
+	err := serialize.GetSerializer(serialize.NETWORK).Deserialize(out.RawTx, rawTx)
+	if err != nil {
+		ctx.logger.Error("failed to deserialize RawTx", err)
+		return err
+	}
+
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, out.RawTx)
+	}
+
+	//Prompt for password
+	if len(password) == 0 {
+		password = PromptForPassword()
+	}
+
+	wallet, err := accounts.NewWalletKeyStore(keyStorePath)
+	if err != nil {
+		ctx.logger.Error("failed to create secure wallet", err)
+		return err
+	}
+	if !wallet.Open(address, password) {
+		ctx.logger.Error("failed to open secure wallet")
+		return errors.New("failed to open secure wallet")
+	}
+
+	pub, signature, err := wallet.SignWithAddress(out.RawTx, address)
+	if err != nil {
+		ctx.logger.Error("error signing transaction", err)
+		return err
+	}
+
+	signedTx := &action.SignedTx{
+		RawTx:      *rawTx,
+		Signatures: []action.Signature{{Signer: pub, Signed: signature}},
+	}
+	return broadcastSignedTx(ctx, signedTx)
+}
@@ -106,6 +106,10 @@ func delegateUnstake(cmd *cobra.Command, args []string) error {
 		return errors.New("error de-serializing signedTx")
 	}
 
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, out.RawTx)
+	}
+
 	if !wallet.Open(usrAddress, unstakeArgs.Password) {
 		ctx.logger.Error("failed to open secure wallet")
 		return errors.New("failed to open secure wallet")
@@ -0,0 +1,211 @@
+/*
+   ____             _              _                      _____           _                  _
+  / __ \           | |            | |                    |  __ \         | |                | |
+ | |  | |_ __   ___| |     ___  __| | __ _  ___ _ __     | |__) | __ ___ | |_ ___   ___ ___ | |
+ | |  | | '_ \ / _ \ |    / _ \/ _` |/ _` |/ _ \ '__|    |  ___/ '__/ _ \| __/ _ \ / __/ _ \| |
+ | |__| | | | |  __/ |___|  __/ (_| | (_| |  __/ |       | |   | | | (_) | || (_) | (_| (_) | |
+  \____/|_| |_|\___|______\___|\__,_|\__, |\___|_|       |_|   |_|  \___/ \__\___/ \___\___/|_|
+                                      __/ |
+                                     |___/
+
+
+Copyright 2017 - 2020 OneLedger
+*/
+
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/accounts"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+type VestingCreateArguments struct {
+	Funder       []byte `json:"funder"`
+	Beneficiary  []byte `json:"beneficiary"`
+	Amount       string `json:"amount"`
+	Currency     string `json:"currency"`
+	StartHeight  int64  `json:"startHeight"`
+	CliffHeight  int64  `json:"cliffHeight"`
+	EndHeight    int64  `json:"endHeight"`
+	PeriodLength int64  `json:"periodLength"`
+	Fee          string `json:"fee"`
+	Gas          int64  `json:"gas"`
+	Password     string `json:"password"`
+}
+
+type VestingShowArguments struct {
+	Address  []byte `json:"address"`
+	Currency string `json:"currency"`
+}
+
+var (
+	VestingCmd = &cobra.Command{
+		Use:   "vesting",
+		Short: "OneLedger vesting accounts",
+		Long:  "Send coins locked by a vesting schedule, the locked coins can be staked or delegated but not spent",
+	}
+
+	vestingCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Send coins to a beneficiary, locked until they vest",
+		RunE:  vestingCreate,
+	}
+
+	vestingShowCmd = &cobra.Command{
+		Use:   "show",
+		Short: "Show the vesting schedule of an address and its locked balance",
+		RunE:  vestingShow,
+	}
+
+	vestingCreateArgs = &VestingCreateArguments{}
+	vestingShowArgs   = &VestingShowArguments{}
+)
+
+func init() {
+	RootCmd.AddCommand(VestingCmd)
+	VestingCmd.AddCommand(vestingCreateCmd, vestingShowCmd)
+
+	vestingCreateCmd.Flags().BytesHexVar(&vestingCreateArgs.Funder, "funder", []byte{}, "address sending the coins")
+	vestingCreateCmd.Flags().BytesHexVar(&vestingCreateArgs.Beneficiary, "beneficiary", []byte{}, "address receiving the vesting coins")
+	vestingCreateCmd.Flags().StringVar(&vestingCreateArgs.Amount, "amount", "0", "specify an amount")
+	vestingCreateCmd.Flags().StringVar(&vestingCreateArgs.Currency, "currency", "OLT", "the currency")
+	vestingCreateCmd.Flags().Int64Var(&vestingCreateArgs.StartHeight, "start", 0, "height the vesting starts at")
+	vestingCreateCmd.Flags().Int64Var(&vestingCreateArgs.CliffHeight, "cliff", 0, "height before which nothing vests, 0 for no cliff")
+	vestingCreateCmd.Flags().Int64Var(&vestingCreateArgs.EndHeight, "end", 0, "height everything has vested at")
+	vestingCreateCmd.Flags().Int64Var(&vestingCreateArgs.PeriodLength, "period", 0, "vest in steps of this many blocks instead of linearly")
+	vestingCreateCmd.Flags().StringVar(&vestingCreateArgs.Fee, "fee", "0", "include a fee in OLT")
+	vestingCreateCmd.Flags().Int64Var(&vestingCreateArgs.Gas, "gas", 20000, "gas limit")
+	vestingCreateCmd.Flags().StringVar(&vestingCreateArgs.Password, "password", "", "password to access secure wallet")
+
+	vestingShowCmd.Flags().BytesHexVar(&vestingShowArgs.Address, "address", []byte{}, "address of the vesting account")
+	vestingShowCmd.Flags().StringVar(&vestingShowArgs.Currency, "currency", "OLT", "the currency")
+}
+
+func vestingCreate(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	currencies, err := fullnode.ListCurrencies()
+	if err != nil {
+		ctx.logger.Error("failed to get currencies", err)
+		return err
+	}
+	currencySet := currencies.Currencies.GetCurrencySet()
+	c, ok := currencySet.GetCurrencyByName(vestingCreateArgs.Currency)
+	if !ok {
+		return errors.New("currency not support:" + vestingCreateArgs.Currency)
+	}
+	_, err = strconv.ParseFloat(vestingCreateArgs.Amount, 64)
+	if err != nil {
+		return err
+	}
+	amt := c.NewCoinFromString(padZero(vestingCreateArgs.Amount)).Amount
+	gasPrice, err := feeAmount(currencySet, vestingCreateArgs.Fee)
+	if err != nil {
+		return err
+	}
+	schedule := balance.VestingLinear
+	if vestingCreateArgs.PeriodLength > 0 {
+		schedule = balance.VestingPeriodic
+	}
+
+	//Prompt for password
+	if len(vestingCreateArgs.Password) == 0 {
+		vestingCreateArgs.Password = PromptForPassword()
+	}
+
+	//Create new Wallet and User Address
+	wallet, err := accounts.NewWalletKeyStore(keyStorePath)
+	if err != nil {
+		ctx.logger.Error("failed to create secure wallet", err)
+		return err
+	}
+
+	//Verify User Password
+	usrAddress := keys.Address(vestingCreateArgs.Funder)
+	authenticated, err := wallet.VerifyPassphrase(usrAddress, vestingCreateArgs.Password)
+	if !authenticated {
+		ctx.logger.Error("authentication error", err)
+		return err
+	}
+
+	out, err := fullnode.CreateVesting(client.CreateVestingRequest{
+		Funder:       usrAddress,
+		Beneficiary:  vestingCreateArgs.Beneficiary,
+		Amount:       action.Amount{Currency: c.Name, Value: *amt},
+		StartHeight:  vestingCreateArgs.StartHeight,
+		CliffHeight:  vestingCreateArgs.CliffHeight,
+		EndHeight:    vestingCreateArgs.EndHeight,
+		Schedule:     schedule,
+		PeriodLength: vestingCreateArgs.PeriodLength,
+		GasPrice:     gasPrice,
+		Gas:          vestingCreateArgs.Gas,
+	})
+	if err != nil {
+		ctx.logger.Error("failed to create vesting tx", err)
+		return err
+	}
+
+	rawTx := &action.RawTx{}
+	err = serialize.GetSerializer(serialize.NETWORK).Deserialize(out.RawTx, rawTx)
+	if err != nil {
+		ctx.logger.Error("failed to deserialize RawTx", err)
+		return err
+	}
+
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, out.RawTx)
+	}
+
+	if !wallet.Open(usrAddress, vestingCreateArgs.Password) {
+		ctx.logger.Error("failed to open secure wallet")
+		return errors.New("failed to open secure wallet")
+	}
+
+	pub, signature, err := wallet.SignWithAddress(out.RawTx, usrAddress)
+	if err != nil {
+		ctx.logger.Error("error signing transaction", err)
+		return err
+	}
+
+	signedTx := &action.SignedTx{
+		RawTx:      *rawTx,
+		Signatures: []action.Signature{{Signer: pub, Signed: signature}},
+	}
+	return broadcastSignedTx(ctx, signedTx)
+}
+
+func vestingShow(cmd *cobra.Command, args []string) error {
+	ctx := NewContext()
+	fullnode := ctx.clCtx.FullNodeClient()
+
+	out, err := fullnode.GetVestingAccount(client.VestingAccountRequest{
+		Address:  vestingShowArgs.Address,
+		Currency: vestingShowArgs.Currency,
+	})
+	if err != nil {
+		ctx.logger.Error("failed to get vesting account", err)
+		return err
+	}
+	v := out.Vesting
+	fmt.Println("Address:", v.Address.String())
+	fmt.Println("Schedule:", v.Schedule, "from", v.StartHeight, "to", v.EndHeight, "cliff", v.CliffHeight)
+	if v.Schedule == balance.VestingPeriodic {
+		fmt.Println("Period length:", v.PeriodLength)
+	}
+	fmt.Println("Original vesting:", v.OriginalVesting.String(), v.Currency)
+	fmt.Println("Delegated vesting:", v.DelegatedVesting.String(), v.Currency)
+	fmt.Println("Locked at height", strconv.FormatInt(out.Height, 10)+":", out.Locked)
+	return nil
+}
@@ -113,6 +113,10 @@ func voteExec(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, out.RawTx)
+	}
+
 	if !wallet.Open(usrAddress, allegationVoteArgs.Password) {
 		ctx.logger.Error("failed to open secure wallet")
 		return err
@@ -108,6 +108,10 @@ func withdrawRewards(cmd *cobra.Command, args []string) error {
 		return errors.New("error de-serializing signedTx")
 	}
 
+	if rootArgs.dryRun {
+		return SimulateTx(ctx, out.RawTx)
+	}
+
 	if !wallet.Open(usrAddress, withDrawRewardsArgs.Password) {
 		ctx.logger.Error("failed to open secure wallet")
 		return errors.New("failed to open secure wallet")
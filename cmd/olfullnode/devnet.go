@@ -23,6 +23,7 @@ import (
 	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/network_delegation"
 	"github.com/Oneledger/protocol/data/rewards"
+	"github.com/Oneledger/protocol/data/tokens"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -49,6 +50,7 @@ import (
 	"github.com/Oneledger/protocol/data/keys"
 
 	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
 )
 
 var (
@@ -150,6 +152,8 @@ type testnetConfig struct {
 	useAsync                 bool
 	cacheSize                uint64
 	frankensteinBlock        int64
+	nonceBlock               int64
+	forks                    []string
 }
 
 func init() {
@@ -185,6 +189,8 @@ func init() {
 	testnetCmd.Flags().BoolVar(&testnetArgs.useAsync, "use_async", false, "async mode for olvm send transaction")
 	testnetCmd.Flags().Uint64Var(&testnetArgs.cacheSize, "cache_size", 10000, "cache size for mempool")
 	testnetCmd.Flags().Int64Var(&testnetArgs.frankensteinBlock, "frankenstein_block", 1, "Fork block for frankenstein update")
+	testnetCmd.Flags().Int64Var(&testnetArgs.nonceBlock, "nonce_block", 1, "Fork block for account nonce update")
+	testnetCmd.Flags().StringSliceVar(&testnetArgs.forks, "forks", []string{}, "Fork blocks of the other forks, as name:height")
 }
 
 func randStr(size int) string {
@@ -568,8 +574,14 @@ func runDevnet(_ *cobra.Command, _ []string) error {
 	}
 	genesisDoc.Validators = validatorList
 
+	forkHeights, err := parseForkHeights(args.forks)
+	if err != nil {
+		return err
+	}
 	genesisDoc.ForkParams = &config.ForkParams{
 		FrankensteinBlock: args.frankensteinBlock,
+		NonceBlock:        args.nonceBlock,
+		Forks:             forkHeights,
 	}
 
 	for i := 0; i < totalNodes; i++ {
@@ -610,6 +622,11 @@ func initialState(args *testnetConfig, nodeList []node, option ethchain.ChainDri
 		FeeCurrency:   olt,
 		MinFeeDecimal: 9,
 	}
+	// the fee market is disabled until a proposal enables it
+	feeMarketOpt := fees.MarketOptions{
+		TargetUtilisation:        50,
+		BaseFeeChangeDenominator: 8,
+	}
 	balances := make([]consensus.BalanceState, 0, len(nodeList))
 	staking := make([]consensus.Stake, 0, len(nodeList))
 	rewards := rewards.RewardMasterState{
@@ -776,6 +793,10 @@ func initialState(args *testnetConfig, nodeList []node, option ethchain.ChainDri
 			DelegOptions:    delegOption,
 			EvidenceOptions: evidenceOption,
 			RewardOptions:   rewardOpt,
+
+			FeeMarketOptions: feeMarketOpt,
+			GasOptions:       storage.DefaultGasOptions(),
+			TokenOptions:     tokens.Options{CreationFee: *balance.NewAmount(0)},
 		},
 	}
 }
@@ -29,7 +29,9 @@ import (
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/data/ons"
 	"github.com/Oneledger/protocol/data/rewards"
+	"github.com/Oneledger/protocol/data/tokens"
 	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
 )
 
 type genesisArgument struct {
@@ -51,6 +53,11 @@ type genesisArgument struct {
 
 	// fork
 	frankensteinBlock int64
+	nonceBlock        int64
+	forks             []string
+
+	// vesting schedule of the genesis OLT allocations, as start:cliff:end[:period]
+	vesting string
 
 	ethUrl               string
 	deploySmartcontracts bool
@@ -94,6 +101,9 @@ func init() {
 	genesisCmd.Flags().BoolVar(&genesisCmdArgs.deploySmartcontracts, "deploy_smart_contracts", false, "deploy eth contracts")
 	// fork
 	genesisCmd.Flags().Int64Var(&genesisCmdArgs.frankensteinBlock, "frankenstein_block", 1, "Fork block for frankenstein update")
+	genesisCmd.Flags().Int64Var(&genesisCmdArgs.nonceBlock, "nonce_block", 1, "Fork block for account nonce update")
+	genesisCmd.Flags().StringSliceVar(&genesisCmdArgs.forks, "forks", []string{}, "Fork blocks of the other forks, as name:height")
+	genesisCmd.Flags().StringVar(&genesisCmdArgs.vesting, "vesting", "", "Lock the OLT allocations with a vesting schedule, as start:cliff:end heights, linear unless a period length is added as start:cliff:end:period")
 }
 
 func newMainetContext(args *genesisArgument) (*mainetContext, error) {
@@ -254,14 +264,24 @@ func runGenesis(_ *cobra.Command, _ []string) error {
 	//}
 	//os.Remove(filepath.Join(genesisCmdArgs.pvkey_Dir, "cdOpts.json"))
 	states := getInitialState(args, nodeList, *cdo, *onsOp, btccdo, reserveDomains, initialAddrs)
+	states.Vesting, err = getGenesisVesting(args.vesting, states)
+	if err != nil {
+		return err
+	}
 
 	genesisDoc, err := consensus.NewGenesisDoc(getChainID(), states)
 	if err != nil {
 		return errors.Wrap(err, "failed to create new genesis file")
 	}
 	genesisDoc.Validators = validatorList
+	forkHeights, err := parseForkHeights(genesisCmdArgs.forks)
+	if err != nil {
+		return err
+	}
 	genesisDoc.ForkParams = &config.ForkParams{
 		FrankensteinBlock: genesisCmdArgs.frankensteinBlock,
+		NonceBlock:        genesisCmdArgs.nonceBlock,
+		Forks:             forkHeights,
 	}
 
 	for _, nodeName := range ctx.names {
@@ -382,6 +402,11 @@ func getInitialState(args *genesisArgument, nodeList []node, option ethchain.Cha
 		FeeCurrency:   olt,
 		MinFeeDecimal: 9,
 	}
+	// the fee market is disabled until a proposal enables it
+	feeMarketOpt := fees.MarketOptions{
+		TargetUtilisation:        50,
+		BaseFeeChangeDenominator: 8,
+	}
 	balances := make([]consensus.BalanceState, 0, len(nodeList))
 	staking := make([]consensus.Stake, 0, len(nodeList))
 	domains := make([]consensus.DomainState, 0, len(reservedDomains))
@@ -528,6 +553,10 @@ func getInitialState(args *genesisArgument, nodeList []node, option ethchain.Cha
 			ONSOptions:      onsOption,
 			StakingOptions:  stakingOption,
 			EvidenceOptions: evidenceOption,
+
+			FeeMarketOptions: feeMarketOpt,
+			GasOptions:       storage.DefaultGasOptions(),
+			TokenOptions:     tokens.Options{CreationFee: *balance.NewAmount(0)},
 		},
 	}
 }
@@ -548,3 +577,65 @@ func getInitialAddress(initialAddrs []keys.Address, initialTokenHolders []string
 	}
 	return initialAddrs, nil
 }
+
+// getGenesisVesting locks every OLT allocation of the genesis with the vesting schedule given as
+// start:cliff:end[:period], the schedule is periodic when the period length is given
+func getGenesisVesting(schedule string, states consensus.AppState) ([]balance.VestingAccount, error) {
+	if schedule == "" {
+		return nil, nil
+	}
+	parts := strings.Split(schedule, ":")
+	if len(parts) != 3 && len(parts) != 4 {
+		return nil, errors.Errorf("invalid vesting %s, expected start:cliff:end[:period]", schedule)
+	}
+	heights := make([]int64, len(parts))
+	for i, part := range parts {
+		height, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid vesting %s", schedule)
+		}
+		heights[i] = height
+	}
+	kind, period := balance.VestingLinear, int64(0)
+	if len(heights) == 4 {
+		kind, period = balance.VestingPeriodic, heights[3]
+	}
+
+	var olt balance.Currency
+	for _, c := range states.Currencies {
+		if c.Name == "OLT" {
+			olt = c
+		}
+	}
+	vesting := make([]balance.VestingAccount, 0, len(states.Balances))
+	for _, bal := range states.Balances {
+		if bal.Currency != olt.Name {
+			continue
+		}
+		account, err := balance.NewVestingAccount(bal.Address, olt.NewCoinFromAmount(bal.Amount), heights[0], heights[1],
+			heights[2], kind, period)
+		if err != nil {
+			return nil, err
+		}
+		vesting = append(vesting, *account)
+	}
+	return vesting, nil
+}
+
+// parseForkHeights reads the fork heights given as name:height
+func parseForkHeights(forks []string) ([]config.ForkHeight, error) {
+	forkHeights := make([]config.ForkHeight, 0, len(forks))
+	for _, fork := range forks {
+		parts := strings.Split(fork, ":")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid fork %s, expected name:height", fork)
+		}
+		height, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid height of fork %s", parts[0])
+		}
+		forkHeights = append(forkHeights, config.ForkHeight{Name: parts[0], Height: height})
+	}
+	params := config.ForkParams{Forks: forkHeights}
+	return forkHeights, params.Validate()
+}
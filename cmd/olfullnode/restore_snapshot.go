@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/Oneledger/protocol/app"
+	olnode "github.com/Oneledger/protocol/app/node"
+	"github.com/Oneledger/protocol/consensus"
+	"github.com/Oneledger/protocol/storage"
+)
+
+type restoreSnapshotArgs struct {
+	dir         string
+	rpcServers  []string
+	trustHeight int64
+	trustHash   string
+	trustPeriod time.Duration
+}
+
+var restoreSnapshotCtx = &restoreSnapshotArgs{}
+
+var restoreSnapshotCmd = &cobra.Command{
+	Use:   "restore_snapshot",
+	Short: "Restore the chain state of a new node from a snapshot, the node then syncs from the snapshot height on",
+	RunE:  restoreSnapshot,
+}
+
+func init() {
+	RootCmd.AddCommand(restoreSnapshotCmd)
+	restoreSnapshotCmd.Flags().StringVar(&restoreSnapshotCtx.dir, "dir", "", "Snapshot directory copied from the snapshots of another node")
+	restoreSnapshotCmd.Flags().StringSliceVar(&restoreSnapshotCtx.rpcServers, "rpcServers", []string{}, "Tendermint rpc servers verifying the snapshot, at least two")
+	restoreSnapshotCmd.Flags().Int64Var(&restoreSnapshotCtx.trustHeight, "trustHeight", 0, "Height of a trusted block")
+	restoreSnapshotCmd.Flags().StringVar(&restoreSnapshotCtx.trustHash, "trustHash", "", "Hash of the trusted block")
+	restoreSnapshotCmd.Flags().DurationVar(&restoreSnapshotCtx.trustPeriod, "trustPeriod", 168*time.Hour, "Period the trusted block stays trusted")
+}
+
+// restoreSnapshot verifies the blocks around the snapshot height against the trusted block, restores the chain
+// state from the snapshot and bootstraps the consensus state after the snapshot block
+func restoreSnapshot(cmd *cobra.Command, args []string) error {
+	ctx := nodeCtx
+	err := ctx.init(rootArgs.rootDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize config")
+	}
+	restoreArgs := restoreSnapshotCtx
+	if restoreArgs.dir == "" {
+		return errors.New("missing snapshot directory")
+	}
+	trustHash, err := hex.DecodeString(restoreArgs.trustHash)
+	if err != nil {
+		return errors.Wrap(err, "invalid trusted block hash")
+	}
+
+	snapshot, err := storage.ReadSnapshot(restoreArgs.dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to read snapshot")
+	}
+
+	tmcfg := ctx.cfg.TMConfig()
+	genesis, err := types.GenesisDocFromFile(tmcfg.GenesisFile())
+	if err != nil {
+		return errors.Wrap(err, "failed to read genesis")
+	}
+	trust := consensus.TrustOptions{
+		Period: restoreArgs.trustPeriod,
+		Height: restoreArgs.trustHeight,
+		Hash:   trustHash,
+	}
+	verified, err := consensus.VerifyState(genesis.ChainID, restoreArgs.rpcServers, trust, snapshot.Height)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify the snapshot block")
+	}
+
+	appNodeContext, err := olnode.NewNodeContext(ctx.cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to create app's node context")
+	}
+	application, err := app.NewApp(ctx.cfg, appNodeContext)
+	if err != nil {
+		return errors.Wrap(err, "failed to create new app")
+	}
+	defer application.Context.Close()
+
+	_, err = application.RestoreSnapshot(restoreArgs.dir, verified.State.AppHash)
+	if err != nil {
+		return errors.Wrap(err, "failed to restore snapshot")
+	}
+	err = consensus.BootstrapState(&tmcfg, verified)
+	if err != nil {
+		return errors.Wrap(err, "failed to bootstrap consensus state")
+	}
+
+	ctx.logger.Info("Restored snapshot, the node syncs from height", snapshot.Height+1)
+	return nil
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/data/tokens"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
 )
@@ -69,7 +70,14 @@ type publicKey struct {
 }
 
 type ForkParams struct {
-	FrankensteinBlock string `json:"frankensteinBlock"`
+	FrankensteinBlock string       `json:"frankensteinBlock"`
+	NonceBlock        string       `json:"nonceBlock"`
+	Forks             []ForkHeight `json:"forks,omitempty"`
+}
+
+type ForkHeight struct {
+	Name   string `json:"name"`
+	Height string `json:"height"`
 }
 
 type GenesisValidator struct {
@@ -248,6 +256,10 @@ func writeListWithTag(ctx app.StorageCtx, writer io.Writer, tag string) bool {
 		DumpValidatorsToFile(ctx.Validators, writer, writeStruct)
 	case "balances":
 		DumpBalanceToFile(ctx.Balances, writer, writeStruct)
+	case "vesting":
+		DumpVestingToFile(ctx.Balances, writer, writeStruct)
+	case "tokens":
+		DumpTokensToFile(ctx.Tokens, writer, writeStruct)
 	case "staking":
 		DumpStakingToFile(ctx.Validators, writer, writeStruct)
 	case "domains":
@@ -338,8 +350,14 @@ func SaveChainState(application *app.App, filename string, directory string) err
 	_, err = fmt.Fprint(writer, token)
 	_, err = writer.Write([]byte("\n"))
 
+	forkHeights := make([]ForkHeight, 0, len(genesisDoc.ForkParams.Forks))
+	for _, fork := range genesisDoc.ForkParams.Forks {
+		forkHeights = append(forkHeights, ForkHeight{Name: fork.Name, Height: strconv.Itoa(int(fork.Height))})
+	}
 	writeStructWithTag(writer, ForkParams{
 		FrankensteinBlock: strconv.Itoa(int(genesisDoc.ForkParams.FrankensteinBlock)),
+		NonceBlock:        strconv.Itoa(int(genesisDoc.ForkParams.NonceBlock)),
+		Forks:             forkHeights,
 	}, "fork")
 
 	for jsonDecoder.More() {
@@ -361,9 +379,11 @@ func SaveChainState(application *app.App, filename string, directory string) err
 
 	startBlock(writer, "\"app_state\"")
 	writeStructWithTag(writer, appState.Currencies, "currencies")
+	writeListWithTag(ctx, writer, "tokens")
 	writeStructWithTag(writer, GetGovernance(ctx.Govern), "governance")
 	writeStructWithTag(writer, appState.Chain, "state")
 	writeListWithTag(ctx, writer, "balances")
+	writeListWithTag(ctx, writer, "vesting")
 	writeListWithTag(ctx, writer, "staking")
 	writeStoreWithTag(ctx, writer, "delegation")
 	writeStoreWithTag(ctx, writer, "rewards")
@@ -459,6 +479,42 @@ func DumpBalanceToFile(bs *balance.Store, writer io.Writer, fn func(writer io.Wr
 	return
 }
 
+//Retrieves the vesting accounts, with their delegated vesting, and writes them to an io stream.
+func DumpVestingToFile(bs *balance.Store, writer io.Writer, fn func(writer io.Writer, obj interface{}) bool) {
+	iterator := 0
+	delimiter := ","
+	bs.IterateVesting(func(vesting *balance.VestingAccount) bool {
+		if iterator != 0 {
+			_, err := writer.Write([]byte(delimiter))
+			if err != nil {
+				return true
+			}
+		}
+		fn(writer, vesting)
+		iterator++
+		return false
+	})
+	return
+}
+
+//Retrieves the tokens created at runtime, with their supply, and writes them to an io stream.
+func DumpTokensToFile(ts *tokens.Store, writer io.Writer, fn func(writer io.Writer, obj interface{}) bool) {
+	iterator := 0
+	delimiter := ","
+	ts.Iterate(func(token *tokens.Token) bool {
+		if iterator != 0 {
+			_, err := writer.Write([]byte(delimiter))
+			if err != nil {
+				return true
+			}
+		}
+		fn(writer, token)
+		iterator++
+		return false
+	})
+	return
+}
+
 func DumpDomainToFile(ds *ons.DomainStore, height int64, writer io.Writer, fn func(writer io.Writer, obj interface{}) bool) {
 	iterator := 0
 	delimiter := ","
@@ -581,6 +637,24 @@ func GetGovernance(gs *governance.Store) *governance.GovernanceState {
 		return nil
 	}
 
+	feeMarketOptions, err := gs.GetFeeMarketOptions()
+	if err != nil {
+		fmt.Print("Error Reading Fee Market options: ", err)
+		return nil
+	}
+
+	gasOptions, err := gs.GetGasOptions()
+	if err != nil {
+		fmt.Print("Error Reading Gas options: ", err)
+		return nil
+	}
+
+	tokenOptions, err := gs.GetTokenOptions()
+	if err != nil {
+		fmt.Print("Error Reading Token options: ", err)
+		return nil
+	}
+
 	return &governance.GovernanceState{
 		FeeOption:       *feeOption,
 		ETHCDOption:     *ethOption,
@@ -590,6 +664,10 @@ func GetGovernance(gs *governance.Store) *governance.GovernanceState {
 		StakingOptions:  *stakingOptions,
 		EvidenceOptions: *evidenceOptions,
 		RewardOptions:   *rewardOptions,
+
+		FeeMarketOptions: *feeMarketOptions,
+		GasOptions:       *gasOptions,
+		TokenOptions:     *tokenOptions,
 	}
 }
 
@@ -654,10 +732,11 @@ func DumpGovProposalsToFile(pm *governance.ProposalMasterStore, writer io.Writer
 				}
 			}
 			govProp := governance.GovProposal{
-				Prop:          *proposal,
-				ProposalVotes: pm.GetProposalVotes(proposal.ProposalID),
-				ProposalFunds: pm.GetProposalFunds(proposal.ProposalID),
-				State:         state,
+				Prop:           *proposal,
+				ProposalVotes:  pm.GetProposalVotes(proposal.ProposalID),
+				DelegatorVotes: pm.GetDelegatorVotes(proposal.ProposalID),
+				ProposalFunds:  pm.GetProposalFunds(proposal.ProposalID),
+				State:          state,
 			}
 
 			fn(writer, govProp)
@@ -686,6 +765,7 @@ func DumpNetworkDelegatorsToFile(nd *network_delegation.Store, writer io.Writer,
 					Address: addr,
 					Amount:  coin,
 				}
+				delegatorState.Validator, _ = nd.GetValidator(*addr)
 				fn(writer, delegatorState)
 				iterator++
 				return false
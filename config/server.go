@@ -192,6 +192,10 @@ type NodeConfig struct {
 	Auth Authorisation `toml:"Auth" desc:"the OwnerCredentials and RPCPrivateKey should be configured together"`
 
 	ChainStateRotation ChainStateRotationCfg `toml:"ChainStateRotation" desc:"the schedule for chain state rotation"`
+
+	Snapshot SnapshotCfg `toml:"Snapshot" desc:"the schedule for chain state snapshots served to state syncing nodes"`
+
+	Archive ArchiveCfg `toml:"Archive" desc:"the archive keeping the chain state versions deleted by the rotation"`
 }
 
 type Authorisation struct {
@@ -200,6 +204,9 @@ type Authorisation struct {
 
 	//Private key for RPC Authentication
 	RPCPrivateKey string `toml:"rpc_private_key" desc:"(ED25519 key) This private key will be used to generate a token for authentication through RPC Port; if not configured, anyone can access the SDK rpc port without authentication"`
+
+	//Node keys without RPC Authentication
+	AllowInsecureUnlock bool `toml:"allow_insecure_unlock" desc:"Allow anyone to sign with the node keystore through the web3 personal and eth_sign/eth_sendTransaction methods when the RPCPrivateKey is not configured, for local networks only"`
 }
 
 type ChainStateRotationCfg struct {
@@ -222,6 +229,28 @@ type ChainStateRotationCfg struct {
 	Cycles int64
 }
 
+type ArchiveCfg struct {
+	// "enabled" : stream the diff of every committed version into the archive, the past versions are
+	// read from it once the rotation deleted them from the chain state
+	Enabled bool
+
+	// "dir" : directory of the archive database, relative to the db directory
+	Dir string
+}
+
+type SnapshotCfg struct {
+	// "interval" : every X number of version to take a snapshot of
+	// interval = 0 : snapshots disabled
+	Interval int64
+
+	// "keep_recent" : number of latest snapshots to keep on disk
+	// keep_recent = 0 : keep every snapshot
+	KeepRecent int64
+
+	// "chunk_size" : maximum size in bytes of a single snapshot chunk
+	ChunkSize int64
+}
+
 func DefaultNodeConfig() *NodeConfig {
 	return &NodeConfig{
 		NodeName:     "Newton-Node",
@@ -232,8 +261,9 @@ func DefaultNodeConfig() *NodeConfig {
 		IndexTags:    []string{},
 		IndexAllTags: false,
 		Auth: Authorisation{
-			OwnerCredentials: []string{},
-			RPCPrivateKey:    "",
+			OwnerCredentials:    []string{},
+			RPCPrivateKey:       "",
+			AllowInsecureUnlock: false,
 		},
 
 		ChainStateRotation: ChainStateRotationCfg{
@@ -242,6 +272,17 @@ func DefaultNodeConfig() *NodeConfig {
 			Cycles: 10,
 		},
 
+		Snapshot: SnapshotCfg{
+			Interval:   0,
+			KeepRecent: 2,
+			ChunkSize:  4 << 20,
+		},
+
+		Archive: ArchiveCfg{
+			Enabled: false,
+			Dir:     "archive",
+		},
+
 		//"btc" service temporarily disabled
 		Services: []string{"broadcast", "node", "owner", "query", "tx", "eth"},
 	}
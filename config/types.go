@@ -115,15 +115,71 @@ func GenesisDocFromFile(genDocFile string) (*GenesisDoc, error) {
 	return genDoc, nil
 }
 
+// names of the network forks, the fork is enabled by setting its activation height in the genesis
+const (
+	FrankensteinFork = "frankenstein"
+	NonceFork        = "nonce"
+	// DomainRecordsFork lets the owners of the domains set their resolver records
+	DomainRecordsFork = "domainRecords"
+	// LazyRewardsFork distributes the rewards of the network delegators through a cumulative reward index
+	LazyRewardsFork = "lazyRewards"
+	// ValidatorDelegationFork lets the network delegators pick a validator, which keeps its own commission from their
+	// rewards, the rewards are distributed through the reward index of the lazy rewards
+	ValidatorDelegationFork = "validatorDelegation"
+	// DelegatorVotingFork lets the network delegators vote on the proposals, they inherit the vote of their validator
+	DelegatorVotingFork = "delegatorVoting"
+	// BatchFork lets the users run several messages as a single transaction, they are either all applied or none is
+	BatchFork = "batch"
+	// FeeGrantFork lets a fee payer other than the signer pay the fee of a transaction, from the allowance it granted
+	FeeGrantFork = "feeGrant"
+	// MultiSigFork lets the users register k-of-n multisig accounts which sign with a bundle of signatures
+	MultiSigFork = "multiSig"
+	// VestingFork lets the users send coins locked by a vesting schedule to another address
+	VestingFork = "vesting"
+	// TokenFactoryFork lets the users and the governance register new currencies at runtime
+	TokenFactoryFork = "tokenFactory"
+	// TypedTxFork accepts the EIP-2930 access list and EIP-1559 dynamic fee ethereum transactions in the OLVM
+	TypedTxFork = "typedTx"
+	// NativeContractsFork lets the OLVM contracts call the native modules through precompiled contracts
+	NativeContractsFork = "nativeContracts"
+	// FeeHistoryFork records the gas usage and the gas prices paid by every block for eth_feeHistory
+	FeeHistoryFork = "feeHistory"
+)
+
 // ForkParams determine the fork blocks number where to apply the global update for network
 type ForkParams struct {
 	FrankensteinBlock int64 `json:"frankensteinBlock"`
+	NonceBlock        int64 `json:"nonceBlock"`
+
+	// activation heights of the forks which don't have their own field
+	Forks []ForkHeight `json:"forks,omitempty"`
+}
+
+// ForkHeight is the activation height of a named fork
+type ForkHeight struct {
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
 }
 
 // DefaultForkParams initial config
 func DefaultForkParams() *ForkParams {
 	return &ForkParams{
 		FrankensteinBlock: 1, // 0 means disabled as tendermint blocks started from 1
+		NonceBlock:        1,
+		Forks: []ForkHeight{
+			{Name: DomainRecordsFork, Height: 1},
+			{Name: LazyRewardsFork, Height: 1},
+			{Name: ValidatorDelegationFork, Height: 1},
+			{Name: DelegatorVotingFork, Height: 1},
+			{Name: BatchFork, Height: 1},
+			{Name: FeeGrantFork, Height: 1},
+			{Name: MultiSigFork, Height: 1},
+			{Name: VestingFork, Height: 1},
+			{Name: TokenFactoryFork, Height: 1},
+			{Name: TypedTxFork, Height: 1},
+			{Name: NativeContractsFork, Height: 1},
+			{Name: FeeHistoryFork, Height: 1},
+		},
 	}
 }
 
@@ -138,18 +194,40 @@ func (f *ForkParams) ToMap() (map[string]interface{}, error) {
 	return inMap, err
 }
 
-// IsFrankensteinBlock check if fork update arrived to apply change at specific block
-func (f *ForkParams) IsFrankensteinBlock(height int64) bool {
-	return f.FrankensteinBlock != 0 && f.FrankensteinBlock == height
+// Height returns the activation height of the named fork, 0 means the fork is disabled
+func (f *ForkParams) Height(name string) int64 {
+	switch name {
+	case FrankensteinFork:
+		return f.FrankensteinBlock
+	case NonceFork:
+		return f.NonceBlock
+	}
+	for _, fork := range f.Forks {
+		if fork.Name == name {
+			return fork.Height
+		}
+	}
+	return 0
 }
 
-// IsFrankensteinUpdate check if fork update arrived to apply changes after specific block
-func (f *ForkParams) IsFrankensteinUpdate(height int64) bool {
-	return f.FrankensteinBlock != 0 && f.FrankensteinBlock <= height
+// IsActive check if the named fork applies its changes at specific block
+func (f *ForkParams) IsActive(name string, height int64) bool {
+	forkHeight := f.Height(name)
+	return forkHeight != 0 && forkHeight <= height
 }
 
 // Validate validates the ForkParams to ensure all values are within their
 // allowed limits, and returns an error if they are not.
 func (f *ForkParams) Validate() error {
+	names := make(map[string]bool)
+	for _, fork := range f.Forks {
+		if fork.Name == FrankensteinFork || fork.Name == NonceFork || names[fork.Name] {
+			return errors.Errorf("fork %s is set twice", fork.Name)
+		}
+		if fork.Height < 0 {
+			return errors.Errorf("invalid height %d for fork %s", fork.Height, fork.Name)
+		}
+		names[fork.Name] = true
+	}
 	return nil
 }
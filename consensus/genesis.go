@@ -17,6 +17,7 @@ import (
 	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/data/rewards"
+	"github.com/Oneledger/protocol/data/tokens"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/serialize"
 )
@@ -80,9 +81,11 @@ type Stake identity.Stake
 
 type AppState struct {
 	Currencies    balance.Currencies             `json:"currencies"`
+	Tokens        []tokens.Token                 `json:"tokens,omitempty"`
 	Governance    governance.GovernanceState     `json:"governance"`
 	Chain         ChainState                     `json:"state"`
 	Balances      []BalanceState                 `json:"balances"`
+	Vesting       []balance.VestingAccount       `json:"vesting,omitempty"`
 	Staking       []Stake                        `json:"staking"`
 	Witness       []Stake                        `json:"witness"`
 	Delegation    delegation.DelegationState     `json:"delegation"`
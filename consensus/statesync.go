@@ -0,0 +1,148 @@
+package consensus
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	lite "github.com/tendermint/tendermint/lite2"
+	"github.com/tendermint/tendermint/lite2/provider"
+	litehttp "github.com/tendermint/tendermint/lite2/provider/http"
+	litedb "github.com/tendermint/tendermint/lite2/store/db"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// Tendermint v0.33 has no state sync, a node restoring the chain state from a snapshot bootstraps its consensus
+// state itself: the headers around the snapshot height are verified by a light client and written into the empty
+// state and block stores, so the node fast syncs from the snapshot height on.
+
+type TrustOptions = lite.TrustOptions
+
+// VerifiedState is the consensus state after the snapshot block, along with the block and its commit
+type VerifiedState struct {
+	State  sm.State
+	Block  *types.Block
+	Commit *types.Commit
+}
+
+// VerifyState verifies the headers of the blocks height, height+1 and height+2 with a light client started from the
+// trusted header, the first server is the primary and the others are witnesses. It returns the consensus state after
+// the block at height, its app hash is the one the snapshot of height has to match.
+func VerifyState(chainID string, servers []string, trust TrustOptions, height int64) (*VerifiedState, error) {
+	if len(servers) < 2 {
+		return nil, errors.New("at least two rpc servers are needed to verify the state")
+	}
+	providers := make([]provider.Provider, 0, len(servers))
+	for _, server := range servers {
+		p, err := litehttp.New(chainID, server)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to connect to %s", server)
+		}
+		providers = append(providers, p)
+	}
+	client, err := lite.NewClient(chainID, trust, providers[0], providers[1:],
+		litedb.New(dbm.NewMemDB(), chainID), lite.Logger(tmlog.NewNopLogger()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start the light client")
+	}
+
+	// the app hash of the state after height is in the header of the next block and the validators of the block
+	// after it are known from the one after
+	now := time.Now()
+	headers := make([]*types.SignedHeader, 3)
+	vals := make([]*types.ValidatorSet, 3)
+	for i := range headers {
+		headers[i], err = client.VerifyHeaderAtHeight(height+int64(i), now)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to verify header %d", height+int64(i))
+		}
+		vals[i], _, err = client.TrustedValidatorSet(height + int64(i))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get validators %d", height+int64(i))
+		}
+	}
+	last, current := headers[0], headers[1]
+
+	rpc, err := rpcclient.NewHTTP(servers[0], "/websocket")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %s", servers[0])
+	}
+	block, err := rpc.Block(&height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block %d", height)
+	}
+	if !bytes.Equal(block.Block.Hash(), last.Hash()) {
+		return nil, fmt.Errorf("block %d does not match its verified header", height)
+	}
+	params, err := rpc.ConsensusParams(&current.Height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get consensus params %d", current.Height)
+	}
+	if !bytes.Equal(params.ConsensusParams.Hash(), current.ConsensusHash) {
+		return nil, fmt.Errorf("consensus params %d do not match the verified header", current.Height)
+	}
+
+	state := sm.State{
+		Version: sm.Version{
+			Consensus: current.Version,
+			Software:  version.TMCoreSemVer,
+		},
+		ChainID:         chainID,
+		LastBlockHeight: last.Height,
+		LastBlockID:     last.Commit.BlockID,
+		LastBlockTime:   last.Time,
+		LastValidators:  vals[0],
+		Validators:      vals[1],
+		NextValidators:  vals[2],
+		// the sets of the previous heights are written by BootstrapState
+		LastHeightValidatorsChanged:      current.Height + 1,
+		ConsensusParams:                  params.ConsensusParams,
+		LastHeightConsensusParamsChanged: current.Height,
+		LastResultsHash:                  current.LastResultsHash,
+		AppHash:                          current.AppHash,
+	}
+	return &VerifiedState{State: state, Block: block.Block, Commit: last.Commit}, nil
+}
+
+// BootstrapState writes the verified state into the empty state and block stores of the node
+func BootstrapState(tmcfg *Config, vs *VerifiedState) error {
+	backend := dbm.BackendType(tmcfg.DBBackend)
+	stateDB := dbm.NewDB("state", backend, tmcfg.DBDir())
+	defer stateDB.Close()
+	blockDB := dbm.NewDB("blockstore", backend, tmcfg.DBDir())
+	defer blockDB.Close()
+
+	if !sm.LoadState(stateDB).IsEmpty() || store.LoadBlockStoreStateJSON(blockDB).Height != 0 {
+		return errors.New("consensus state is not empty, can't bootstrap it")
+	}
+
+	// executing the next blocks loads the validators of the previous heights, SaveState only writes the next ones
+	height := vs.State.LastBlockHeight
+	stateDB.Set(validatorsKey(height), (&sm.ValidatorsInfo{
+		ValidatorSet:      vs.State.LastValidators,
+		LastHeightChanged: height,
+	}).Bytes())
+	stateDB.Set(validatorsKey(height+1), (&sm.ValidatorsInfo{
+		ValidatorSet:      vs.State.Validators,
+		LastHeightChanged: height + 1,
+	}).Bytes())
+	sm.SaveState(stateDB, vs.State)
+
+	// the block store only saves contiguous blocks, it is moved right before the snapshot block
+	store.BlockStoreStateJSON{Height: height - 1}.Save(blockDB)
+	blockStore := store.NewBlockStore(blockDB)
+	blockStore.SaveBlock(vs.Block, vs.Block.MakePartSet(types.BlockPartSizeBytes), vs.Commit)
+	return nil
+}
+
+// validatorsKey is the key Tendermint stores the validator set of a height under
+func validatorsKey(height int64) []byte {
+	return []byte(fmt.Sprintf("validatorsKey:%v", height))
+}
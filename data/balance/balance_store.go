@@ -19,6 +19,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/serialize"
 	"github.com/Oneledger/protocol/storage"
@@ -27,12 +28,19 @@ import (
 type Store struct {
 	State  *storage.State
 	prefix []byte
+
+	// vestingPrefix keeps the vesting accounts apart from the balances
+	vestingPrefix []byte
+
+	// forks gate the vesting locks, they are enforced at every height without them
+	forks *config.ForkParams
 }
 
 func NewStore(prefix string, state *storage.State) *Store {
 	return &Store{
-		State:  state,
-		prefix: storage.Prefix(prefix),
+		State:         state,
+		prefix:        storage.Prefix(prefix),
+		vestingPrefix: storage.Prefix(prefix + "vest"),
 	}
 }
 
@@ -41,6 +49,16 @@ func (st *Store) WithState(state *storage.State) *Store {
 	return st
 }
 
+// SetupForks sets the fork params, the balances are written without reading the vesting accounts before the
+// vesting fork
+func (st *Store) SetupForks(forks *config.ForkParams) {
+	st.forks = forks
+}
+
+func (st *Store) GetForks() *config.ForkParams {
+	return st.forks
+}
+
 func (st *Store) BuildKey(addr keys.Address, coin *Coin) []byte {
 	name := "OLT"
 	if coin != nil {
@@ -131,8 +149,27 @@ func (st *Store) IterateAll(fn func(addr keys.Address, c string, amt Amount) boo
 //	return st.State.Exists(key)
 //}
 
+// SetBalance sets the balance of the address, as the accounts of the vm do. It can't take the balance below the
+// locked vesting coins, the same way as MinusFromAddress.
 func (st *Store) SetBalance(addr keys.Address, coin Coin) error {
 	key := storage.StoreKey(addr.String() + storage.DB_PREFIX + coin.Currency.Name)
+
+	vesting, err := st.lockingVesting(addr, coin.Currency.Name)
+	if err != nil {
+		return err
+	}
+	if vesting != nil {
+		amt, err := st.get(key)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get address balance %s", addr.String())
+		}
+		lock := vesting.SpendLock(st.height())
+		if coin.Amount.LessThan(*amt) && coin.Amount.LessThan(*lock) {
+			return errors.Wrapf(ErrLockedBalance, "set balance of address: %s, balance: %s, locked: %s, coin: %s",
+				addr.String(), coin.Currency.NewCoinFromAmount(*amt).String(), coin.Currency.NewCoinFromAmount(*lock).String(), coin.String())
+		}
+	}
+
 	return st.set(key, *coin.Amount)
 }
 
@@ -168,9 +205,69 @@ func (st *Store) MinusFromAddress(addr keys.Address, coin Coin) error {
 		return errors.Wrapf(err, "minus from address: %s, balance: %s, coin: %s", addr.String(), base.String(), coin.String())
 	}
 
+	vesting, err := st.lockingVesting(addr, coin.Currency.Name)
+	if err != nil {
+		return err
+	}
+	if vesting != nil {
+		lock := vesting.SpendLock(st.height())
+		if newCoin.Amount.LessThan(*lock) {
+			return errors.Wrapf(ErrLockedBalance, "minus from address: %s, balance: %s, locked: %s, coin: %s",
+				addr.String(), base.String(), coin.Currency.NewCoinFromAmount(*lock).String(), coin.String())
+		}
+	}
+
+	return st.set(key, *newCoin.Amount)
+}
+
+// DelegateFromAddress takes the coin out of the balance to stake or delegate it, the locked vesting coins can be
+// delegated
+func (st *Store) DelegateFromAddress(addr keys.Address, coin Coin) error {
+	key := storage.StoreKey(addr.String() + storage.DB_PREFIX + coin.Currency.Name)
+
+	amt, err := st.get(key)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get address balance %s", addr.String())
+	}
+
+	base := coin.Currency.NewCoinFromAmount(*amt)
+	newCoin, err := base.Minus(coin)
+	if err != nil {
+		return errors.Wrapf(err, "delegate from address: %s, balance: %s, coin: %s", addr.String(), base.String(), coin.String())
+	}
+
+	vesting, err := st.lockingVesting(addr, coin.Currency.Name)
+	if err != nil {
+		return err
+	}
+	if vesting != nil {
+		vesting.TrackDelegation(st.height(), *coin.Amount)
+		err = st.setVesting(vesting)
+		if err != nil {
+			return err
+		}
+	}
+
 	return st.set(key, *newCoin.Amount)
 }
 
+// UndelegateToAddress puts the coin back in the balance once it is undelegated, it goes back to the locked vesting
+// coins first
+func (st *Store) UndelegateToAddress(addr keys.Address, coin Coin) error {
+	vesting, err := st.lockingVesting(addr, coin.Currency.Name)
+	if err != nil {
+		return err
+	}
+	if vesting != nil {
+		vesting.TrackUndelegation(*coin.Amount)
+		err = st.setVesting(vesting)
+		if err != nil {
+			return err
+		}
+	}
+	return st.AddToAddress(addr, coin)
+}
+
 func (st *Store) CheckBalanceFromAddress(addr keys.Address, coin Coin) error {
 	key := storage.StoreKey(addr.String() + storage.DB_PREFIX + coin.Currency.Name)
 
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"math"
 	"math/big"
+	"sync"
 
 	"github.com/Oneledger/protocol/utils"
 
@@ -122,7 +123,9 @@ func (c Currency) NewCoinFromBytes(amount []byte) Coin {
 	}
 }
 
+// CurrencySet is the registry of the currencies, the tokens created at runtime are registered while it is in use
 type CurrencySet struct {
+	mtx     sync.RWMutex
 	nameMap map[string]Currency
 	idMap   map[int64]Currency
 }
@@ -132,6 +135,8 @@ func NewCurrencySet() *CurrencySet {
 }
 
 func (cl *CurrencySet) Register(c Currency) error {
+	cl.mtx.Lock()
+	defer cl.mtx.Unlock()
 	_, ok := cl.nameMap[c.Name]
 	if ok { // If the currency is already registered, return a duplicate error
 		return ErrDuplicateCurrency
@@ -142,22 +147,30 @@ func (cl *CurrencySet) Register(c Currency) error {
 }
 
 func (cl *CurrencySet) GetCurrencyByName(name string) (Currency, bool) {
+	cl.mtx.RLock()
+	defer cl.mtx.RUnlock()
 	c, ok := cl.nameMap[name]
 	return c, ok
 }
 
 func (cl *CurrencySet) GetCurrencyById(id int64) (Currency, bool) {
+	cl.mtx.RLock()
+	defer cl.mtx.RUnlock()
 	c, ok := cl.idMap[id]
 	return c, ok
 }
 
-func (cl CurrencySet) Len() int {
+func (cl *CurrencySet) Len() int {
+	cl.mtx.RLock()
+	defer cl.mtx.RUnlock()
 	return len(cl.nameMap)
 }
 
 type Currencies []Currency
 
-func (c CurrencySet) GetCurrencies() Currencies {
+func (c *CurrencySet) GetCurrencies() Currencies {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
 	result := make([]Currency, len(c.nameMap))
 	i := 0
 	for _, v := range c.nameMap {
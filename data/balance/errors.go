@@ -31,4 +31,9 @@ var (
 	ErrBalanceErrorMinusFailed = codes.ProtocolError{Code: codes.BalanceErrorMinusFailed, Msg: "Failed to minus balance from account"}
 
 	ErrAccountNotFound = errors.New("account not found")
+
+	ErrInvalidVesting = codes.ProtocolError{Code: codes.VestingErrInvalidSchedule, Msg: "invalid vesting schedule"}
+	ErrLockedBalance  = codes.ProtocolError{Code: codes.VestingErrLockedBalance, Msg: "balance is locked by the vesting schedule"}
+	ErrVestingExists  = codes.ProtocolError{Code: codes.VestingErrAccountExists, Msg: "vesting account already exists"}
+	ErrNoVesting      = codes.ProtocolError{Code: codes.VestingErrNotFound, Msg: "vesting account not found"}
 )
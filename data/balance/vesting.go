@@ -0,0 +1,139 @@
+package balance
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+// VestingSchedule is the way the coins of a vesting account unlock between its cliff and end heights
+type VestingSchedule string
+
+const (
+	// VestingLinear unlocks the coins block by block
+	VestingLinear VestingSchedule = "linear"
+	// VestingPeriodic unlocks the coins in equal steps every PeriodLength blocks
+	VestingPeriodic VestingSchedule = "periodic"
+)
+
+// VestingAccount locks OriginalVesting of an address balance until it vests. Nothing vests before the cliff, it all
+// has vested at the end height. The locked coins can still be staked or delegated, DelegatedVesting tracks the part
+// of the delegated coins that was taken from the locked ones.
+type VestingAccount struct {
+	Address          keys.Address    `json:"address"`
+	Currency         string          `json:"currency"`
+	OriginalVesting  Amount          `json:"originalVesting"`
+	DelegatedVesting Amount          `json:"delegatedVesting"`
+	StartHeight      int64           `json:"startHeight"`
+	CliffHeight      int64           `json:"cliffHeight"`
+	EndHeight        int64           `json:"endHeight"`
+	Schedule         VestingSchedule `json:"schedule"`
+	PeriodLength     int64           `json:"periodLength,omitempty"`
+}
+
+// NewVestingAccount returns the vesting account of the coin, a cliff height of 0 means there is no cliff
+func NewVestingAccount(addr keys.Address, coin Coin, start, cliff, end int64, schedule VestingSchedule, period int64) (*VestingAccount, error) {
+	if cliff == 0 {
+		cliff = start
+	}
+	account := &VestingAccount{
+		Address:          addr,
+		Currency:         coin.Currency.Name,
+		OriginalVesting:  *coin.Amount,
+		DelegatedVesting: *NewAmount(0),
+		StartHeight:      start,
+		CliffHeight:      cliff,
+		EndHeight:        end,
+		Schedule:         schedule,
+		PeriodLength:     period,
+	}
+	err := account.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Validate checks the schedule of the vesting account
+func (v *VestingAccount) Validate() error {
+	if err := v.Address.Err(); err != nil {
+		return errors.Wrap(ErrInvalidVesting, err.Error())
+	}
+	if v.Currency == "" {
+		return errors.Wrap(ErrInvalidVesting, "missing currency")
+	}
+	if v.OriginalVesting.BigInt().Sign() <= 0 {
+		return errors.Wrap(ErrInvalidVesting, "vesting amount must be positive")
+	}
+	if v.DelegatedVesting.BigInt().Sign() < 0 || v.OriginalVesting.LessThan(v.DelegatedVesting) {
+		return errors.Wrap(ErrInvalidVesting, "delegated vesting out of range")
+	}
+	if v.StartHeight < 0 || v.CliffHeight < v.StartHeight || v.EndHeight <= v.StartHeight || v.CliffHeight > v.EndHeight {
+		return errors.Wrapf(ErrInvalidVesting, "heights start %d, cliff %d, end %d", v.StartHeight, v.CliffHeight, v.EndHeight)
+	}
+	switch v.Schedule {
+	case VestingLinear:
+	case VestingPeriodic:
+		if v.PeriodLength <= 0 || v.PeriodLength > v.EndHeight-v.StartHeight {
+			return errors.Wrapf(ErrInvalidVesting, "period length %d", v.PeriodLength)
+		}
+	default:
+		return errors.Wrapf(ErrInvalidVesting, "unknown schedule %s", v.Schedule)
+	}
+	return nil
+}
+
+// VestedAmount returns how much of the original vesting has unlocked at the height
+func (v *VestingAccount) VestedAmount(height int64) *Amount {
+	if height < v.CliffHeight || height <= v.StartHeight {
+		return NewAmount(0)
+	}
+	if height >= v.EndHeight {
+		return NewAmountFromBigInt(new(big.Int).Set(v.OriginalVesting.BigInt()))
+	}
+
+	elapsed, total := height-v.StartHeight, v.EndHeight-v.StartHeight
+	if v.Schedule == VestingPeriodic {
+		elapsed = elapsed / v.PeriodLength
+		total = (total + v.PeriodLength - 1) / v.PeriodLength
+	}
+	vested := new(big.Int).Mul(v.OriginalVesting.BigInt(), big.NewInt(elapsed))
+	vested.Quo(vested, big.NewInt(total))
+	return NewAmountFromBigInt(vested)
+}
+
+// LockedAmount returns how much of the original vesting is still locked at the height
+func (v *VestingAccount) LockedAmount(height int64) *Amount {
+	locked := new(big.Int).Sub(v.OriginalVesting.BigInt(), v.VestedAmount(height).BigInt())
+	return NewAmountFromBigInt(locked)
+}
+
+// SpendLock returns how much of the balance can't be spent at the height, the locked coins that are delegated
+// aren't in the balance anymore
+func (v *VestingAccount) SpendLock(height int64) *Amount {
+	lock := new(big.Int).Sub(v.LockedAmount(height).BigInt(), v.DelegatedVesting.BigInt())
+	if lock.Sign() < 0 {
+		lock.SetInt64(0)
+	}
+	return NewAmountFromBigInt(lock)
+}
+
+// TrackDelegation moves the locked part of the delegated amount to the delegated vesting
+func (v *VestingAccount) TrackDelegation(height int64, amount Amount) {
+	fromLocked := v.SpendLock(height)
+	if amount.LessThan(*fromLocked) {
+		fromLocked = &amount
+	}
+	v.DelegatedVesting = *v.DelegatedVesting.Plus(*fromLocked)
+}
+
+// TrackUndelegation gives the undelegated amount back to the locked coins first
+func (v *VestingAccount) TrackUndelegation(amount Amount) {
+	back := amount
+	if v.DelegatedVesting.LessThan(back) {
+		back = v.DelegatedVesting
+	}
+	v.DelegatedVesting = *NewAmountFromBigInt(new(big.Int).Sub(v.DelegatedVesting.BigInt(), back.BigInt()))
+}
@@ -0,0 +1,103 @@
+package balance
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func (st *Store) vestingKey(addr keys.Address, currency string) storage.StoreKey {
+	key := storage.StoreKey(addr.String() + storage.DB_PREFIX + currency)
+	return append(storage.StoreKey(st.vestingPrefix), key...)
+}
+
+// height returns the height the state is used at, the chain state is at the last committed block while the block
+// being delivered is the next one
+func (st *Store) height() int64 {
+	if st.State.IsVersioned() {
+		return st.State.Version()
+	}
+	return st.State.Version() + 1
+}
+
+// GetVesting returns the vesting account of the address for the currency, ErrNoVesting if there is none
+func (st *Store) GetVesting(addr keys.Address, currency string) (*VestingAccount, error) {
+	dat, err := st.State.Get(st.vestingKey(addr, currency))
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) == 0 {
+		return nil, ErrNoVesting
+	}
+	vesting := &VestingAccount{}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(dat, vesting)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize vesting account")
+	}
+	return vesting, nil
+}
+
+// lockingVesting returns the vesting account locking the balance of the address for the currency, nil if there is
+// none or the vesting fork isn't active yet
+func (st *Store) lockingVesting(addr keys.Address, currency string) (*VestingAccount, error) {
+	if st.forks != nil && !st.forks.IsActive(config.VestingFork, st.height()) {
+		return nil, nil
+	}
+	vesting, err := st.GetVesting(addr, currency)
+	if err == ErrNoVesting {
+		return nil, nil
+	}
+	return vesting, err
+}
+
+// SetVesting registers a vesting account, an address has at most one vesting schedule per currency
+func (st *Store) SetVesting(vesting *VestingAccount) error {
+	err := vesting.Validate()
+	if err != nil {
+		return err
+	}
+	if st.State.Exists(st.vestingKey(vesting.Address, vesting.Currency)) {
+		return errors.Wrapf(ErrVestingExists, "%s %s", vesting.Address, vesting.Currency)
+	}
+	return st.setVesting(vesting)
+}
+
+func (st *Store) setVesting(vesting *VestingAccount) error {
+	dat, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(vesting)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize vesting account")
+	}
+	return st.State.Set(st.vestingKey(vesting.Address, vesting.Currency), dat)
+}
+
+// GetLockedBalance returns how much of the address balance of the currency can't be spent yet
+func (st *Store) GetLockedBalance(addr keys.Address, curr *Currency) (Coin, error) {
+	vesting, err := st.GetVesting(addr, curr.Name)
+	if err == ErrNoVesting {
+		return curr.NewCoinFromInt(0), nil
+	}
+	if err != nil {
+		return Coin{}, err
+	}
+	return curr.NewCoinFromAmount(*vesting.SpendLock(st.height())), nil
+}
+
+// IterateVesting goes through all the vesting accounts
+func (st *Store) IterateVesting(fn func(vesting *VestingAccount) bool) bool {
+	return st.State.IterateRange(
+		st.vestingPrefix,
+		storage.Rangefix(string(st.vestingPrefix)),
+		true,
+		func(key, value []byte) bool {
+			vesting := &VestingAccount{}
+			err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(value, vesting)
+			if err != nil {
+				return true
+			}
+			return fn(vesting)
+		},
+	)
+}
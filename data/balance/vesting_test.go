@@ -0,0 +1,99 @@
+package balance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+var vestingOLT = Currency{Id: 0, Name: "OLT", Chain: 0, Decimal: 18, Unit: "nue"}
+
+func TestVestingAccount_VestedAmount(t *testing.T) {
+	addr := keys.Address("asdfasdfasdfasdfasdf")
+	coin := vestingOLT.NewCoinFromAmount(*NewAmount(1000))
+
+	linear, err := NewVestingAccount(addr, coin, 100, 200, 1100, VestingLinear, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", linear.VestedAmount(50).String())
+	assert.Equal(t, "0", linear.VestedAmount(199).String())
+	assert.Equal(t, "100", linear.VestedAmount(200).String())
+	assert.Equal(t, "500", linear.VestedAmount(600).String())
+	assert.Equal(t, "1000", linear.VestedAmount(1100).String())
+	assert.Equal(t, "0", linear.LockedAmount(2000).String())
+
+	periodic, err := NewVestingAccount(addr, coin, 100, 0, 1100, VestingPeriodic, 250)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", periodic.VestedAmount(349).String())
+	assert.Equal(t, "250", periodic.VestedAmount(350).String())
+	assert.Equal(t, "750", periodic.VestedAmount(1099).String())
+	assert.Equal(t, "1000", periodic.VestedAmount(1100).String())
+
+	_, err = NewVestingAccount(addr, coin, 100, 50, 1100, VestingLinear, 0)
+	assert.Error(t, err)
+	_, err = NewVestingAccount(addr, coin, 100, 0, 1100, VestingPeriodic, 0)
+	assert.Error(t, err)
+	_, err = NewVestingAccount(addr, coin, 100, 0, 1100, "cliff", 0)
+	assert.Error(t, err)
+}
+
+func TestStore_VestingLock(t *testing.T) {
+	cs := storage.NewState(storage.NewChainState("balance", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewStore("b", cs)
+	addr := keys.Address("asdfasdfasdfasdfasdf")
+
+	err := store.AddToAddress(addr, vestingOLT.NewCoinFromAmount(*NewAmount(1500)))
+	assert.NoError(t, err)
+	vesting, err := NewVestingAccount(addr, vestingOLT.NewCoinFromAmount(*NewAmount(1000)), 10, 0, 1010, VestingLinear, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, store.SetVesting(vesting))
+	assert.Error(t, store.SetVesting(vesting))
+
+	// the first block is before the schedule starts, only the coins out of the schedule can be spent
+	err = store.MinusFromAddress(addr, vestingOLT.NewCoinFromAmount(*NewAmount(501)))
+	assert.Error(t, err)
+	err = store.MinusFromAddress(addr, vestingOLT.NewCoinFromAmount(*NewAmount(500)))
+	assert.NoError(t, err)
+
+	// the locked coins can be delegated, and once delegated they don't lock the balance anymore
+	err = store.DelegateFromAddress(addr, vestingOLT.NewCoinFromAmount(*NewAmount(600)))
+	assert.NoError(t, err)
+	vesting, err = store.GetVesting(addr, vestingOLT.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "600", vesting.DelegatedVesting.String())
+	locked, err := store.GetLockedBalance(addr, &vestingOLT)
+	assert.NoError(t, err)
+	assert.Equal(t, "400", locked.Amount.String())
+	assert.Error(t, store.MinusFromAddress(addr, vestingOLT.NewCoinFromAmount(*NewAmount(1))))
+
+	// undelegated coins go back to the locked ones first
+	err = store.UndelegateToAddress(addr, vestingOLT.NewCoinFromAmount(*NewAmount(600)))
+	assert.NoError(t, err)
+	vesting, err = store.GetVesting(addr, vestingOLT.Name)
+	assert.NoError(t, err)
+	assert.True(t, vesting.DelegatedVesting.IsZero())
+	assert.Error(t, store.MinusFromAddress(addr, vestingOLT.NewCoinFromAmount(*NewAmount(1))))
+
+	cs.Commit()
+	n := 0
+	store.IterateVesting(func(v *VestingAccount) bool {
+		n++
+		return false
+	})
+	assert.Equal(t, 1, n)
+
+	// the locks aren't enforced before the vesting fork, the vesting accounts aren't read then
+	store.SetupForks(&config.ForkParams{Forks: []config.ForkHeight{{Name: config.VestingFork, Height: 100}}})
+	gc := storage.NewGasCalculator(1000000)
+	store.WithState(cs.WithGas(gc))
+	assert.NoError(t, store.MinusFromAddress(addr, vestingOLT.NewCoinFromAmount(*NewAmount(0))))
+	beforeFork := gc.GetConsumed()
+	store.SetupForks(nil)
+	assert.NoError(t, store.MinusFromAddress(addr, vestingOLT.NewCoinFromAmount(*NewAmount(0))))
+	assert.True(t, gc.GetConsumed()-beforeFork > beforeFork)
+	assert.Error(t, store.MinusFromAddress(addr, vestingOLT.NewCoinFromAmount(*NewAmount(1))))
+}
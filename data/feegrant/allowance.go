@@ -0,0 +1,50 @@
+package feegrant
+
+import (
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+// Allowance lets the grantee pay the fees of its transactions from the balance of the granter
+type Allowance struct {
+	Granter keys.Address `json:"granter"`
+	Grantee keys.Address `json:"grantee"`
+	// fees left to spend, in the fee currency, nil for no limit
+	SpendLimit *balance.Amount `json:"spendLimit,omitempty"`
+	// last height the allowance can be used at, 0 for no expiry
+	Expiry int64 `json:"expiry,omitempty"`
+	// tx types the allowance pays for, all of them when empty
+	AllowedTxTypes []int `json:"allowedTxTypes,omitempty"`
+}
+
+// IsExpired tells whether the allowance can't be used anymore at the height
+func (a *Allowance) IsExpired(height int64) bool {
+	return a.Expiry != 0 && height > a.Expiry
+}
+
+// Allows tells whether the allowance pays for the tx type
+func (a *Allowance) Allows(txType int) bool {
+	if len(a.AllowedTxTypes) == 0 {
+		return true
+	}
+	for _, t := range a.AllowedTxTypes {
+		if t == txType {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns the reason the allowance can't pay the fee of the tx type at the height, nil if it can
+func (a *Allowance) Check(txType int, fee *balance.Amount, height int64) error {
+	if a.IsExpired(height) {
+		return ErrAllowanceExpired
+	}
+	if !a.Allows(txType) {
+		return ErrTxTypeNotAllowed
+	}
+	if a.SpendLimit != nil && a.SpendLimit.BigInt().Cmp(fee.BigInt()) < 0 {
+		return ErrSpendLimitReached
+	}
+	return nil
+}
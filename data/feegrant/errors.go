@@ -0,0 +1,13 @@
+package feegrant
+
+import (
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+var (
+	ErrAllowanceNotFound = codes.ProtocolError{codes.FeeGrantErrNotFound, "no fee allowance granted to the signer"}
+	ErrAllowanceExpired  = codes.ProtocolError{codes.FeeGrantErrExpired, "fee allowance expired"}
+	ErrTxTypeNotAllowed  = codes.ProtocolError{codes.FeeGrantErrTxTypeNotAllowed, "fee allowance doesn't cover the tx type"}
+	ErrSpendLimitReached = codes.ProtocolError{codes.FeeGrantErrSpendLimitReached, "fee is more than the spend limit left in the allowance"}
+	ErrInvalidFeePayer   = codes.ProtocolError{codes.FeeGrantErrInvalidFeePayer, "invalid fee payer"}
+)
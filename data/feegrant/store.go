@@ -0,0 +1,113 @@
+package feegrant
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// Store keeps the fee allowances by granter and grantee
+type Store struct {
+	state  *storage.State
+	szlr   serialize.Serializer
+	prefix []byte
+}
+
+func NewStore(prefix string, state *storage.State) *Store {
+	return &Store{
+		state:  state,
+		prefix: storage.Prefix(prefix),
+		szlr:   serialize.GetSerializer(serialize.PERSISTENT),
+	}
+}
+
+func (st *Store) WithState(state *storage.State) *Store {
+	st.state = state
+	return st
+}
+
+func (st *Store) getKey(granter keys.Address, grantee keys.Address) storage.StoreKey {
+	return storage.StoreKey(string(st.prefix) + granter.String() + storage.DB_PREFIX + grantee.String())
+}
+
+// Get returns the allowance of the granter to the grantee, ErrAllowanceNotFound if there is none
+func (st *Store) Get(granter keys.Address, grantee keys.Address) (*Allowance, error) {
+	dat, err := st.state.Get(st.getKey(granter, grantee))
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) == 0 {
+		return nil, ErrAllowanceNotFound
+	}
+	allowance := &Allowance{}
+	err = st.szlr.Deserialize(dat, allowance)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize fee allowance")
+	}
+	return allowance, nil
+}
+
+// Set grants the allowance, it replaces the previous allowance of the granter to the grantee
+func (st *Store) Set(allowance *Allowance) error {
+	dat, err := st.szlr.Serialize(allowance)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize fee allowance")
+	}
+	return st.state.Set(st.getKey(allowance.Granter, allowance.Grantee), dat)
+}
+
+// Delete revokes the allowance of the granter to the grantee
+func (st *Store) Delete(granter keys.Address, grantee keys.Address) error {
+	if _, err := st.Get(granter, grantee); err != nil {
+		return err
+	}
+	_, err := st.state.Delete(st.getKey(granter, grantee))
+	return err
+}
+
+// UseAllowance takes the fee of a transaction of the grantee out of the allowance of the granter, the fee itself is
+// charged to the granter by the caller. An allowance which reaches its spend limit is removed.
+func (st *Store) UseAllowance(granter keys.Address, grantee keys.Address, txType int, fee *balance.Amount, height int64) error {
+	allowance, err := st.Get(granter, grantee)
+	if err != nil {
+		return err
+	}
+	err = allowance.Check(txType, fee, height)
+	if err != nil {
+		return err
+	}
+	if allowance.SpendLimit == nil {
+		return nil
+	}
+
+	left, err := allowance.SpendLimit.Minus(*fee)
+	if err != nil {
+		return ErrSpendLimitReached
+	}
+	if left.IsZero() {
+		return st.Delete(granter, grantee)
+	}
+	allowance.SpendLimit = left
+	return st.Set(allowance)
+}
+
+// Iterate iterates the allowances of the granter
+func (st *Store) Iterate(granter keys.Address, fn func(allowance *Allowance) bool) bool {
+	prefix := append(storage.StoreKey(string(st.prefix)+granter.String()), storage.DB_PREFIX...)
+	return st.state.IterateRange(
+		prefix,
+		storage.Rangefix(string(prefix)),
+		true,
+		func(key, value []byte) bool {
+			allowance := &Allowance{}
+			err := st.szlr.Deserialize(value, allowance)
+			if err != nil {
+				return true
+			}
+			return fn(allowance)
+		},
+	)
+}
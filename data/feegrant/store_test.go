@@ -0,0 +1,72 @@
+package feegrant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func setup() (*Store, keys.Address, keys.Address) {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	granter := keys.Address(ed25519.GenPrivKey().PubKey().Address())
+	grantee := keys.Address(ed25519.GenPrivKey().PubKey().Address())
+	return NewStore("fg", state), granter, grantee
+}
+
+func TestStore_UseAllowance(t *testing.T) {
+	store, granter, grantee := setup()
+
+	assert.Equal(t, ErrAllowanceNotFound, store.UseAllowance(granter, grantee, 1, balance.NewAmount(10), 1))
+
+	require.NoError(t, store.Set(&Allowance{
+		Granter:        granter,
+		Grantee:        grantee,
+		SpendLimit:     balance.NewAmount(25),
+		Expiry:         100,
+		AllowedTxTypes: []int{1},
+	}))
+
+	assert.NoError(t, store.UseAllowance(granter, grantee, 1, balance.NewAmount(10), 1))
+	allowance, err := store.Get(granter, grantee)
+	require.NoError(t, err)
+	assert.Equal(t, balance.NewAmount(15), allowance.SpendLimit)
+
+	assert.Equal(t, ErrTxTypeNotAllowed, store.UseAllowance(granter, grantee, 2, balance.NewAmount(10), 1))
+	assert.Equal(t, ErrAllowanceExpired, store.UseAllowance(granter, grantee, 1, balance.NewAmount(10), 101))
+	assert.Equal(t, ErrSpendLimitReached, store.UseAllowance(granter, grantee, 1, balance.NewAmount(20), 1))
+
+	// the allowance is removed once it is spent
+	assert.NoError(t, store.UseAllowance(granter, grantee, 1, balance.NewAmount(15), 1))
+	store.state.Commit()
+	_, err = store.Get(granter, grantee)
+	assert.Equal(t, ErrAllowanceNotFound, err)
+}
+
+func TestStore_Iterate(t *testing.T) {
+	store, granter, grantee := setup()
+	other := keys.Address(ed25519.GenPrivKey().PubKey().Address())
+
+	require.NoError(t, store.Set(&Allowance{Granter: granter, Grantee: grantee}))
+	require.NoError(t, store.Set(&Allowance{Granter: granter, Grantee: other}))
+	require.NoError(t, store.Set(&Allowance{Granter: other, Grantee: grantee}))
+	store.state.Commit()
+
+	grantees := 0
+	store.Iterate(granter, func(allowance *Allowance) bool {
+		assert.Equal(t, granter, allowance.Granter)
+		grantees++
+		return false
+	})
+	assert.Equal(t, 2, grantees)
+
+	require.NoError(t, store.Delete(granter, other))
+	store.state.Commit()
+	assert.Equal(t, ErrAllowanceNotFound, store.Delete(granter, other))
+}
@@ -0,0 +1,290 @@
+package fees
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+const (
+	MARKET_PREFIX   = "feemarket"
+	BASE_FEE_KEY    = "basefee"
+	BLOCK_FEE_KEY   = "block"
+	FEE_HISTORY_LEN = int64(1024)
+)
+
+// MarketOptions tunes the fee market, when enabled the minimum gas price is the base fee of the block. The base fee
+// rises when the blocks use more than the target share of the block gas limit and falls when they use less.
+type MarketOptions struct {
+	Enabled bool `json:"enabled"`
+	// percentage of the block gas limit the base fee is stable at
+	TargetUtilisation int64 `json:"targetUtilisation"`
+	// the base fee changes by at most 1/BaseFeeChangeDenominator from a block to the next
+	BaseFeeChangeDenominator int64 `json:"baseFeeChangeDenominator"`
+	// the base fee part of the fees is credited to this address, it is burnt when empty
+	BaseFeeAddress keys.Address `json:"baseFeeAddress,omitempty"`
+}
+
+// BlockFee is the gas usage of a block and the base fee it charged
+type BlockFee struct {
+	Height   int64          `json:"height"`
+	GasUsed  int64          `json:"gasUsed"`
+	GasLimit int64          `json:"gasLimit"`
+	BaseFee  balance.Amount `json:"baseFee"`
+	// distribution of the gas prices paid by the transactions of the block, sorted by price
+	GasPrices []GasPrice `json:"gasPrices,omitempty"`
+}
+
+// GasPrice is the gas used in a block by the transactions paying the same effective gas price
+type GasPrice struct {
+	Price balance.Amount `json:"price"`
+	Gas   int64          `json:"gas"`
+}
+
+// AddGasPrice adds the gas used at the price to the distribution, which stays sorted with a single entry by price
+func AddGasPrice(prices []GasPrice, price balance.Amount, gas int64) []GasPrice {
+	i := sort.Search(len(prices), func(i int) bool {
+		return prices[i].Price.BigInt().Cmp(price.BigInt()) >= 0
+	})
+	if i < len(prices) && prices[i].Price.BigInt().Cmp(price.BigInt()) == 0 {
+		prices[i].Gas += gas
+		return prices
+	}
+	prices = append(prices, GasPrice{})
+	copy(prices[i+1:], prices[i:])
+	prices[i] = GasPrice{Price: price, Gas: gas}
+	return prices
+}
+
+// Rewards returns the priority fee paid above the base fee at each percentile of the gas used by the transactions
+// of the block, the same way eth_feeHistory weights them, zero for an empty block
+func (b *BlockFee) Rewards(percentiles []float64) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+	var total int64
+	for _, p := range b.GasPrices {
+		total += p.Gas
+	}
+	if total == 0 {
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+		return rewards
+	}
+
+	reward := func(p GasPrice) *big.Int {
+		tip := new(big.Int).Sub(p.Price.BigInt(), b.BaseFee.BigInt())
+		if tip.Sign() < 0 {
+			tip.SetInt64(0)
+		}
+		return tip
+	}
+	index := 0
+	sumGas := b.GasPrices[0].Gas
+	for i, percentile := range percentiles {
+		threshold := int64(float64(total) * percentile / 100)
+		for sumGas < threshold && index < len(b.GasPrices)-1 {
+			index++
+			sumGas += b.GasPrices[index].Gas
+		}
+		rewards[i] = reward(b.GasPrices[index])
+	}
+	return rewards
+}
+
+// NextBaseFee adjusts the base fee of a block with the gas used by it, the same way EIP-1559 does
+func NextBaseFee(opt *MarketOptions, baseFee *big.Int, gasUsed, gasLimit int64) *big.Int {
+	target := gasLimit * opt.TargetUtilisation / 100
+	if target <= 0 || gasUsed == target {
+		return new(big.Int).Set(baseFee)
+	}
+
+	diff := gasUsed - target
+	if diff < 0 {
+		diff = -diff
+	}
+	delta := new(big.Int).Mul(baseFee, big.NewInt(diff))
+	delta.Div(delta, big.NewInt(target))
+	delta.Div(delta, big.NewInt(opt.BaseFeeChangeDenominator))
+
+	if gasUsed > target {
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return delta.Add(baseFee, delta)
+	}
+	return delta.Sub(baseFee, delta)
+}
+
+func (st *Store) SetupMarketOpt(opt *MarketOptions) {
+	st.marketOpt = opt
+}
+
+func (st *Store) GetMarketOpt() *MarketOptions {
+	return st.marketOpt
+}
+
+// SetupBlockGasLimit sets the gas limit of the blocks, -1 when they are unlimited
+func (st *Store) SetupBlockGasLimit(limit int64) {
+	st.blockGasLimit = limit
+}
+
+func (st *Store) GetBlockGasLimit() int64 {
+	return st.blockGasLimit
+}
+
+func (st *Store) marketEnabled() bool {
+	return st.marketOpt != nil && st.marketOpt.Enabled
+}
+
+func marketKey(key string) storage.StoreKey {
+	return storage.StoreKey(string(storage.Prefix(MARKET_PREFIX)) + key)
+}
+
+func blockFeeKey(height int64) storage.StoreKey {
+	return marketKey(BLOCK_FEE_KEY + storage.DB_PREFIX + strconv.FormatInt(height, 10))
+}
+
+// BaseFee returns the base fee of the current block, zero when the fee market has never been enabled
+func (st *Store) BaseFee() balance.Coin {
+	dat, _ := st.state.Get(marketKey(BASE_FEE_KEY))
+	a := balance.NewAmount(0)
+	if len(dat) != 0 {
+		err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(dat, a)
+		if err != nil {
+			a = balance.NewAmount(0)
+		}
+	}
+	return st.feeOpt.FeeCurrency.NewCoinFromAmount(*a)
+}
+
+func (st *Store) setBaseFee(amount balance.Amount) error {
+	dat, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(&amount)
+	if err != nil {
+		return err
+	}
+	return st.state.Set(marketKey(BASE_FEE_KEY), dat)
+}
+
+// MinFee returns the minimum gas price of the transactions, the base fee of the block if the fee market is enabled
+// and above the minimum of the fee options
+func (st *Store) MinFee() balance.Coin {
+	minFee := st.feeOpt.MinFee()
+	if !st.marketEnabled() {
+		return minFee
+	}
+	baseFee := st.BaseFee()
+	if minFee.LessThanCoin(baseFee) {
+		return baseFee
+	}
+	return minFee
+}
+
+// AddFee adds the fee paid for the gas used by a transaction to the pool, when the fee market is enabled the base
+// fee part of it is burnt or credited to the base fee address, the pool gets the rest
+func (st *Store) AddFee(charge balance.Coin, gasUsed int64) error {
+	if !st.marketEnabled() {
+		return st.AddToPool(charge)
+	}
+
+	base := st.BaseFee().MultiplyInt64(gasUsed)
+	if charge.LessThanEqualCoin(base) {
+		base = charge
+	}
+	tip, err := charge.Minus(base)
+	if err != nil {
+		return err
+	}
+	if len(st.marketOpt.BaseFeeAddress) != 0 {
+		err = st.AddToAddress(st.marketOpt.BaseFeeAddress, base)
+		if err != nil {
+			return err
+		}
+	}
+	return st.AddToPool(tip)
+}
+
+// UpdateBaseFee records the gas used by the block and sets the base fee of the next one, the records older than
+// FEE_HISTORY_LEN blocks are removed
+func (st *Store) UpdateBaseFee(height, gasUsed, gasLimit int64) error {
+	if !st.marketEnabled() {
+		if st.state.Exists(marketKey(BASE_FEE_KEY)) {
+			_, err := st.state.Delete(marketKey(BASE_FEE_KEY))
+			return err
+		}
+		return nil
+	}
+
+	baseFee := st.MinFee()
+	// the block may have been recorded with the gas prices of its transactions already
+	if !st.state.Exists(blockFeeKey(height)) {
+		err := st.setBlockFee(BlockFee{
+			Height:   height,
+			GasUsed:  gasUsed,
+			GasLimit: gasLimit,
+			BaseFee:  *baseFee.Amount,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	next := NextBaseFee(st.marketOpt, baseFee.Amount.BigInt(), gasUsed, gasLimit)
+	if next.Cmp(st.feeOpt.MinFee().Amount.BigInt()) < 0 {
+		next = st.feeOpt.MinFee().Amount.BigInt()
+	}
+	return st.setBaseFee(*balance.NewAmountFromBigInt(next))
+}
+
+// RecordBlockFee records the gas used by the block and the gas prices paid by its transactions whether the fee
+// market is enabled or not, it is called before the base fee moves to the one of the next block
+func (st *Store) RecordBlockFee(height, gasUsed, gasLimit int64, prices []GasPrice) error {
+	return st.setBlockFee(BlockFee{
+		Height:    height,
+		GasUsed:   gasUsed,
+		GasLimit:  gasLimit,
+		BaseFee:   *st.MinFee().Amount,
+		GasPrices: prices,
+	})
+}
+
+func (st *Store) setBlockFee(record BlockFee) error {
+	dat, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize block fee")
+	}
+	err = st.state.Set(blockFeeKey(record.Height), dat)
+	if err != nil {
+		return err
+	}
+	if old := record.Height - FEE_HISTORY_LEN; old > 0 && st.state.Exists(blockFeeKey(old)) {
+		_, err = st.state.Delete(blockFeeKey(old))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBlockFee returns the gas usage and base fee of the block, nil if the block isn't part of the history
+func (st *Store) GetBlockFee(height int64) (*BlockFee, error) {
+	dat, err := st.state.Get(blockFeeKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) == 0 {
+		return nil, nil
+	}
+	record := &BlockFee{}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(dat, record)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize block fee")
+	}
+	return record, nil
+}
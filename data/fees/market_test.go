@@ -0,0 +1,158 @@
+package fees
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+var testMarketOpt = &MarketOptions{
+	Enabled:                  true,
+	TargetUtilisation:        50,
+	BaseFeeChangeDenominator: 8,
+}
+
+func setupMarketStore(opt *MarketOptions) (*Store, *storage.State) {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	st := NewStore("f", state)
+	st.SetupOpt(&FeeOption{
+		FeeCurrency:   balance.Currency{Id: 0, Name: "OLT", Decimal: 18, Unit: "nue"},
+		MinFeeDecimal: 9,
+	})
+	st.SetupMarketOpt(opt)
+	return st, state
+}
+
+func TestNextBaseFee(t *testing.T) {
+	baseFee := big.NewInt(1000000)
+
+	// on target
+	assert.Equal(t, baseFee, NextBaseFee(testMarketOpt, baseFee, 500, 1000))
+	// full block, +1/8
+	assert.Equal(t, big.NewInt(1125000), NextBaseFee(testMarketOpt, baseFee, 1000, 1000))
+	// empty block, -1/8
+	assert.Equal(t, big.NewInt(875000), NextBaseFee(testMarketOpt, baseFee, 0, 1000))
+	// the base fee always rises above the target
+	assert.Equal(t, big.NewInt(2), NextBaseFee(testMarketOpt, big.NewInt(1), 501, 1000))
+	// unlimited blocks have no target
+	assert.Equal(t, baseFee, NextBaseFee(testMarketOpt, baseFee, 1000, -1))
+}
+
+func TestStore_UpdateBaseFee(t *testing.T) {
+	st, state := setupMarketStore(testMarketOpt)
+	minFee := st.GetOpt().MinFee()
+
+	assert.Equal(t, minFee, st.MinFee())
+
+	// the base fee never goes below the minimum fee of the options
+	assert.NoError(t, st.UpdateBaseFee(1, 0, 1000))
+	state.Commit()
+	assert.Equal(t, minFee, st.MinFee())
+
+	assert.NoError(t, st.UpdateBaseFee(2, 1000, 1000))
+	state.Commit()
+	expected := minFee.MultiplyInt64(9).DivideInt64(8)
+	assert.Equal(t, expected, st.MinFee())
+
+	record, err := st.GetBlockFee(2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), record.GasUsed)
+	assert.Equal(t, *minFee.Amount, record.BaseFee)
+
+	// disabling the market goes back to the minimum fee of the options
+	st.SetupMarketOpt(&MarketOptions{})
+	assert.NoError(t, st.UpdateBaseFee(3, 1000, 1000))
+	state.Commit()
+	assert.Equal(t, minFee, st.MinFee())
+	assert.True(t, st.BaseFee().Amount.BigInt().Sign() == 0)
+}
+
+func TestStore_AddFee(t *testing.T) {
+	recipient := keys.Address("recipient0000000000000")
+	opt := *testMarketOpt
+	opt.BaseFeeAddress = recipient
+	st, state := setupMarketStore(&opt)
+	assert.NoError(t, st.UpdateBaseFee(1, 500, 1000))
+	state.Commit()
+
+	baseFee := st.BaseFee()
+	charge := baseFee.MultiplyInt64(3).MultiplyInt64(100)
+	assert.NoError(t, st.AddFee(charge, 100))
+	state.Commit()
+
+	pool, err := st.Get([]byte(POOL_KEY))
+	assert.NoError(t, err)
+	assert.Equal(t, baseFee.MultiplyInt64(2).MultiplyInt64(100), pool)
+
+	base, err := st.Get(recipient)
+	assert.NoError(t, err)
+	assert.Equal(t, baseFee.MultiplyInt64(100), base)
+}
+
+func TestAddGasPrice(t *testing.T) {
+	var prices []GasPrice
+	prices = AddGasPrice(prices, *balance.NewAmountFromInt(20), 100)
+	prices = AddGasPrice(prices, *balance.NewAmountFromInt(10), 50)
+	prices = AddGasPrice(prices, *balance.NewAmountFromInt(30), 25)
+	prices = AddGasPrice(prices, *balance.NewAmountFromInt(20), 10)
+
+	assert.Len(t, prices, 3)
+	assert.Equal(t, *balance.NewAmountFromInt(10), prices[0].Price)
+	assert.Equal(t, int64(50), prices[0].Gas)
+	assert.Equal(t, *balance.NewAmountFromInt(20), prices[1].Price)
+	assert.Equal(t, int64(110), prices[1].Gas)
+	assert.Equal(t, *balance.NewAmountFromInt(30), prices[2].Price)
+}
+
+func TestBlockFee_Rewards(t *testing.T) {
+	int64s := func(rewards []*big.Int) []int64 {
+		values := make([]int64, len(rewards))
+		for i, r := range rewards {
+			values[i] = r.Int64()
+		}
+		return values
+	}
+
+	record := &BlockFee{BaseFee: *balance.NewAmountFromInt(10)}
+	assert.Equal(t, []int64{0, 0}, int64s(record.Rewards([]float64{10, 90})))
+
+	record.GasPrices = []GasPrice{
+		{Price: *balance.NewAmountFromInt(5), Gas: 100},
+		{Price: *balance.NewAmountFromInt(12), Gas: 100},
+		{Price: *balance.NewAmountFromInt(20), Gas: 200},
+	}
+	rewards := record.Rewards([]float64{0, 25, 26, 50, 100})
+	// the prices under the base fee pay no reward, the percentiles are weighted by gas
+	assert.Equal(t, []int64{0, 0, 2, 2, 10}, int64s(rewards))
+}
+
+func TestStore_RecordBlockFee(t *testing.T) {
+	st, state := setupMarketStore(testMarketOpt)
+	minFee := st.GetOpt().MinFee()
+	prices := AddGasPrice(nil, *minFee.Amount, 21000)
+
+	assert.NoError(t, st.RecordBlockFee(1, 21000, 1000000, prices))
+	assert.NoError(t, st.UpdateBaseFee(1, 21000, 1000000))
+	state.Commit()
+
+	// the record keeps the gas prices and the base fee of the block
+	record, err := st.GetBlockFee(1)
+	assert.NoError(t, err)
+	assert.Equal(t, prices, record.GasPrices)
+	assert.Equal(t, *minFee.Amount, record.BaseFee)
+
+	// the blocks are recorded with the market disabled too
+	st.SetupMarketOpt(&MarketOptions{})
+	assert.NoError(t, st.RecordBlockFee(2, 0, 1000000, nil))
+	assert.NoError(t, st.UpdateBaseFee(2, 0, 1000000))
+	state.Commit()
+	record, err = st.GetBlockFee(2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), record.Height)
+}
@@ -13,6 +13,10 @@ type Store struct {
 	state  *storage.State
 	prefix []byte
 	feeOpt *FeeOption
+
+	marketOpt *MarketOptions
+	// gas limit of the blocks, the base fee moves with the share of it the blocks use
+	blockGasLimit int64
 }
 
 func NewStore(prefix string, state *storage.State) *Store {
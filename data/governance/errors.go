@@ -27,6 +27,7 @@ var (
 	ErrInvalidPassPercentage  = codes.ProtocolError{codes.GovErrInvalidPassPercentage, "invalid pass percentage"}
 	ErrInvalidFundingDeadline = codes.ProtocolError{codes.GovErrInvalidFundingDeadline, "invalid funding deadline"}
 	ErrInvalidVotingDeadline  = codes.ProtocolError{codes.GovErrInvalidVotingDeadline, "invalid voting deadline"}
+	ErrInvalidUpgradePlan     = codes.ProtocolError{codes.GovErrInvalidUpgradePlan, "invalid upgrade plan"}
 
 	//Funding
 	ErrDeductFunding          = codes.ProtocolError{codes.GovErrDeductFunding, "failed to deduct funds from address"}
@@ -54,6 +55,7 @@ var (
 	ErrStatusNotCompleted              = codes.ProtocolError{Code: codes.GovErrStatusNotCompleted, Msg: "TX not in completed status"}
 	ErrFinalizeDistributtionFailed     = codes.ProtocolError{Code: codes.GovErrFinalizeDistributtionFailed, Msg: "Failed in distributing Funds"}
 	ErrFinalizeConfigUpdateFailed      = codes.ProtocolError{Code: codes.GovErrFinalizeConfigUpdateFailed, Msg: "Failed to execute Config Update"}
+	ErrFinalizeCodeChangeFailed        = codes.ProtocolError{Code: codes.GovErrFinalizeCodeChangeFailed, Msg: "Failed to schedule Code Change"}
 	ErrStatusUnableToSetFinalized      = codes.ProtocolError{Code: codes.GovErrStatusUnableToSetFinalized, Msg: "Failed to set status to finalized"}
 	ErrStatusUnableToSetFinalizeFailed = codes.ProtocolError{Code: codes.GovErrUnableToSetFinalizeFailed, Msg: "Failed to set status to finalized Failed"}
 	ErrGovFundBalanceMismatch          = codes.ProtocolError{Code: codes.GovFundBalanceMismatch, Msg: "Balance Mismatch While Burning Funds"}
@@ -63,6 +65,7 @@ var (
 	ErrNoSuchFunder                = codes.ProtocolError{codes.GovErrNoSuchFunder, "no such funder funded this proposal"}
 	ErrUnmatchedProposer           = codes.ProtocolError{codes.GovErrUnmatchedProposer, "proposer does not match"}
 	ErrInvalidVoterId              = codes.ProtocolError{codes.GovErrInvalidVoterId, "invalid voter id"}
+	ErrNoActiveDelegation          = codes.ProtocolError{codes.GovErrNoActiveDelegation, "voter has no active network delegation"}
 	ErrWithdrawCheckFundsFailed    = codes.ProtocolError{Code: codes.GovErrWithdrawCheckFundsFailed, Msg: "ErrWithdraw, failed to check available funds to withdraw for this contributor"}
 
 	//Proposal Prefix
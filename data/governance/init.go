@@ -55,6 +55,9 @@ const (
 	VOTE_RESULT_FAILED VoteResult = 0x11
 	VOTE_RESULT_TBD    VoteResult = 0x12
 
+	//Key prefix of the delegator votes
+	DELEGATOR_VOTE_PREFIX = "delegator"
+
 	//Error Codes
 	errorSerialization   = "321"
 	errorDeSerialization = "322"
@@ -67,10 +70,11 @@ const (
 )
 
 type GovProposal struct {
-	Prop          Proposal        `json:"proposal"`
-	ProposalVotes []*ProposalVote `json:"proposalVotes"`
-	ProposalFunds []ProposalFund  `json:"proposalFunds"`
-	State         ProposalState
+	Prop           Proposal         `json:"proposal"`
+	ProposalVotes  []*ProposalVote  `json:"proposalVotes"`
+	DelegatorVotes []*DelegatorVote `json:"delegatorVotes,omitempty"`
+	ProposalFunds  []ProposalFund   `json:"proposalFunds"`
+	State          ProposalState
 }
 
 type ProposalMasterStore struct {
@@ -94,6 +98,14 @@ func (p *ProposalMasterStore) GetProposalVotes(id ProposalID) []*ProposalVote {
 	return votes
 }
 
+func (p *ProposalMasterStore) GetDelegatorVotes(id ProposalID) []*DelegatorVote {
+	votes, err := p.ProposalVote.GetDelegatorVotesByID(id)
+	if err != nil || len(votes) == 0 {
+		return nil
+	}
+	return votes
+}
+
 func (p *ProposalMasterStore) GetProposalFunds(id ProposalID) []ProposalFund {
 	return p.ProposalFund.GetFundsForProposalID(id, func(proposalID ProposalID, fundingAddr keys.Address, amt *balance.Amount) ProposalFund {
 		propFund := ProposalFund{
@@ -113,7 +125,9 @@ func (p *ProposalMasterStore) LoadProposals(proposals []GovProposal) error {
 			return err
 		}
 		for _, vote := range prop.ProposalVotes {
-			err = p.ProposalVote.Setup(prop.Prop.ProposalID, NewProposalVote(vote.Validator, OPIN_UNKNOWN, vote.Power))
+			pv := NewProposalVote(vote.Validator, OPIN_UNKNOWN, vote.Power)
+			pv.DelegatedPower = vote.DelegatedPower
+			err = p.ProposalVote.Setup(prop.Prop.ProposalID, pv)
 			if err != nil {
 				return err
 			}
@@ -122,6 +136,12 @@ func (p *ProposalMasterStore) LoadProposals(proposals []GovProposal) error {
 				return err
 			}
 		}
+		for _, vote := range prop.DelegatorVotes {
+			err = p.ProposalVote.SetDelegatorVote(prop.Prop.ProposalID, vote)
+			if err != nil {
+				return err
+			}
+		}
 		for _, fund := range prop.ProposalFunds {
 			err = p.ProposalFund.AddFunds(fund.Id, fund.Address, fund.FundingAmount)
 			if err != nil {
@@ -48,6 +48,7 @@ type Proposal struct {
 	VotingDeadline        int64           `json:"votingDeadline"`
 	PassPercentage        int             `json:"passPercent"`
 	GovernanceStateUpdate string          `json:"updateGovernanace"`
+	Upgrade               *UpgradePlan    `json:"upgrade,omitempty"`
 }
 
 func NewProposal(proposalID ProposalID, propType ProposalType, desc string, headline string, proposer keys.Address, fundingDeadline int64, fundingGoal *balance.Amount,
@@ -12,6 +12,16 @@ type ProposalVote struct {
 	Validator keys.Address `json:"validator"`
 	Opinion   VoteOpinion  `json:"opinion"`
 	Power     int64        `json:"power"`
+	// power of the network delegators who picked the validator when the voting started, they inherit its vote
+	DelegatedPower int64 `json:"delegatedPower,omitempty"`
+}
+
+// DelegatorVote is the vote of a network delegator, it overrides the vote inherited from the validator it picked
+type DelegatorVote struct {
+	Delegator keys.Address `json:"delegator"`
+	Validator keys.Address `json:"validator,omitempty"`
+	Opinion   VoteOpinion  `json:"opinion"`
+	Power     int64        `json:"power"`
 }
 
 type VoteStatus struct {
@@ -29,6 +39,15 @@ func NewProposalVote(validator keys.Address, opinion VoteOpinion, power int64) *
 	}
 }
 
+func NewDelegatorVote(delegator, validator keys.Address, opinion VoteOpinion, power int64) *DelegatorVote {
+	return &DelegatorVote{
+		Delegator: delegator,
+		Validator: validator,
+		Opinion:   opinion,
+		Power:     power,
+	}
+}
+
 func NewVoteStatus(result VoteResult, yesPower, noPower, allPower int64) *VoteStatus {
 	return &VoteStatus{
 		Result:   result,
@@ -60,3 +79,26 @@ func (vote *ProposalVote) String() string {
 	return fmt.Sprintf("validator= %v, opinion= %v, power= %v",
 		hex.EncodeToString(vote.Validator), vote.Opinion.String(), vote.Power)
 }
+
+func (vote *DelegatorVote) Bytes() []byte {
+	value, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(vote)
+	if err != nil {
+		logger.Error("delegator vote not serializable", err)
+		return []byte{}
+	}
+	return value
+}
+
+func (vote *DelegatorVote) FromBytes(msg []byte) (*DelegatorVote, error) {
+	err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(msg, vote)
+	if err != nil {
+		logger.Error("failed to deserialize a delegator vote from bytes", err)
+		return nil, err
+	}
+	return vote, nil
+}
+
+func (vote *DelegatorVote) String() string {
+	return fmt.Sprintf("delegator= %v, validator= %v, opinion= %v, power= %v",
+		hex.EncodeToString(vote.Delegator), hex.EncodeToString(vote.Validator), vote.Opinion.String(), vote.Power)
+}
@@ -80,19 +80,71 @@ func (pvs *ProposalVoteStore) Update(proposalID ProposalID, vote *ProposalVote)
 	return nil
 }
 
+// Set a delegator's vote to proposalID, it replaces the previous vote of the delegator
+func (pvs *ProposalVoteStore) SetDelegatorVote(proposalID ProposalID, vote *DelegatorVote) error {
+	info := fmt.Sprintf("Delegator Vote Set: proposalID= %v, %v", proposalID, vote.String())
+
+	key := GetDelegatorKey(pvs.prefix, proposalID, vote.Delegator)
+	err := pvs.store.Set(key, vote.Bytes())
+	if err != nil {
+		logger.Errorf("%v, storage failure", info)
+		return err
+	}
+	logger.Detail(info)
+
+	return nil
+}
+
+// Update a delegator's vote to proposalID, the delegator keeps the power and the validator it had when it first voted
+// and only changes its opinion afterwards
+func (pvs *ProposalVoteStore) UpdateDelegator(proposalID ProposalID, vote *DelegatorVote) error {
+	info := fmt.Sprintf("Delegator Vote Update: proposalID= %v, %v", proposalID, vote.String())
+
+	// Get this vote record
+	key := GetDelegatorKey(pvs.prefix, proposalID, vote.Delegator)
+	msg, err := pvs.store.Get(key)
+	if err != nil {
+		logger.Errorf("%v, can't participate in voting", info)
+		return err
+	}
+	if len(msg) == 0 {
+		return pvs.SetDelegatorVote(proposalID, vote)
+	}
+
+	// Deserialize it
+	dv, err := (&DelegatorVote{}).FromBytes(msg)
+	if err != nil {
+		logger.Errorf("%v, deserialize delegator vote failed", info)
+		return err
+	}
+
+	// Update opinion field only
+	dv.Opinion = vote.Opinion
+	err = pvs.store.Set(key, dv.Bytes())
+	if err != nil {
+		logger.Errorf("%v, storage failure", info)
+		return err
+	}
+	logger.Detail(info)
+
+	return nil
+}
+
 // Delete all voting records under a proposalID
 func (pvs *ProposalVoteStore) Delete(proposalID ProposalID) error {
 	info := fmt.Sprintf("Vote Delete: proposalID= %v", proposalID)
 
 	succeed := true
-	pvs.IterateByID(proposalID, func(key []byte, value []byte) bool {
+	deleteFn := func(key []byte, value []byte) bool {
 		_, err := pvs.store.Delete(key)
 		if err != nil {
 			logger.Errorf("%v, failed to delete vote, key= %v", info, string(key))
 			succeed = false
 		}
 		return false
-	})
+	}
+	pvs.IterateByID(proposalID, deleteFn)
+	pvs.IterateDelegatorsByID(proposalID, deleteFn)
 
 	if !succeed {
 		logger.Errorf("%v, delete failed", info)
@@ -106,6 +158,7 @@ func (pvs *ProposalVoteStore) Delete(proposalID ProposalID) error {
 //ResultSoFar check and see if a proposal has already passed or failed
 //Proposal passed if passPercent already achieved
 //Proposal never pass if received enough NEGATIVE votes
+//The network delegators vote with their validator unless they voted themselves
 func (pvs *ProposalVoteStore) ResultSoFar(proposalID ProposalID, passPercent int) (*VoteStatus, error) {
 	info := fmt.Sprintf("Vote IsPassed: proposalID= %v", proposalID)
 
@@ -115,11 +168,22 @@ func (pvs *ProposalVoteStore) ResultSoFar(proposalID ProposalID, passPercent int
 		stat := NewVoteStatus(VOTE_RESULT_TBD, 0, 0, 0)
 		return stat, ErrVoteCheckVoteResultFailed
 	}
+	delegatorVotes, err := pvs.GetDelegatorVotesByID(proposalID)
+	if err != nil {
+		logger.Errorf("%v, getDelegatorVotesByID failed", info)
+		stat := NewVoteStatus(VOTE_RESULT_TBD, 0, 0, 0)
+		return stat, ErrVoteCheckVoteResultFailed
+	}
 
 	// Accumulates power of each opinion
 	allPower := int64(0)
 	eachPower := make([]int64, 4)
 	for _, vote := range votes {
+		power := vote.Power + InheritedPower(vote, delegatorVotes)
+		allPower += power
+		eachPower[vote.Opinion] += power
+	}
+	for _, vote := range delegatorVotes {
 		allPower += vote.Power
 		eachPower[vote.Opinion] += vote.Power
 	}
@@ -169,6 +233,56 @@ func (pvs *ProposalVoteStore) IterateByID(proposalID ProposalID, fn func(key []b
 	)
 }
 
+// Iterate delegator voting records by proposalID
+func (pvs *ProposalVoteStore) IterateDelegatorsByID(proposalID ProposalID, fn func(key []byte, value []byte) bool) (stopped bool) {
+	prefix := GetDelegatorKey(pvs.prefix, proposalID, nil)
+	return pvs.store.IterateRange(
+		prefix,
+		storage.Rangefix(string(prefix)),
+		true,
+		func(key, value []byte) bool {
+			return fn(key, value)
+		},
+	)
+}
+
+// get delegator votes by proposalID, there may be none
+func (pvs *ProposalVoteStore) GetDelegatorVotesByID(proposalID ProposalID) ([]*DelegatorVote, error) {
+	info := fmt.Sprintf("Vote getDelegatorVotesByID: proposalID= %v", proposalID)
+
+	var err error
+	votes := make([]*DelegatorVote, 0)
+	pvs.IterateDelegatorsByID(proposalID, func(key []byte, value []byte) bool {
+		var vote *DelegatorVote
+		vote, err = (&DelegatorVote{}).FromBytes(value)
+		if err != nil {
+			logger.Errorf("%v, key= %v, deserialize delegator vote failed", info, key)
+			return true
+		}
+		votes = append(votes, vote)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+// InheritedPower returns the power of the delegators of the validator who didn't vote themselves
+func InheritedPower(vote *ProposalVote, delegatorVotes []*DelegatorVote) int64 {
+	power := vote.DelegatedPower
+	for _, dv := range delegatorVotes {
+		if dv.Validator.Equal(vote.Validator) {
+			power -= dv.Power
+		}
+	}
+	if power < 0 {
+		return 0
+	}
+	return power
+}
+
 // get voting votes by proposalID
 func (pvs *ProposalVoteStore) GetVotesByID(proposalID ProposalID) ([]keys.Address, []*ProposalVote, error) {
 	info := fmt.Sprintf("Vote getVotesByID: proposalID= %v", proposalID)
@@ -208,3 +322,7 @@ func (pvs *ProposalVoteStore) GetVotesByID(proposalID ProposalID) ([]keys.Addres
 func GetKey(prefix []byte, proposalID ProposalID, vote *ProposalVote) []byte {
 	return storage.StoreKey(string(prefix) + string(proposalID) + storage.DB_PREFIX + string(vote.Validator))
 }
+
+func GetDelegatorKey(prefix []byte, proposalID ProposalID, delegator keys.Address) []byte {
+	return storage.StoreKey(string(prefix) + DELEGATOR_VOTE_PREFIX + storage.DB_PREFIX + string(proposalID) + storage.DB_PREFIX + string(delegator))
+}
@@ -281,3 +281,51 @@ func TestProposalVoteStore_ProposalTBD(t *testing.T) {
 		assert.Equal(t, VOTE_RESULT_TBD, stat.Result)
 	})
 }
+
+func TestProposalVoteStore_DelegatorVotes(t *testing.T) {
+	db := db.NewDB("test", db.MemDBBackend, "")
+	pvs := NewProposalVoteStore("pvs", storage.NewState(storage.NewChainState("chainstate", db)))
+	v0, _ := hex.DecodeString(hex0)
+	v1, _ := hex.DecodeString(hex1)
+	d0, _ := hex.DecodeString(hex2)
+	d1, _ := hex.DecodeString(hex3)
+
+	// the delegators of the first validator have 30 of power when the voting starts
+	vote0 := NewProposalVote(v0, OPIN_UNKNOWN, 10)
+	vote0.DelegatedPower = 30
+	assert.Nil(t, pvs.Setup(proposalID, vote0))
+	assert.Nil(t, pvs.Setup(proposalID, NewProposalVote(v1, OPIN_UNKNOWN, 10)))
+	pvs.store.Commit()
+
+	stat, err := pvs.ResultSoFar(proposalID, passPercent)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(50), stat.PowerAll)
+
+	assert.Nil(t, pvs.Update(proposalID, NewProposalVote(v0, OPIN_POSITIVE, 10)))
+	assert.Nil(t, pvs.Update(proposalID, NewProposalVote(v1, OPIN_NEGATIVE, 10)))
+	// a delegator of the first validator overrides its vote, a delegator of the pool votes on its own
+	assert.Nil(t, pvs.UpdateDelegator(proposalID, NewDelegatorVote(d0, v0, OPIN_POSITIVE, 10)))
+	assert.Nil(t, pvs.UpdateDelegator(proposalID, NewDelegatorVote(d1, nil, OPIN_POSITIVE, 5)))
+	// the delegator keeps the power it first voted with when it changes its opinion
+	assert.Nil(t, pvs.UpdateDelegator(proposalID, NewDelegatorVote(d0, v1, OPIN_NEGATIVE, 100)))
+	pvs.store.Commit()
+
+	stat, err = pvs.ResultSoFar(proposalID, passPercent)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10+20+5), stat.PowerYes)
+	assert.Equal(t, int64(10+10), stat.PowerNo)
+	assert.Equal(t, int64(55), stat.PowerAll)
+	assert.Equal(t, VOTE_RESULT_FAILED, stat.Result)
+
+	delegatorVotes, err := pvs.GetDelegatorVotesByID(proposalID)
+	assert.Nil(t, err)
+	assert.Len(t, delegatorVotes, 2)
+	assert.Equal(t, int64(20), InheritedPower(vote0, delegatorVotes))
+
+	// the delegator votes go with the proposal
+	assert.Nil(t, pvs.Delete(proposalID))
+	pvs.store.Commit()
+	delegatorVotes, err = pvs.GetDelegatorVotesByID(proposalID)
+	assert.Nil(t, err)
+	assert.Len(t, delegatorVotes, 0)
+}
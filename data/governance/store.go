@@ -21,6 +21,7 @@ import (
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/data/tokens"
 	"github.com/Oneledger/protocol/serialize"
 	"github.com/Oneledger/protocol/storage"
 )
@@ -47,6 +48,12 @@ const (
 
 	ADMIN_NETWK_DELEG_OPTION string = "networkdelegopt"
 
+	ADMIN_FEE_MARKET_OPTION string = "feemarketopt"
+
+	ADMIN_GAS_OPTION string = "gasopt"
+
+	ADMIN_TOKEN_OPTION string = "tokenopt"
+
 	TOTAL_FUNDS_PREFIX string = "t"
 
 	INDIVIDUAL_FUNDS_PREFIX string = "i"
@@ -62,6 +69,9 @@ const (
 	LAST_UPDATE_HEIGHT_ONS         string = "onsOptions"
 	LAST_UPDATE_HEIGHT_PROPOSAL    string = "proposalOptions"
 	LAST_UPDATE_HEIGHT_EVIDENCE    string = "evidenceOptions"
+	LAST_UPDATE_HEIGHT_FEE_MARKET  string = "feeMarketOptions"
+	LAST_UPDATE_HEIGHT_GAS         string = "gasOptions"
+	LAST_UPDATE_HEIGHT_TOKEN       string = "tokenOptions"
 	HEIGHT_INDEPENDENT_VALUE       string = "heightindependent"
 
 	// Pool names
@@ -193,6 +203,18 @@ func (st *Store) SetAllLUH() error {
 	if err != nil {
 		return err
 	}
+	err = st.SetLUH(LAST_UPDATE_HEIGHT_FEE_MARKET)
+	if err != nil {
+		return err
+	}
+	err = st.SetLUH(LAST_UPDATE_HEIGHT_GAS)
+	if err != nil {
+		return err
+	}
+	err = st.SetLUH(LAST_UPDATE_HEIGHT_TOKEN)
+	if err != nil {
+		return err
+	}
 	err = st.SetLUH(LAST_UPDATE_HEIGHT)
 	if err != nil {
 		return err
@@ -549,6 +571,112 @@ func (st *Store) GetNetworkDelegOptions() (*network_delegation.Options, error) {
 	return delegOptions, nil
 }
 
+func (st *Store) SetFeeMarketOptions(marketOptions fees.MarketOptions) error {
+	bytes, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(marketOptions)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize fee market options")
+	}
+	err = st.Set(ADMIN_FEE_MARKET_OPTION, bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to set fee market options")
+	}
+	return nil
+}
+
+// GetFeeMarketOptions returns the fee market options, the market is disabled on the chains started before the
+// options were added
+func (st *Store) GetFeeMarketOptions() (*fees.MarketOptions, error) {
+	marketOptions := &fees.MarketOptions{}
+	luh, err := st.GetUnversioned(LAST_UPDATE_HEIGHT, LAST_UPDATE_HEIGHT_FEE_MARKET)
+	if err != nil {
+		return nil, err
+	}
+	if len(luh) == 0 {
+		return marketOptions, nil
+	}
+
+	bytes, err := st.Get(ADMIN_FEE_MARKET_OPTION, LAST_UPDATE_HEIGHT_FEE_MARKET)
+	if err != nil {
+		return nil, err
+	}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(bytes, marketOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize fee market options")
+	}
+	return marketOptions, nil
+}
+
+func (st *Store) SetGasOptions(gasOptions storage.GasOptions) error {
+	bytes, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(gasOptions)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize gas options")
+	}
+	err = st.Set(ADMIN_GAS_OPTION, bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to set gas options")
+	}
+	return nil
+}
+
+// GetGasOptions returns the gas schedule, the chains started before the schedule was added to the governance use
+// the default one
+func (st *Store) GetGasOptions() (*storage.GasOptions, error) {
+	luh, err := st.GetUnversioned(LAST_UPDATE_HEIGHT, LAST_UPDATE_HEIGHT_GAS)
+	if err != nil {
+		return nil, err
+	}
+	if len(luh) == 0 {
+		gasOptions := storage.DefaultGasOptions()
+		return &gasOptions, nil
+	}
+
+	bytes, err := st.Get(ADMIN_GAS_OPTION, LAST_UPDATE_HEIGHT_GAS)
+	if err != nil {
+		return nil, err
+	}
+	gasOptions := &storage.GasOptions{}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(bytes, gasOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize gas options")
+	}
+	return gasOptions, nil
+}
+
+func (st *Store) SetTokenOptions(tokenOptions tokens.Options) error {
+	bytes, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(tokenOptions)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize token options")
+	}
+	err = st.Set(ADMIN_TOKEN_OPTION, bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to set token options")
+	}
+	return nil
+}
+
+// GetTokenOptions returns the token factory options, the creation of tokens by tx is disabled on the chains started
+// before the options were added
+func (st *Store) GetTokenOptions() (*tokens.Options, error) {
+	tokenOptions := &tokens.Options{CreationFee: *balance.NewAmount(0)}
+	luh, err := st.GetUnversioned(LAST_UPDATE_HEIGHT, LAST_UPDATE_HEIGHT_TOKEN)
+	if err != nil {
+		return nil, err
+	}
+	if len(luh) == 0 {
+		return tokenOptions, nil
+	}
+
+	bytes, err := st.Get(ADMIN_TOKEN_OPTION, LAST_UPDATE_HEIGHT_TOKEN)
+	if err != nil {
+		return nil, err
+	}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(bytes, tokenOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize token options")
+	}
+	return tokenOptions, nil
+}
+
 func (st *Store) GetPoolList() (map[string]keys.Address, error) {
 	poolList := map[string]keys.Address{}
 	propOpt, err := st.GetProposalOptions()
@@ -13,6 +13,8 @@ import (
 	"github.com/Oneledger/protocol/data/network_delegation"
 	"github.com/Oneledger/protocol/data/ons"
 	"github.com/Oneledger/protocol/data/rewards"
+	"github.com/Oneledger/protocol/data/tokens"
+	"github.com/Oneledger/protocol/storage"
 )
 
 type GovernanceState struct {
@@ -25,6 +27,10 @@ type GovernanceState struct {
 	DelegOptions    network_delegation.Options `json:"delegOptions"`
 	EvidenceOptions evidence.Options           `json:"evidenceOptions"`
 	RewardOptions   rewards.Options            `json:"rewardOptions"`
+
+	FeeMarketOptions fees.MarketOptions `json:"feeMarketOptions"`
+	GasOptions       storage.GasOptions `json:"gasOptions"`
+	TokenOptions     tokens.Options     `json:"tokenOptions"`
 }
 type (
 	ProposalID      string
@@ -0,0 +1,93 @@
+package governance
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/serialize"
+)
+
+const (
+	ADMIN_UPGRADE_PLAN string = "upgradeplan"
+
+	// applied upgrades, the height they were applied at is stored under the name of the upgrade
+	UPGRADE_DONE string = "upgradedone"
+)
+
+// UpgradePlan is the code change scheduled by a passed proposal. At the height of the plan the nodes stop,
+// unless they run the version the plan is named after, the checksum is the sha256 of the released binary.
+type UpgradePlan struct {
+	Name       string     `json:"name"`
+	Height     int64      `json:"height"`
+	Checksum   string     `json:"checksum"`
+	ProposalID ProposalID `json:"proposalId,omitempty"`
+}
+
+func (p UpgradePlan) Validate() error {
+	if len(p.Name) == 0 {
+		return errors.Wrap(ErrInvalidUpgradePlan, "missing name")
+	}
+	if p.Height <= 0 {
+		return errors.Wrap(ErrInvalidUpgradePlan, "invalid height")
+	}
+	checksum, err := hex.DecodeString(p.Checksum)
+	if err != nil || len(checksum) != 32 {
+		return errors.Wrap(ErrInvalidUpgradePlan, "checksum must be a hex encoded sha256")
+	}
+	return nil
+}
+
+func (st *Store) SetUpgradePlan(plan UpgradePlan) error {
+	bytes, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(plan)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize upgrade plan")
+	}
+	err = st.SetUnversioned(ADMIN_UPGRADE_PLAN, HEIGHT_INDEPENDENT_VALUE, bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to set the upgrade plan")
+	}
+	return nil
+}
+
+// GetUpgradePlan returns the pending upgrade plan, nil if no upgrade is scheduled
+func (st *Store) GetUpgradePlan() (*UpgradePlan, error) {
+	bytes, err := st.GetUnversioned(ADMIN_UPGRADE_PLAN, HEIGHT_INDEPENDENT_VALUE)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes) == 0 {
+		return nil, nil
+	}
+	plan := &UpgradePlan{}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(bytes, plan)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize upgrade plan")
+	}
+	return plan, nil
+}
+
+// ClearUpgradePlan empties the upgrade plan, a deleted key doesn't read as empty until the block is committed
+func (st *Store) ClearUpgradePlan() error {
+	return st.SetUnversioned(ADMIN_UPGRADE_PLAN, HEIGHT_INDEPENDENT_VALUE, []byte{})
+}
+
+// SetUpgradeDone records the height the upgrade was applied at
+func (st *Store) SetUpgradeDone(name string, height int64) error {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(height))
+	return st.SetUnversioned(name, UPGRADE_DONE, b)
+}
+
+// GetUpgradeHeight returns the height the upgrade was applied at, 0 if it hasn't been applied
+func (st *Store) GetUpgradeHeight(name string) (int64, error) {
+	data, err := st.GetUnversioned(name, UPGRADE_DONE)
+	if err != nil {
+		return 0, err
+	}
+	if len(data) == 0 {
+		return 0, nil
+	}
+	return int64(binary.LittleEndian.Uint64(data)), nil
+}
@@ -0,0 +1,58 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/storage"
+)
+
+const testChecksum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func TestUpgradePlan_Validate(t *testing.T) {
+	plan := UpgradePlan{Name: "v0.19.0", Height: 100, Checksum: testChecksum}
+	assert.NoError(t, plan.Validate())
+
+	plan.Checksum = "1234"
+	assert.Error(t, plan.Validate())
+
+	plan = UpgradePlan{Name: "", Height: 100, Checksum: testChecksum}
+	assert.Error(t, plan.Validate())
+
+	plan = UpgradePlan{Name: "v0.19.0", Height: 0, Checksum: testChecksum}
+	assert.Error(t, plan.Validate())
+}
+
+func TestStore_UpgradePlan(t *testing.T) {
+	cs := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewStore("g", cs)
+
+	plan, err := store.GetUpgradePlan()
+	assert.NoError(t, err)
+	assert.Nil(t, plan)
+
+	expected := UpgradePlan{Name: "v0.19.0", Height: 100, Checksum: testChecksum, ProposalID: "proposal"}
+	assert.NoError(t, store.SetUpgradePlan(expected))
+	cs.Commit()
+
+	plan, err = store.GetUpgradePlan()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, *plan)
+
+	assert.NoError(t, store.SetUpgradeDone(plan.Name, plan.Height))
+	assert.NoError(t, store.ClearUpgradePlan())
+	plan, err = store.GetUpgradePlan()
+	assert.NoError(t, err)
+	assert.Nil(t, plan)
+	cs.Commit()
+
+	plan, err = store.GetUpgradePlan()
+	assert.NoError(t, err)
+	assert.Nil(t, plan)
+
+	height, err := store.GetUpgradeHeight("v0.19.0")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), height)
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/Oneledger/protocol/data/network_delegation"
 	"github.com/Oneledger/protocol/data/ons"
 	"github.com/Oneledger/protocol/data/rewards"
+	"github.com/Oneledger/protocol/data/tokens"
+	"github.com/Oneledger/protocol/storage"
 )
 
 var (
@@ -58,6 +60,16 @@ var (
 	//FEE
 	minFeeDecimal = int64(0)
 	maxFeeDecimal = int64(18)
+	//Fee market
+	minTargetUtilisation        = int64(1)
+	maxTargetUtilisation        = int64(100)
+	minBaseFeeChangeDenominator = int64(1)
+	maxBaseFeeChangeDenominator = int64(1000)
+	//Gas
+	minGasCost       = int64(1)
+	maxGasCost       = int64(1_000_000)
+	minGasMultiplier = int64(1)
+	maxGasMultiplier = int64(10_000)
 	//ETH
 	minBlockConfirmation = int64(0)
 	maxBlockConfirmation = int64(50)
@@ -79,7 +91,7 @@ var (
 	// can be between 0 -100, PenaltyBurnPercentage + PenaltyBountyPercentage is always 100
 )
 
-func (st *Store) ValidateGov(govstate GovernanceState) (bool, error) {
+func (st *Store) ValidateGov(govstate GovernanceState, blockGasLimit int64) (bool, error) {
 	ok, err := st.ValidateONS(&govstate.ONSOptions)
 	if err != nil || !ok {
 		return false, err
@@ -112,6 +124,18 @@ func (st *Store) ValidateGov(govstate GovernanceState) (bool, error) {
 	if err != nil || !ok {
 		return false, err
 	}
+	ok, err = st.ValidateFeeMarket(&govstate.FeeMarketOptions, blockGasLimit)
+	if err != nil || !ok {
+		return false, err
+	}
+	ok, err = st.ValidateGas(&govstate.GasOptions)
+	if err != nil || !ok {
+		return false, err
+	}
+	ok, err = st.ValidateToken(&govstate.TokenOptions)
+	if err != nil || !ok {
+		return false, err
+	}
 	return true, nil
 }
 
@@ -152,6 +176,67 @@ func (st *Store) ValidateFee(opt *fees.FeeOption) (bool, error) {
 	return true, nil
 }
 
+// ValidateFeeMarket checks the fee market options, the base fee can only move with the gas usage of the blocks when
+// they have a gas limit
+func (st *Store) ValidateFeeMarket(opt *fees.MarketOptions, blockGasLimit int64) (bool, error) {
+	if !opt.Enabled {
+		return true, nil
+	}
+	if blockGasLimit <= 0 {
+		return false, errors.New("the fee market needs a positive block max gas")
+	}
+	if !verifyRangeInt64(opt.TargetUtilisation, minTargetUtilisation, maxTargetUtilisation) {
+		return false, errors.New("target utilisation should be between 1 and 100")
+	}
+	if !verifyRangeInt64(opt.BaseFeeChangeDenominator, minBaseFeeChangeDenominator, maxBaseFeeChangeDenominator) {
+		return false, errors.New("base fee change denominator should be between 1 and 1000")
+	}
+	if len(opt.BaseFeeAddress) != 0 && opt.BaseFeeAddress.Err() != nil {
+		return false, errors.Wrap(opt.BaseFeeAddress.Err(), "base fee address")
+	}
+	return true, nil
+}
+
+func (st *Store) ValidateToken(opt *tokens.Options) (bool, error) {
+	if opt.CreationFee.BigInt().Sign() < 0 {
+		return false, errors.Wrap(tokens.ErrInvalidOptions, "creation fee can't be negative")
+	}
+	return true, nil
+}
+
+func (st *Store) ValidateGas(opt *storage.GasOptions) (bool, error) {
+	costs := []struct {
+		name string
+		gas  storage.Gas
+	}{
+		{"store bytes", opt.Schedule.StoreBytes},
+		{"read flat", opt.Schedule.ReadFlat},
+		{"read bytes", opt.Schedule.ReadBytes},
+		{"write flat", opt.Schedule.WriteFlat},
+		{"write bytes", opt.Schedule.WriteBytes},
+		{"verify sig", opt.Schedule.VerifySig},
+		{"hash bytes", opt.Schedule.HashBytes},
+		{"check exist", opt.Schedule.CheckExist},
+		{"delete", opt.Schedule.Delete},
+	}
+	for _, cost := range costs {
+		if !verifyRangeInt64(int64(cost.gas), minGasCost, maxGasCost) {
+			return false, errors.Errorf("%s gas should be between %d and %d", cost.name, minGasCost, maxGasCost)
+		}
+	}
+	txTypes := make(map[int]bool)
+	for _, m := range opt.TxMultipliers {
+		if txTypes[m.TxType] {
+			return false, errors.Errorf("duplicate gas multiplier for tx type %d", m.TxType)
+		}
+		txTypes[m.TxType] = true
+		if !verifyRangeInt64(m.Percent, minGasMultiplier, maxGasMultiplier) {
+			return false, errors.Errorf("gas multiplier should be between %d and %d percent", minGasMultiplier, maxGasMultiplier)
+		}
+	}
+	return true, nil
+}
+
 func (st *Store) ValidateStaking(opt *delegation.Options) (bool, error) {
 	ok, err := opt.MinSelfDelegationAmount.CheckInRange(*minSelfDelegationAmount, *maxSelfDelegationAmount)
 	if err != nil || !ok {
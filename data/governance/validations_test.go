@@ -64,7 +64,7 @@ func init() {
 }
 
 func TestStore_ValidateGov(t *testing.T) {
-	ok, err := vStore.ValidateGov(*generateGov())
+	ok, err := vStore.ValidateGov(*generateGov(), 1000000)
 
 	assert.NoError(t, err, "Should Pass")
 	assert.True(t, ok)
@@ -241,6 +241,57 @@ func TestStore_ValidateONS(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestStore_ValidateFeeMarket(t *testing.T) {
+	opt := fees.MarketOptions{}
+	ok, err := vStore.ValidateFeeMarket(&opt, 1000000)
+	assert.NoError(t, err, "Disabled market isn't checked")
+	assert.True(t, ok)
+	opt = fees.MarketOptions{Enabled: true, TargetUtilisation: 0, BaseFeeChangeDenominator: 8}
+	ok, err = vStore.ValidateFeeMarket(&opt, 1000000)
+	assert.Error(t, err, "Target utilisation too low")
+	assert.False(t, ok)
+	opt = fees.MarketOptions{Enabled: true, TargetUtilisation: 50, BaseFeeChangeDenominator: 0}
+	ok, err = vStore.ValidateFeeMarket(&opt, 1000000)
+	assert.Error(t, err, "Denominator too low")
+	assert.False(t, ok)
+	opt = fees.MarketOptions{Enabled: true, TargetUtilisation: 50, BaseFeeChangeDenominator: 8}
+	ok, err = vStore.ValidateFeeMarket(&opt, -1)
+	assert.Error(t, err, "Unlimited block gas")
+	assert.False(t, ok)
+	ok, err = vStore.ValidateFeeMarket(&opt, 0)
+	assert.Error(t, err, "No block gas limit")
+	assert.False(t, ok)
+	ok, err = vStore.ValidateFeeMarket(&opt, 1000000)
+	assert.NoError(t, err, "Should Pass")
+	assert.True(t, ok)
+}
+
+func TestStore_ValidateGas(t *testing.T) {
+	opt := storage.DefaultGasOptions()
+	ok, err := vStore.ValidateGas(&opt)
+	assert.NoError(t, err, "Default schedule should Pass")
+	assert.True(t, ok)
+	opt.Schedule.ReadFlat = 0
+	ok, err = vStore.ValidateGas(&opt)
+	assert.Error(t, err, "Free reads")
+	assert.False(t, ok)
+	opt = storage.DefaultGasOptions()
+	opt.SetMultiplier(1, 0)
+	ok, err = vStore.ValidateGas(&opt)
+	assert.Error(t, err, "Multiplier too low")
+	assert.False(t, ok)
+	opt = storage.DefaultGasOptions()
+	opt.TxMultipliers = []storage.TxGasMultiplier{{TxType: 1, Percent: 150}, {TxType: 1, Percent: 200}}
+	ok, err = vStore.ValidateGas(&opt)
+	assert.Error(t, err, "Duplicate multiplier")
+	assert.False(t, ok)
+	opt = storage.DefaultGasOptions()
+	opt.SetMultiplier(1, 150)
+	ok, err = vStore.ValidateGas(&opt)
+	assert.NoError(t, err, "Should Pass")
+	assert.True(t, ok)
+}
+
 func TestStore_ValidateFee(t *testing.T) {
 	updates := generateGov()
 	updates.FeeOption.MinFeeDecimal = 20
@@ -517,5 +568,6 @@ func generateGov() *GovernanceState {
 		StakingOptions:  stakingOption,
 		RewardOptions:   rewzOpt,
 		EvidenceOptions: evidenceOption,
+		GasOptions:      storage.DefaultGasOptions(),
 	}
 }
@@ -0,0 +1,149 @@
+package multisig
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+const (
+	// MaxSigners is the largest number of signers of a multisig account
+	MaxSigners = 16
+
+	// addressDomain is the message of the policy the address is derived from, it keeps the account addresses apart
+	// from the addresses of the other multisigs
+	addressDomain = "multisig_account"
+)
+
+// Account is an on-chain k-of-n account, its transactions need the signatures of Threshold of its Signers
+type Account struct {
+	Address   keys.Address   `json:"address"`
+	Threshold int            `json:"threshold"`
+	Signers   []keys.Address `json:"signers"`
+}
+
+// NewAccount returns the account of the threshold policy, the signers are sorted so the same policy always has the
+// same address
+func NewAccount(threshold int, signers []keys.Address) (*Account, error) {
+	if len(signers) == 0 || len(signers) > MaxSigners {
+		return nil, errors.Wrapf(ErrInvalidPolicy, "%d signers, expected between 1 and %d", len(signers), MaxSigners)
+	}
+	if threshold <= 0 || threshold > len(signers) {
+		return nil, errors.Wrapf(ErrInvalidPolicy, "threshold %d of %d signers", threshold, len(signers))
+	}
+	sorted := sortSigners(signers)
+	for i, signer := range sorted {
+		if err := signer.Err(); err != nil {
+			return nil, errors.Wrap(ErrInvalidPolicy, err.Error())
+		}
+		if i > 0 && sorted[i-1].Equal(signer) {
+			return nil, errors.Wrapf(ErrInvalidPolicy, "duplicate signer %s", signer)
+		}
+	}
+	return &Account{
+		Address:   AccountAddress(threshold, sorted),
+		Threshold: threshold,
+		Signers:   sorted,
+	}, nil
+}
+
+// AccountAddress derives the address of the multisig account from its threshold policy
+func AccountAddress(threshold int, signers []keys.Address) keys.Address {
+	policy := keys.MultiSig{
+		Msg:     []byte(addressDomain),
+		M:       threshold,
+		Signers: sortSigners(signers),
+	}
+	return policy.Address()
+}
+
+func sortSigners(signers []keys.Address) []keys.Address {
+	sorted := make([]keys.Address, len(signers))
+	copy(sorted, signers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+	return sorted
+}
+
+// NewBundle returns an empty signature bundle for the account, the signers add their signatures over msg to it
+func (a *Account) NewBundle(msg []byte) (*keys.MultiSig, error) {
+	bundle := &keys.MultiSig{}
+	err := bundle.Init(msg, a.Threshold, a.Signers)
+	if err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// Verify checks the bundle has the signatures over msg of at least the threshold of signers of the account
+func (a *Account) Verify(msg []byte, bundle keys.MultiSig) error {
+	if bundle.M != a.Threshold || len(bundle.Signers) != len(a.Signers) {
+		return ErrPolicyMismatch
+	}
+	for i := range a.Signers {
+		if !a.Signers[i].Equal(bundle.Signers[i]) {
+			return ErrPolicyMismatch
+		}
+	}
+
+	signed := make(map[int]bool)
+	for _, s := range bundle.Signatures {
+		if len(s.Signed) == 0 {
+			continue
+		}
+		if s.Index < 0 || s.Index >= len(a.Signers) || signed[s.Index] {
+			return errors.Wrapf(ErrInvalidSignature, "index %d", s.Index)
+		}
+		h, err := s.PubKey.GetHandler()
+		if err != nil {
+			return errors.Wrap(ErrInvalidSignature, err.Error())
+		}
+		if !h.Address().Equal(a.Signers[s.Index]) || !h.VerifyBytes(msg, s.Signed) {
+			return errors.Wrapf(ErrInvalidSignature, "signer %s", a.Signers[s.Index])
+		}
+		signed[s.Index] = true
+	}
+	if len(signed) < a.Threshold {
+		return errors.Wrapf(ErrNotEnoughSignatures, "%d of %d", len(signed), a.Threshold)
+	}
+	return nil
+}
+
+// Combine merges the signatures of the partially signed bundles, they must be bundles of the same policy
+func Combine(bundles ...keys.MultiSig) (*keys.MultiSig, error) {
+	if len(bundles) == 0 {
+		return nil, errors.New("no bundle to combine")
+	}
+	combined := &keys.MultiSig{}
+	err := combined.Init(bundles[0].Msg, bundles[0].M, bundles[0].Signers)
+	if err != nil {
+		return nil, err
+	}
+	for _, bundle := range bundles {
+		if bundle.M != combined.M || len(bundle.Signers) != len(combined.Signers) || !bytes.Equal(bundle.Msg, combined.Msg) {
+			return nil, ErrPolicyMismatch
+		}
+		for i := range bundle.Signers {
+			if !bundle.Signers[i].Equal(combined.Signers[i]) {
+				return nil, ErrPolicyMismatch
+			}
+		}
+		for _, s := range bundle.Signatures {
+			if len(s.Signed) == 0 {
+				continue
+			}
+			if s.Index < 0 || s.Index >= len(combined.Signers) {
+				return nil, errors.Wrapf(ErrInvalidSignature, "index %d", s.Index)
+			}
+			err := combined.AddSignature(s)
+			if err != nil {
+				return nil, errors.Wrapf(ErrInvalidSignature, "index %d: %s", s.Index, err)
+			}
+		}
+	}
+	return combined, nil
+}
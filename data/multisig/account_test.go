@@ -0,0 +1,113 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+type signer struct {
+	pub  keys.PublicKey
+	priv keys.PrivateKey
+	addr keys.Address
+}
+
+func newSigners(t *testing.T, n int) []signer {
+	signers := make([]signer, n)
+	for i := range signers {
+		pub, priv, err := keys.NewKeyPairFromTendermint()
+		require.NoError(t, err)
+		h, err := pub.GetHandler()
+		require.NoError(t, err)
+		signers[i] = signer{pub: pub, priv: priv, addr: h.Address()}
+	}
+	return signers
+}
+
+func sign(t *testing.T, account *Account, s signer, msg []byte) keys.Signature {
+	h, err := s.priv.GetHandler()
+	require.NoError(t, err)
+	signed, err := h.Sign(msg)
+	require.NoError(t, err)
+	index := 0
+	for i, addr := range account.Signers {
+		if addr.Equal(s.addr) {
+			index = i
+		}
+	}
+	return keys.Signature{Index: index, PubKey: s.pub, Signed: signed}
+}
+
+func TestNewAccount(t *testing.T) {
+	signers := newSigners(t, 3)
+	addrs := []keys.Address{signers[0].addr, signers[1].addr, signers[2].addr}
+
+	account, err := NewAccount(2, addrs)
+	require.NoError(t, err)
+	assert.NoError(t, account.Address.Err())
+
+	// the address doesn't depend on the order of the signers
+	reversed, err := NewAccount(2, []keys.Address{addrs[2], addrs[1], addrs[0]})
+	require.NoError(t, err)
+	assert.Equal(t, account.Address, reversed.Address)
+
+	other, err := NewAccount(3, addrs)
+	require.NoError(t, err)
+	assert.NotEqual(t, account.Address, other.Address)
+
+	_, err = NewAccount(0, addrs)
+	assert.Error(t, err)
+	_, err = NewAccount(4, addrs)
+	assert.Error(t, err)
+	_, err = NewAccount(1, []keys.Address{addrs[0], addrs[0]})
+	assert.Error(t, err)
+}
+
+func TestAccount_Verify(t *testing.T) {
+	signers := newSigners(t, 3)
+	account, err := NewAccount(2, []keys.Address{signers[0].addr, signers[1].addr, signers[2].addr})
+	require.NoError(t, err)
+	msg := []byte("raw tx")
+
+	first, err := account.NewBundle(msg)
+	require.NoError(t, err)
+	require.NoError(t, first.AddSignature(sign(t, account, signers[0], msg)))
+	assert.Equal(t, ErrNotEnoughSignatures, errors.Cause(account.Verify(msg, *first)))
+
+	second, err := account.NewBundle(msg)
+	require.NoError(t, err)
+	require.NoError(t, second.AddSignature(sign(t, account, signers[2], msg)))
+
+	combined, err := Combine(*first, *second)
+	require.NoError(t, err)
+	assert.NoError(t, account.Verify(msg, *combined))
+	assert.Equal(t, ErrInvalidSignature, errors.Cause(account.Verify([]byte("other tx"), *combined)))
+
+	combined.M = 1
+	assert.Equal(t, ErrPolicyMismatch, account.Verify(msg, *combined))
+}
+
+func TestStore_Set(t *testing.T) {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewStore("msa", state)
+
+	signers := newSigners(t, 2)
+	account, err := NewAccount(2, []keys.Address{signers[0].addr, signers[1].addr})
+	require.NoError(t, err)
+
+	_, err = store.Get(account.Address)
+	assert.Equal(t, ErrAccountNotFound, err)
+
+	require.NoError(t, store.Set(account))
+	assert.Equal(t, ErrAccountExists, store.Set(account))
+
+	stored, err := store.Get(account.Address)
+	require.NoError(t, err)
+	assert.Equal(t, account, stored)
+}
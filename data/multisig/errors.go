@@ -0,0 +1,14 @@
+package multisig
+
+import (
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+var (
+	ErrAccountNotFound     = codes.ProtocolError{codes.MultiSigErrAccountNotFound, "multisig account not found"}
+	ErrAccountExists       = codes.ProtocolError{codes.MultiSigErrAccountExists, "multisig account already exists"}
+	ErrInvalidPolicy       = codes.ProtocolError{codes.MultiSigErrInvalidPolicy, "invalid multisig threshold policy"}
+	ErrPolicyMismatch      = codes.ProtocolError{codes.MultiSigErrPolicyMismatch, "signature bundle doesn't match the multisig account"}
+	ErrInvalidSignature    = codes.ProtocolError{codes.MultiSigErrInvalidSignature, "invalid signature in the multisig bundle"}
+	ErrNotEnoughSignatures = codes.ProtocolError{codes.MultiSigErrNotEnoughSignatures, "not enough signatures to reach the multisig threshold"}
+)
@@ -0,0 +1,67 @@
+package multisig
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// Store keeps the multisig accounts by address
+type Store struct {
+	state  *storage.State
+	szlr   serialize.Serializer
+	prefix []byte
+}
+
+func NewStore(prefix string, state *storage.State) *Store {
+	return &Store{
+		state:  state,
+		prefix: storage.Prefix(prefix),
+		szlr:   serialize.GetSerializer(serialize.PERSISTENT),
+	}
+}
+
+func (st *Store) WithState(state *storage.State) *Store {
+	st.state = state
+	return st
+}
+
+func (st *Store) getKey(addr keys.Address) storage.StoreKey {
+	return storage.StoreKey(string(st.prefix) + addr.String())
+}
+
+// Get returns the multisig account at the address, ErrAccountNotFound if there is none
+func (st *Store) Get(addr keys.Address) (*Account, error) {
+	dat, err := st.state.Get(st.getKey(addr))
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) == 0 {
+		return nil, ErrAccountNotFound
+	}
+	account := &Account{}
+	err = st.szlr.Deserialize(dat, account)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize multisig account")
+	}
+	return account, nil
+}
+
+// Exists tells whether there is a multisig account at the address
+func (st *Store) Exists(addr keys.Address) bool {
+	return st.state.Exists(st.getKey(addr))
+}
+
+// Set registers the multisig account, the threshold policy of an account never changes
+func (st *Store) Set(account *Account) error {
+	if st.Exists(account.Address) {
+		return ErrAccountExists
+	}
+	dat, err := st.szlr.Serialize(account)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize multisig account")
+	}
+	return st.state.Set(st.getKey(account.Address), dat)
+}
@@ -13,4 +13,6 @@ var (
 	ErrGettingPendingDelgAmount      = codes.ProtocolError{codes.NetDelgErrGettingPendingDelgAmount, "failed to get pending network delegation amount"}
 	ErrInitiateWithdrawal            = codes.ProtocolError{codes.NetDelgErrWithdraw, "failed to initiate rewards withdrawal"}
 	ErrReinvestRewards               = codes.ProtocolError{codes.NetDelgErrReinvest, "failed to reinvest rewards"}
+	ErrValidatorNotFound             = codes.ProtocolError{codes.NetDelgErrValidatorNotFound, "delegation validator not found"}
+	ErrValidatorMismatch             = codes.ProtocolError{codes.NetDelgErrValidatorMismatch, "active delegation goes to another validator"}
 )
@@ -0,0 +1,304 @@
+package network_delegation
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// The rewards of the delegators are distributed lazily: every block adds the rewards per delegated unit to a
+// cumulative index, the share of a delegator is its stake multiplied by the growth of the index since it was last
+// settled. The share is settled into the rewards balance when the stake changes and before the rewards are taken.
+// The delegators who picked a validator share an index of the validator, the others the index of the pool.
+
+// RewardIndexPrecision scales the cumulative index so the rounding stays below a unit of reward for any stake
+var RewardIndexPrecision = new(big.Int).Exp(big.NewInt(10), big.NewInt(36), nil)
+
+// DelegStake is the stake the rewards of a delegator are computed from and the index it was last settled at, the
+// index is the one of the validator picked by the delegator if any
+type DelegStake struct {
+	Stake     balance.Amount `json:"stake"`
+	Index     balance.Amount `json:"index"`
+	Validator keys.Address   `json:"validator,omitempty"`
+}
+
+// pending returns the rewards earned by the stake since it was last settled
+func (ds *DelegStake) pending(index *big.Int) *balance.Amount {
+	growth := new(big.Int).Sub(index, ds.Index.BigInt())
+	rewards := growth.Mul(growth, ds.Stake.BigInt())
+	return balance.NewAmountFromBigInt(rewards.Div(rewards, RewardIndexPrecision))
+}
+
+// AccumulateRewards adds the rewards of the block to the cumulative index, the power is the amount delegated in the
+// pool the rewards are shared by
+func (drs *DelegRewardStore) AccumulateRewards(rewards *balance.Amount, power *big.Int) error {
+	if power.Sign() <= 0 {
+		return nil
+	}
+	index, err := drs.GetRewardIndex()
+	if err != nil {
+		return err
+	}
+	perUnit := new(big.Int).Mul(rewards.BigInt(), RewardIndexPrecision)
+	perUnit.Div(perUnit, power)
+	return drs.set(drs.getRewardIndexKey(), balance.NewAmountFromBigInt(perUnit.Add(perUnit, index)))
+}
+
+// AccumulateValidatorRewards adds the rewards of the delegators who picked the validator to the cumulative index of
+// the validator, the power is the amount they delegated
+func (drs *DelegRewardStore) AccumulateValidatorRewards(validator keys.Address, rewards *balance.Amount, power *big.Int) error {
+	if power.Sign() <= 0 {
+		return nil
+	}
+	index, err := drs.getIndex(validator)
+	if err != nil {
+		return err
+	}
+	perUnit := new(big.Int).Mul(rewards.BigInt(), RewardIndexPrecision)
+	perUnit.Div(perUnit, power)
+	err = drs.set(drs.getValidatorRewardIndexKey(validator), balance.NewAmountFromBigInt(perUnit.Add(perUnit, index)))
+	if err != nil {
+		return err
+	}
+
+	// no stake tracks the total of these delegators, their rewards are added to the total at once
+	total, err := drs.get(drs.getTotalRewardsKey())
+	if err != nil {
+		return err
+	}
+	return drs.set(drs.getTotalRewardsKey(), total.Plus(*rewards))
+}
+
+// GetRewardIndex returns the cumulative rewards per delegated unit of the pool, scaled by RewardIndexPrecision
+func (drs *DelegRewardStore) GetRewardIndex() (*big.Int, error) {
+	return drs.getIndex(nil)
+}
+
+// SetStake settles the rewards of the delegator and sets the stake its next rewards are computed from, the validator
+// is the one picked by the delegator, nil for the pool. It is called every time the active delegation of the
+// delegator changes.
+func (drs *DelegRewardStore) SetStake(delegator keys.Address, validator keys.Address, stake *balance.Amount) error {
+	old, err := drs.settle(delegator)
+	if err != nil {
+		return err
+	}
+	index, err := drs.getIndex(validator)
+	if err != nil {
+		return err
+	}
+	err = drs.setStake(drs.getStakeKey(delegator), &DelegStake{
+		Stake:     *stake,
+		Index:     *balance.NewAmountFromBigInt(index),
+		Validator: validator,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the total rewards are settled the same way, with the stake of all the delegators of the pool
+	poolIndex, err := drs.GetRewardIndex()
+	if err != nil {
+		return err
+	}
+	total, err := drs.settleTotal(poolIndex)
+	if err != nil {
+		return err
+	}
+	totalStake := new(big.Int).Set(total.Stake.BigInt())
+	if len(old.Validator) == 0 {
+		totalStake.Sub(totalStake, old.Stake.BigInt())
+	}
+	if len(validator) == 0 {
+		totalStake.Add(totalStake, stake.BigInt())
+	}
+	total.Stake = *balance.NewAmountFromBigInt(totalStake)
+	return drs.setStake(drs.getTotalStakeKey(), total)
+}
+
+// GetStake returns the stake of the delegator, a zero stake if it has none
+func (drs *DelegRewardStore) GetStake(delegator keys.Address) (*DelegStake, error) {
+	return drs.getStake(drs.getStakeKey(delegator))
+}
+
+// settle adds the rewards earned since the last settlement to the rewards balance of the delegator, it returns the
+// stake of the delegator
+func (drs *DelegRewardStore) settle(delegator keys.Address) (*DelegStake, error) {
+	key := drs.getStakeKey(delegator)
+	stake, err := drs.getStake(key)
+	if err != nil {
+		return nil, err
+	}
+	index, err := drs.getIndex(stake.Validator)
+	if err != nil {
+		return nil, err
+	}
+	if stake.Index.BigInt().Cmp(index) == 0 {
+		return stake, nil
+	}
+
+	rewards := stake.pending(index)
+	if !rewards.IsZero() {
+		balanceKey := drs.getRewardsBalanceKey(delegator)
+		amt, err := drs.get(balanceKey)
+		if err != nil {
+			return nil, err
+		}
+		err = drs.set(balanceKey, amt.Plus(*rewards))
+		if err != nil {
+			return nil, err
+		}
+	}
+	stake.Index = *balance.NewAmountFromBigInt(index)
+	return stake, drs.setStake(key, stake)
+}
+
+// settleTotal adds the rewards earned by all the delegators since the last settlement to the total rewards
+func (drs *DelegRewardStore) settleTotal(index *big.Int) (*DelegStake, error) {
+	total, err := drs.getStake(drs.getTotalStakeKey())
+	if err != nil {
+		return nil, err
+	}
+	rewards := total.pending(index)
+	if !rewards.IsZero() {
+		amt, err := drs.get(drs.getTotalRewardsKey())
+		if err != nil {
+			return nil, err
+		}
+		err = drs.set(drs.getTotalRewardsKey(), amt.Plus(*rewards))
+		if err != nil {
+			return nil, err
+		}
+	}
+	total.Index = *balance.NewAmountFromBigInt(index)
+	return total, nil
+}
+
+// pendingRewards returns the rewards earned by the delegator which are not settled yet
+func (drs *DelegRewardStore) pendingRewards(key storage.StoreKey) (*balance.Amount, error) {
+	stake, err := drs.getStake(key)
+	if err != nil {
+		return nil, err
+	}
+	if stake.Stake.IsZero() {
+		return balance.NewAmount(0), nil
+	}
+	index, err := drs.getIndex(stake.Validator)
+	if err != nil {
+		return nil, err
+	}
+	return stake.pending(index), nil
+}
+
+// getIndex returns the cumulative index of the validator, the one of the pool for a nil validator
+func (drs *DelegRewardStore) getIndex(validator keys.Address) (*big.Int, error) {
+	key := drs.getRewardIndexKey()
+	if len(validator) != 0 {
+		key = drs.getValidatorRewardIndexKey(validator)
+	}
+	index, err := drs.get(key)
+	if err != nil {
+		return nil, err
+	}
+	return index.BigInt(), nil
+}
+
+// MigrateToRewardIndex records the active delegations as the stakes of the delegators, the rewards balances
+// distributed so far are kept as they are
+func (drs *DelegRewardStore) MigrateToRewardIndex(deleg *Store) error {
+	stakes := make(map[string]*balance.Amount)
+	addresses := make([]keys.Address, 0)
+	deleg.IterateActiveAmounts(func(addr *keys.Address, coin *balance.Coin) bool {
+		if coin.Amount.IsZero() {
+			return false
+		}
+		stakes[addr.String()] = coin.Amount
+		addresses = append(addresses, *addr)
+		return false
+	})
+
+	for _, addr := range addresses {
+		validator, err := deleg.GetValidator(addr)
+		if err != nil {
+			return err
+		}
+		err = drs.SetStake(addr, validator, stakes[addr.String()])
+		if err != nil {
+			return errors.Wrapf(err, "failed to set the stake of %s", addr)
+		}
+	}
+	return nil
+}
+
+func (drs *DelegRewardStore) setStake(key storage.StoreKey, stake *DelegStake) error {
+	return drs.set(key, stake)
+}
+
+func (drs *DelegRewardStore) getStake(key storage.StoreKey) (*DelegStake, error) {
+	stake := &DelegStake{Stake: *balance.NewAmount(0), Index: *balance.NewAmount(0)}
+	dat, err := drs.state.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) == 0 {
+		return stake, nil
+	}
+	err = drs.szlr.Deserialize(dat, stake)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize delegator stake")
+	}
+	return stake, nil
+}
+
+// Key for the cumulative rewards per delegated unit
+func (drs *DelegRewardStore) getRewardIndexKey() []byte {
+	key := fmt.Sprintf("%sreward_index", string(drs.prefix))
+	return storage.StoreKey(key)
+}
+
+// Key for the cumulative rewards per delegated unit of a validator
+func (drs *DelegRewardStore) getValidatorRewardIndexKey(validator keys.Address) []byte {
+	key := fmt.Sprintf("%sreward_index_%s", string(drs.prefix), validator)
+	return storage.StoreKey(key)
+}
+
+// Key for delegator stake
+func (drs *DelegRewardStore) getStakeKey(delegator keys.Address) []byte {
+	key := fmt.Sprintf("%sstake_%s", string(drs.prefix), delegator)
+	return storage.StoreKey(key)
+}
+
+// Key for the stake of all the delegators
+func (drs *DelegRewardStore) getTotalStakeKey() []byte {
+	key := fmt.Sprintf("%stotal_stake", string(drs.prefix))
+	return storage.StoreKey(key)
+}
+
+// iterate the stakes of the delegators
+func (drs *DelegRewardStore) iterateStakes(fn func(delegator keys.Address, stake *DelegStake) bool) (stopped bool) {
+	prefix := append(drs.prefix, storage.Prefix("stake")...)
+	return drs.state.IterateRange(
+		prefix,
+		storage.Rangefix(string(prefix)),
+		true,
+		func(key, value []byte) bool {
+			stake := &DelegStake{}
+			err := drs.szlr.Deserialize(value, stake)
+			if err != nil {
+				logger.Error("failed to deserialize delegator stake")
+				return true
+			}
+			addr := keys.Address{}
+			err = addr.UnmarshalText(key[len(prefix):])
+			if err != nil {
+				logger.Error("failed to deserialize delegator address")
+				return true
+			}
+			return fn(addr, stake)
+		},
+	)
+}
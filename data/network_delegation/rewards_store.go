@@ -54,22 +54,43 @@ func (drs *DelegRewardStore) AddRewardsBalance(delegator keys.Address, amount *b
 	return err
 }
 
-// Get rewards balance
+// Get rewards balance, the rewards not settled yet included
 func (drs *DelegRewardStore) GetRewardsBalance(delegator keys.Address) (amt *balance.Amount, err error) {
 	key := drs.getRewardsBalanceKey(delegator)
 	amt, err = drs.get(key)
+	if err != nil {
+		return
+	}
+	pending, err := drs.pendingRewards(drs.getStakeKey(delegator))
+	if err != nil {
+		return
+	}
+	amt = amt.Plus(*pending)
 	return
 }
 
-// Get total rewards
+// Get total rewards, the rewards not settled yet included
 func (drs *DelegRewardStore) GetTotalRewards() (amt *balance.Amount, err error) {
 	key := drs.getTotalRewardsKey()
 	amt, err = drs.get(key)
+	if err != nil {
+		return
+	}
+	pending, err := drs.pendingRewards(drs.getTotalStakeKey())
+	if err != nil {
+		return
+	}
+	amt = amt.Plus(*pending)
 	return
 }
 
 // Deducts an 'amount' of rewards from rewards balance
 func (drs *DelegRewardStore) MinusRewardsBalance(delegator keys.Address, amount *balance.Amount) error {
+	_, err := drs.settle(delegator)
+	if err != nil {
+		return err
+	}
+
 	key := drs.getRewardsBalanceKey(delegator)
 	amt, err := drs.get(key)
 	if err != nil {
@@ -405,14 +426,33 @@ func (drs *DelegRewardStore) SaveState() (*RewardState, bool) {
 	balanceKey := string(storage.Prefix("balance"))
 	//matureKey := string(storage.Prefix("mature"))
 
-	//Populate Current Balances
+	//Populate Current Balances, with the rewards not settled yet
 	var balanceList []Reward
+	listed := make(map[string]bool)
 	drs.iterate(balanceKey, func(delegator keys.Address, amt *balance.Amount) bool {
+		pending, err := drs.pendingRewards(drs.getStakeKey(delegator))
+		if err != nil {
+			return true
+		}
 		reward := Reward{
-			Amount:  amt,
+			Amount:  amt.Plus(*pending),
 			Address: delegator,
 		}
 		balanceList = append(balanceList, reward)
+		listed[delegator.String()] = true
+		return false
+	})
+	drs.iterateStakes(func(delegator keys.Address, stake *DelegStake) bool {
+		if listed[delegator.String()] {
+			return false
+		}
+		pending, err := drs.pendingRewards(drs.getStakeKey(delegator))
+		if err != nil {
+			return true
+		}
+		if !pending.IsZero() {
+			balanceList = append(balanceList, Reward{Amount: pending, Address: delegator})
+		}
 		return false
 	})
 
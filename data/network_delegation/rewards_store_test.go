@@ -2,6 +2,7 @@ package network_delegation
 
 import (
 	"fmt"
+	"math/big"
 	"sort"
 	"testing"
 
@@ -329,3 +330,108 @@ func TestStore_IterateAllPendingAmounts(t *testing.T) {
 	})
 	assert.Equal(t, 4, count)
 }
+
+func TestDelegRewardStore_RewardIndex(t *testing.T) {
+	setup()
+	deleg := NewStore("deleg", cs)
+	olt := balance.Currency{Id: 0, Name: "OLT", Decimal: 18, Unit: "nue"}
+
+	// delegations and rewards from before the reward index
+	storeRwz.AddRewardsBalance(delegators[0], amt1)
+	stake0, stake1 := olt.NewCoinFromUnit(300), olt.NewCoinFromUnit(100)
+	deleg.WithPrefix(ActiveType).Set(delegators[0], &stake0)
+	deleg.WithPrefix(ActiveType).Set(delegators[1], &stake1)
+	storeRwz.state.Commit()
+	assert.Nil(t, storeRwz.MigrateToRewardIndex(deleg))
+	storeRwz.state.Commit()
+
+	// 400 delegated in a pool of 500, the pending delegation doesn't earn anything
+	assert.Nil(t, storeRwz.AccumulateRewards(balance.NewAmount(1000), big.NewInt(500)))
+	storeRwz.state.Commit()
+
+	rewards, err := storeRwz.GetRewardsBalance(delegators[0])
+	assert.Nil(t, err)
+	assert.Equal(t, balance.NewAmount(700), rewards)
+	rewards, err = storeRwz.GetRewardsBalance(delegators[1])
+	assert.Nil(t, err)
+	assert.Equal(t, balance.NewAmount(200), rewards)
+
+	// the rewards are settled with the stake before the change
+	assert.Nil(t, storeRwz.SetStake(delegators[1], nil, balance.NewAmount(400)))
+	assert.Nil(t, storeRwz.AccumulateRewards(balance.NewAmount(1000), big.NewInt(1000)))
+	storeRwz.state.Commit()
+
+	rewards, err = storeRwz.GetRewardsBalance(delegators[1])
+	assert.Nil(t, err)
+	assert.Equal(t, balance.NewAmount(600), rewards)
+
+	// the withdrawal takes from the settled rewards
+	assert.Nil(t, storeRwz.Withdraw(delegators[0], balance.NewAmount(1000), 10))
+	storeRwz.state.Commit()
+	rewards, err = storeRwz.GetRewardsBalance(delegators[0])
+	assert.Nil(t, err)
+	assert.Equal(t, balance.NewAmount(0), rewards)
+
+	total, err := storeRwz.GetTotalRewards()
+	assert.Nil(t, err)
+	assert.Equal(t, balance.NewAmount(100+600+300+200+400), total)
+}
+
+func TestDelegRewardStore_ValidatorRewardIndex(t *testing.T) {
+	setup()
+	deleg := NewStore("deleg", cs)
+	validator := genDelegAddress()
+	olt := balance.Currency{Id: 0, Name: "OLT", Decimal: 18, Unit: "nue"}
+
+	// the second delegator picked the validator
+	stake := olt.NewCoinFromUnit(100)
+	assert.Nil(t, deleg.SetValidator(delegators[1], validator))
+	assert.Nil(t, deleg.SetValidatorPower(validator, &stake))
+	assert.Nil(t, storeRwz.SetStake(delegators[0], nil, balance.NewAmount(300)))
+	assert.Nil(t, storeRwz.SetStake(delegators[1], validator, balance.NewAmount(100)))
+	storeRwz.state.Commit()
+
+	picked, err := deleg.GetValidator(delegators[1])
+	assert.Nil(t, err)
+	assert.Equal(t, validator, picked)
+	count := 0
+	deleg.IterateValidatorPowers(func(addr *keys.Address, coin *balance.Coin) bool {
+		assert.Equal(t, validator, *addr)
+		assert.Equal(t, stake.Amount, coin.Amount)
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+
+	// the pool and the validator have their own index
+	assert.Nil(t, storeRwz.AccumulateRewards(balance.NewAmount(300), big.NewInt(300)))
+	assert.Nil(t, storeRwz.AccumulateValidatorRewards(validator, balance.NewAmount(50), big.NewInt(100)))
+	storeRwz.state.Commit()
+
+	rewards, err := storeRwz.GetRewardsBalance(delegators[0])
+	assert.Nil(t, err)
+	assert.Equal(t, balance.NewAmount(300), rewards)
+	rewards, err = storeRwz.GetRewardsBalance(delegators[1])
+	assert.Nil(t, err)
+	assert.Equal(t, balance.NewAmount(50), rewards)
+
+	// back to the pool, the rewards of the validator are settled
+	assert.Nil(t, storeRwz.SetStake(delegators[1], nil, balance.NewAmount(100)))
+	assert.Nil(t, deleg.SetValidator(delegators[1], nil))
+	picked, err = deleg.GetValidator(delegators[1])
+	assert.Nil(t, err)
+	assert.Nil(t, picked)
+	assert.Nil(t, storeRwz.AccumulateRewards(balance.NewAmount(400), big.NewInt(400)))
+	storeRwz.state.Commit()
+
+	picked, err = deleg.GetValidator(delegators[1])
+	assert.Nil(t, err)
+	assert.Nil(t, picked)
+	rewards, err = storeRwz.GetRewardsBalance(delegators[1])
+	assert.Nil(t, err)
+	assert.Equal(t, balance.NewAmount(150), rewards)
+
+	total, err := storeRwz.GetTotalRewards()
+	assert.Nil(t, err)
+	assert.Equal(t, balance.NewAmount(750), total)
+}
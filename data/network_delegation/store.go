@@ -261,6 +261,22 @@ func (st *Store) LoadDelegators(state State) error {
 		if err != nil {
 			return err
 		}
+		if len(delegator.Validator) == 0 {
+			continue
+		}
+		err = st.SetValidator(*delegator.Address, delegator.Validator)
+		if err != nil {
+			return err
+		}
+		power, err := st.GetValidatorPower(delegator.Validator)
+		if err != nil {
+			return err
+		}
+		newPower := power.Plus(*delegator.Amount)
+		err = st.SetValidatorPower(delegator.Validator, &newPower)
+		if err != nil {
+			return err
+		}
 	}
 	////Load Mature Delegators
 	//for _, delegator := range state.MatureList {
@@ -10,8 +10,9 @@ type DelegationPrefixType int
 
 //------------------------ State Types ------------------------------
 type Delegator struct {
-	Address *keys.Address `json:"address"`
-	Amount  *balance.Coin `json:"amount"`
+	Address   *keys.Address `json:"address"`
+	Amount    *balance.Coin `json:"amount"`
+	Validator keys.Address  `json:"validator,omitempty"`
 }
 
 type PendingDelegator struct {
@@ -0,0 +1,75 @@
+package network_delegation
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+const (
+	ValidatorKey      = "v"
+	ValidatorPowerKey = "t"
+)
+
+// A delegator can pick the validator its delegation goes to, its rewards are then shared with the commission of the
+// validator. The delegators who didn't pick any stay in the pool.
+
+//------------------------------- Validator of delegator -------------------------------
+//build validator key
+func (st *Store) buildValidatorKey(delegator keys.Address) storage.StoreKey {
+	return storage.StoreKey(string(storage.Prefix(string(st.prefix)+storage.DB_PREFIX+ValidatorKey)) + delegator.String())
+}
+
+// GetValidator returns the validator picked by the delegator, nil when it delegates to the pool
+func (st *Store) GetValidator(delegator keys.Address) (keys.Address, error) {
+	dat, err := st.State.Get(st.buildValidatorKey(delegator))
+	if err != nil || len(dat) == 0 {
+		return nil, err
+	}
+	validator := keys.Address{}
+	err = st.szlr.Deserialize(dat, &validator)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize validator of delegator")
+	}
+	return validator, nil
+}
+
+// SetValidator sets the validator picked by the delegator, an empty validator moves it back to the pool
+func (st *Store) SetValidator(delegator keys.Address, validator keys.Address) error {
+	key := st.buildValidatorKey(delegator)
+	if len(validator) == 0 {
+		if !st.State.Exists(key) {
+			return nil
+		}
+		// emptied rather than deleted, a deleted key doesn't read as empty until the block is committed
+		return st.State.Set(key, []byte{})
+	}
+	dat, err := st.szlr.Serialize(validator)
+	if err != nil {
+		return err
+	}
+	return st.State.Set(key, dat)
+}
+
+//------------------------------- Delegated power of validator -------------------------------
+//build validator power key
+func (st *Store) buildValidatorPowerKey() storage.StoreKey {
+	return storage.Prefix(string(st.prefix) + storage.DB_PREFIX + ValidatorPowerKey)
+}
+
+// GetValidatorPower returns the active delegations of the delegators who picked the validator
+func (st *Store) GetValidatorPower(validator keys.Address) (*balance.Coin, error) {
+	return st.get(append(st.buildValidatorPowerKey(), validator.String()...))
+}
+
+// SetValidatorPower sets the active delegations of the delegators who picked the validator
+func (st *Store) SetValidatorPower(validator keys.Address, coin *balance.Coin) error {
+	return st.set(append(st.buildValidatorPowerKey(), validator.String()...), coin)
+}
+
+// IterateValidatorPowers iterates the validators picked by the delegators with their delegated power
+func (st *Store) IterateValidatorPowers(fn func(validator *keys.Address, coin *balance.Coin) bool) bool {
+	return st.iterateAddresses(st.buildValidatorPowerKey(), fn)
+}
@@ -37,6 +37,9 @@ type Domain struct {
 	URI        string `json:"uri"`
 	// the asking price in OLT set by the owner
 	SalePrice *balance.Amount `json:"salePrice"`
+
+	// resolver records, addresses on other chains, text records and the content hash
+	Records *Records `json:"records,omitempty"`
 }
 
 func NewDomain(ownerAddress, accountAddress keys.Address,
@@ -138,4 +141,5 @@ func (d *Domain) ResetAfterSale(buyer, account keys.Address, nBlocks, currentHei
 	d.ActiveFlag = true
 	d.URI = ""
 	d.OnSaleFlag = false
+	d.ResetRecords()
 }
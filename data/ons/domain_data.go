@@ -5,6 +5,8 @@
 package ons
 
 import (
+	"encoding/json"
+
 	"github.com/pkg/errors"
 
 	"github.com/Oneledger/protocol/data/balance"
@@ -23,6 +25,7 @@ type domainData struct {
 	OnSaleFlag       bool         `json:"h"`
 	SalePriceData    []byte       `json:"i"`
 	URI              string       `json:"k"`
+	RecordsData      []byte       `json:"l,omitempty"`
 }
 
 func (d *Domain) NewDataInstance() serialize.Data {
@@ -45,6 +48,9 @@ func (d *Domain) Data() serialize.Data {
 	if d.SalePrice != nil {
 		dd.SalePriceData, _ = d.SalePrice.MarshalJSON()
 	}
+	if d.Records != nil {
+		dd.RecordsData, _ = json.Marshal(d.Records)
+	}
 	return dd
 }
 
@@ -72,6 +78,16 @@ func (d *Domain) SetData(a interface{}) error {
 		}
 		d.SalePrice = amt
 	}
+
+	d.Records = nil
+	if cd.RecordsData != nil {
+		records := &Records{}
+		err := json.Unmarshal(cd.RecordsData, records)
+		if err != nil {
+			return err
+		}
+		d.Records = records
+	}
 	return nil
 }
 
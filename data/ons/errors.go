@@ -9,4 +9,8 @@ import "github.com/pkg/errors"
 var (
 	ErrDomainNameNotValid = errors.New("Domain name is invalid")
 	ErrDomainNotFound     = errors.New("Domain doesn't exist")
+	ErrInvalidRecordType  = errors.New("Record type is invalid")
+	ErrInvalidRecordKey   = errors.New("Record key is invalid")
+	ErrInvalidRecordValue = errors.New("Record value is invalid")
+	ErrTooManyRecords     = errors.New("Too many records for the domain")
 )
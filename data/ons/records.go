@@ -0,0 +1,159 @@
+/*
+
+ */
+
+package ons
+
+import (
+	"encoding/hex"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	"github.com/Oneledger/protocol/utils"
+)
+
+const (
+	// limits of the text records
+	MaxTextRecords     = 32
+	MaxTextKeyLength   = 64
+	MaxTextValueLength = 512
+
+	// the content hash is an encoded multihash, e.g. an ipfs or swarm hash
+	MaxContentHashLength = 128
+)
+
+// RecordType is the kind of a resolver record of a domain
+type RecordType string
+
+const (
+	// RecordAddress is the address of the domain on another chain, the key is the chain
+	RecordAddress RecordType = "address"
+	// RecordText is an arbitrary key/value text record
+	RecordText RecordType = "text"
+	// RecordContentHash is the hash of the content the domain points to, the key is not used
+	RecordContentHash RecordType = "contentHash"
+	// RecordPrimary makes the domain the primary name of its beneficiary in the reverse lookup, key and
+	// value are not used
+	RecordPrimary RecordType = "primary"
+)
+
+// chains which addresses can be stored in an address record
+const (
+	ChainETH = "ETH"
+	ChainBTC = "BTC"
+)
+
+// Records are the resolver records of a domain
+type Records struct {
+	Addresses   map[string]string `json:"addresses,omitempty"`
+	Texts       map[string]string `json:"texts,omitempty"`
+	ContentHash string            `json:"contentHash,omitempty"`
+}
+
+func (r RecordType) IsValid() bool {
+	switch r {
+	case RecordAddress, RecordText, RecordContentHash, RecordPrimary:
+		return true
+	}
+	return false
+}
+
+// ValidateRecord checks the record can be stored, an empty value removes the record so it is always valid
+func ValidateRecord(typ RecordType, key, value string) error {
+	switch typ {
+	case RecordAddress:
+		if len(value) == 0 {
+			return nil
+		}
+		switch key {
+		case ChainETH:
+			if !ethcmn.IsHexAddress(value) {
+				return ErrInvalidRecordValue
+			}
+		case ChainBTC:
+			if !isBTCAddress(value) {
+				return ErrInvalidRecordValue
+			}
+		default:
+			return ErrInvalidRecordKey
+		}
+	case RecordText:
+		if len(key) == 0 || len(key) > MaxTextKeyLength {
+			return ErrInvalidRecordKey
+		}
+		if len(value) > MaxTextValueLength {
+			return ErrInvalidRecordValue
+		}
+	case RecordContentHash:
+		if len(value) == 0 {
+			return nil
+		}
+		hash, err := hex.DecodeString(utils.TrimHex(value))
+		if err != nil || len(hash) == 0 || len(hash) > MaxContentHashLength {
+			return ErrInvalidRecordValue
+		}
+	case RecordPrimary:
+		return nil
+	default:
+		return ErrInvalidRecordType
+	}
+	return nil
+}
+
+func isBTCAddress(value string) bool {
+	for _, params := range []*chaincfg.Params{&chaincfg.MainNetParams, &chaincfg.TestNet3Params} {
+		if _, err := btcutil.DecodeAddress(value, params); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRecord stores the record on the domain, an empty value removes it
+func (d *Domain) SetRecord(typ RecordType, key, value string) error {
+	if d.Records == nil {
+		d.Records = &Records{}
+	}
+
+	switch typ {
+	case RecordAddress:
+		d.Records.Addresses = setOrDelete(d.Records.Addresses, key, value)
+	case RecordText:
+		if _, ok := d.Records.Texts[key]; !ok && len(value) > 0 && len(d.Records.Texts) >= MaxTextRecords {
+			return ErrTooManyRecords
+		}
+		d.Records.Texts = setOrDelete(d.Records.Texts, key, value)
+	case RecordContentHash:
+		d.Records.ContentHash = value
+	default:
+		return ErrInvalidRecordType
+	}
+
+	if d.Records.IsEmpty() {
+		d.Records = nil
+	}
+	return nil
+}
+
+// ResetRecords removes all the records of the domain
+func (d *Domain) ResetRecords() {
+	d.Records = nil
+}
+
+func (r *Records) IsEmpty() bool {
+	return len(r.Addresses) == 0 && len(r.Texts) == 0 && len(r.ContentHash) == 0
+}
+
+func setOrDelete(records map[string]string, key, value string) map[string]string {
+	if len(value) == 0 {
+		delete(records, key)
+		return records
+	}
+	if records == nil {
+		records = make(map[string]string)
+	}
+	records[key] = value
+	return records
+}
@@ -0,0 +1,87 @@
+/*
+
+ */
+
+package ons
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestValidateRecord(t *testing.T) {
+	assert.NoError(t, ValidateRecord(RecordAddress, ChainETH, "0x89205A3A3b2A69De6Dbf7f01ED13B2108B2c43e7"))
+	assert.NoError(t, ValidateRecord(RecordAddress, ChainBTC, "1BoatSLRHtKNngkdXEeobR76b53LETtpyT"))
+	assert.NoError(t, ValidateRecord(RecordAddress, ChainBTC, ""))
+	assert.Equal(t, ErrInvalidRecordValue, ValidateRecord(RecordAddress, ChainETH, "0x1234"))
+	assert.Equal(t, ErrInvalidRecordKey, ValidateRecord(RecordAddress, "DOGE", "D123"))
+
+	assert.NoError(t, ValidateRecord(RecordText, "url", "https://oneledger.io"))
+	assert.Equal(t, ErrInvalidRecordKey, ValidateRecord(RecordText, "", "value"))
+
+	assert.NoError(t, ValidateRecord(RecordContentHash, "", "0xe30101701220"))
+	assert.Equal(t, ErrInvalidRecordValue, ValidateRecord(RecordContentHash, "", "ipfs://hash"))
+
+	assert.Equal(t, ErrInvalidRecordType, ValidateRecord("unknown", "", ""))
+}
+
+func TestDomain_SetRecord(t *testing.T) {
+	d, err := NewDomain(keys.Address("owner"), nil, "alice.ol", 1, "", 100, true)
+	assert.NoError(t, err)
+
+	assert.NoError(t, d.SetRecord(RecordText, "email", "alice@oneledger.io"))
+	assert.NoError(t, d.SetRecord(RecordContentHash, "", "0xe30101701220"))
+	assert.Equal(t, "alice@oneledger.io", d.Records.Texts["email"])
+
+	for i := 0; i < MaxTextRecords; i++ {
+		_ = d.SetRecord(RecordText, string(rune('a'+i)), "value")
+	}
+	assert.Equal(t, ErrTooManyRecords, d.SetRecord(RecordText, "new", "value"))
+
+	// removing all the records drops them from the domain
+	d.ResetRecords()
+	assert.NoError(t, d.SetRecord(RecordText, "email", "alice@oneledger.io"))
+	assert.NoError(t, d.SetRecord(RecordText, "email", ""))
+	assert.Nil(t, d.Records)
+}
+
+func TestDomainStore_Records(t *testing.T) {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	ds := NewDomainStore("d", state)
+
+	owner := keys.Address("owner")
+	d, err := NewDomain(owner, nil, "alice.ol", 1, "", 100, true)
+	assert.NoError(t, err)
+	assert.NoError(t, d.SetRecord(RecordAddress, ChainETH, "0x89205A3A3b2A69De6Dbf7f01ED13B2108B2c43e7"))
+	assert.NoError(t, ds.Set(d))
+	assert.NoError(t, ds.SetPrimary(owner, d.Name))
+	state.Commit()
+
+	stored, err := ds.Get(d.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, d.Records, stored.Records)
+
+	primary, err := ds.GetPrimary(owner, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, d.Name, primary.Name)
+
+	// the reverse entries are not part of the domains
+	count := 0
+	ds.Iterate(func(name Name, domain *Domain) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+
+	// the domain doesn't resolve to the address anymore
+	stored.SetAccountAddress(keys.Address("other"))
+	assert.NoError(t, ds.Set(stored))
+	state.Commit()
+	_, err = ds.GetPrimary(owner, 10)
+	assert.Equal(t, ErrDomainNotFound, err)
+}
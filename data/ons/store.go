@@ -7,6 +7,7 @@ package ons
 import (
 	"github.com/pkg/errors"
 
+	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/serialize"
 	"github.com/Oneledger/protocol/storage"
 )
@@ -18,15 +19,19 @@ type DomainStore struct {
 	opt    *Options
 	szlr   serialize.Serializer
 	prefix []byte
+
+	// reverse lookup from an address to its primary domain, kept out of the range of the domains
+	reversePrefix []byte
 }
 
 // NewDomainStore creates a new storage object from filepath and other configurations
 func NewDomainStore(prefix string, state *storage.State) *DomainStore {
 
 	return &DomainStore{
-		State:  state,
-		szlr:   serialize.GetSerializer(serialize.PERSISTENT),
-		prefix: storage.Prefix(prefix),
+		State:         state,
+		szlr:          serialize.GetSerializer(serialize.PERSISTENT),
+		prefix:        storage.Prefix(prefix),
+		reversePrefix: storage.Prefix("reverse_" + prefix),
 	}
 }
 
@@ -43,6 +48,13 @@ func (ds *DomainStore) GetOptions() *Options {
 	return ds.opt
 }
 
+// BuildKey returns the full chain state key of a domain
+func (ds *DomainStore) BuildKey(name Name) storage.StoreKey {
+	key := make([]byte, 0, len(ds.prefix)+len(name))
+	key = append(key, ds.prefix...)
+	return append(key, name.toKey()...)
+}
+
 // Get is used to retrieve the domain object from the domain name
 func (ds *DomainStore) Get(name Name) (*Domain, error) {
 	key := name.toKey()
@@ -151,3 +163,32 @@ func (ds *DomainStore) DeleteASubdomain(subdomainName Name) error {
 
 	return err
 }
+
+func (ds *DomainStore) buildReverseKey(addr keys.Address) storage.StoreKey {
+	key := make([]byte, 0, len(ds.reversePrefix)+len(addr))
+	key = append(key, ds.reversePrefix...)
+	return append(key, addr.Bytes()...)
+}
+
+// SetPrimary sets the domain returned by the reverse lookup of the address
+func (ds *DomainStore) SetPrimary(addr keys.Address, name Name) error {
+	return ds.State.Set(ds.buildReverseKey(addr), []byte(name.String()))
+}
+
+// GetPrimary returns the primary domain of the address, the domain is only returned while it still
+// resolves to the address, so an entry left by a domain that was sold or updated is ignored
+func (ds *DomainStore) GetPrimary(addr keys.Address, height int64) (*Domain, error) {
+	data, err := ds.State.Get(ds.buildReverseKey(addr))
+	if err != nil || len(data) == 0 {
+		return nil, ErrDomainNotFound
+	}
+
+	domain, err := ds.Get(GetNameFromString(string(data)))
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsActive(height) || !domain.Beneficiary.Equal(addr) {
+		return nil, ErrDomainNotFound
+	}
+	return domain, nil
+}
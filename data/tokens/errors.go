@@ -0,0 +1,15 @@
+package tokens
+
+import (
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+var (
+	ErrTokenNotFound     = codes.ProtocolError{codes.TokenErrNotFound, "token not found"}
+	ErrTokenExists       = codes.ProtocolError{codes.TokenErrExists, "token or currency already exists"}
+	ErrInvalidToken      = codes.ProtocolError{codes.TokenErrInvalid, "invalid token"}
+	ErrNotIssuer         = codes.ProtocolError{codes.TokenErrNotIssuer, "only the issuer can mint or burn the token"}
+	ErrMaxSupplyExceeded = codes.ProtocolError{codes.TokenErrMaxSupplyExceeded, "token max supply exceeded"}
+	ErrCreationDisabled  = codes.ProtocolError{codes.TokenErrCreationDisabled, "token creation is only open to governance"}
+	ErrInvalidOptions    = codes.ProtocolError{codes.TokenErrInvalidOptions, "invalid token options"}
+)
@@ -0,0 +1,167 @@
+package tokens
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+const (
+	tokenKey  = "token"
+	heightKey = "height"
+	nextIdKey = "nextid"
+)
+
+// Store keeps the tokens by name, along with the names of the tokens created at each height
+type Store struct {
+	state  *storage.State
+	szlr   serialize.Serializer
+	prefix []byte
+}
+
+func NewStore(prefix string, state *storage.State) *Store {
+	return &Store{
+		state:  state,
+		prefix: storage.Prefix(prefix),
+		szlr:   serialize.GetSerializer(serialize.PERSISTENT),
+	}
+}
+
+func (st *Store) WithState(state *storage.State) *Store {
+	st.state = state
+	return st
+}
+
+func (st *Store) getKey(parts ...string) storage.StoreKey {
+	key := string(st.prefix)
+	for i, part := range parts {
+		if i > 0 {
+			key += storage.DB_PREFIX
+		}
+		key += part
+	}
+	return storage.StoreKey(key)
+}
+
+// Get returns the token of the currency name, ErrTokenNotFound if there is none
+func (st *Store) Get(name string) (*Token, error) {
+	dat, err := st.state.Get(st.getKey(tokenKey, name))
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) == 0 {
+		return nil, ErrTokenNotFound
+	}
+	token := &Token{}
+	err = st.szlr.Deserialize(dat, token)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize token")
+	}
+	return token, nil
+}
+
+// Exists tells whether a token of the currency name was created
+func (st *Store) Exists(name string) bool {
+	return st.state.Exists(st.getKey(tokenKey, name))
+}
+
+// Create registers the new token with the next currency id, its currency is available once its creation height is
+// over
+func (st *Store) Create(token *Token, currencies *balance.CurrencySet) error {
+	if _, ok := currencies.GetCurrencyByName(token.Currency.Name); ok || st.Exists(token.Currency.Name) {
+		return errors.Wrap(ErrTokenExists, token.Currency.Name)
+	}
+	id, err := st.nextId(currencies)
+	if err != nil {
+		return err
+	}
+	token.Currency.Id = id
+
+	created, err := st.CreatedAt(token.Height)
+	if err != nil {
+		return err
+	}
+	dat, err := st.szlr.Serialize(append(created, token.Currency.Name))
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize token names")
+	}
+	err = st.state.Set(st.getKey(heightKey, strconv.FormatInt(token.Height, 10)), dat)
+	if err != nil {
+		return err
+	}
+	return st.Set(token)
+}
+
+// Set updates the token
+func (st *Store) Set(token *Token) error {
+	dat, err := st.szlr.Serialize(token)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize token")
+	}
+	return st.state.Set(st.getKey(tokenKey, token.Currency.Name), dat)
+}
+
+// nextId returns the currency id of the next token, skipping the ids already used by the currencies
+func (st *Store) nextId(currencies *balance.CurrencySet) (int64, error) {
+	id := firstTokenId
+	dat, err := st.state.Get(st.getKey(nextIdKey))
+	if err != nil {
+		return 0, err
+	}
+	if len(dat) > 0 {
+		id, err = strconv.ParseInt(string(dat), 10, 64)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to read the next token id")
+		}
+	}
+	for {
+		if _, ok := currencies.GetCurrencyById(id); !ok {
+			break
+		}
+		id++
+	}
+	err = st.state.Set(st.getKey(nextIdKey), []byte(strconv.FormatInt(id+1, 10)))
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// CreatedAt returns the names of the tokens created at the height
+func (st *Store) CreatedAt(height int64) ([]string, error) {
+	dat, err := st.state.Get(st.getKey(heightKey, strconv.FormatInt(height, 10)))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0)
+	if len(dat) == 0 {
+		return names, nil
+	}
+	err = st.szlr.Deserialize(dat, &names)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize token names")
+	}
+	return names, nil
+}
+
+// Iterate goes through the committed tokens
+func (st *Store) Iterate(fn func(token *Token) bool) bool {
+	start := st.getKey(tokenKey, "")
+	return st.state.IterateRange(
+		start,
+		storage.Rangefix(string(start)),
+		true,
+		func(key, value []byte) bool {
+			token := &Token{}
+			err := st.szlr.Deserialize(value, token)
+			if err != nil {
+				return true
+			}
+			return fn(token)
+		},
+	)
+}
@@ -0,0 +1,76 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+var issuer = keys.Address("asdfasdfasdfasdfasdf")
+
+func TestToken_MintBurn(t *testing.T) {
+	_, err := NewToken("tok", "utok", 6, issuer, *balance.NewAmount(1000), 10)
+	assert.Error(t, err)
+	_, err = NewToken("TOK", "utok", 19, issuer, *balance.NewAmount(1000), 10)
+	assert.Error(t, err)
+	_, err = NewToken("TOK", "utok", 6, issuer, *balance.NewAmount(0), 10)
+	assert.Error(t, err)
+
+	token, err := NewToken("TOK", "utok", 6, issuer, *balance.NewAmount(1000), 10)
+	assert.NoError(t, err)
+	assert.NoError(t, token.Mint(*balance.NewAmount(600)))
+	assert.Error(t, token.Mint(*balance.NewAmount(401)))
+	assert.NoError(t, token.Mint(*balance.NewAmount(400)))
+	assert.Equal(t, "1000", token.Supply.String())
+
+	assert.Error(t, token.Burn(*balance.NewAmount(1001)))
+	assert.NoError(t, token.Burn(*balance.NewAmount(300)))
+	assert.Equal(t, "700", token.Supply.String())
+}
+
+func TestStore_Create(t *testing.T) {
+	cs := storage.NewState(storage.NewChainState("tokens", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewStore("tkn", cs)
+	currencies := balance.NewCurrencySet()
+	assert.NoError(t, currencies.Register(balance.Currency{Id: 0, Name: "OLT", Decimal: 18, Unit: "nue"}))
+	assert.NoError(t, currencies.Register(balance.Currency{Id: firstTokenId, Name: "OTHER", Decimal: 18, Unit: "o"}))
+
+	olt, err := NewToken("OLT", "nue", 18, issuer, *balance.NewAmount(1000), 10)
+	assert.NoError(t, err)
+	assert.Error(t, store.Create(olt, currencies))
+
+	first, err := NewToken("TOK", "utok", 6, issuer, *balance.NewAmount(1000), 10)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Create(first, currencies))
+	// the ids already used by the currencies are skipped
+	assert.Equal(t, firstTokenId+1, first.Currency.Id)
+	assert.Error(t, store.Create(first, currencies))
+
+	second, err := NewToken("TOKB", "utokb", 6, issuer, *balance.NewAmount(1000), 10)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Create(second, currencies))
+	assert.Equal(t, firstTokenId+2, second.Currency.Id)
+
+	names, err := store.CreatedAt(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"TOK", "TOKB"}, names)
+
+	token, err := store.Get("TOKB")
+	assert.NoError(t, err)
+	assert.Equal(t, issuer, token.Issuer)
+	_, err = store.Get("NONE")
+	assert.Equal(t, ErrTokenNotFound, err)
+
+	cs.Commit()
+	n := 0
+	store.Iterate(func(token *Token) bool {
+		n++
+		return false
+	})
+	assert.Equal(t, 2, n)
+}
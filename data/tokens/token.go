@@ -0,0 +1,105 @@
+package tokens
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+const (
+	// MaxDecimal is the largest number of decimals of a token
+	MaxDecimal = 18
+
+	// firstTokenId is the currency id of the first token, the ids below are left to the genesis currencies
+	firstTokenId = int64(1000)
+)
+
+var tokenName = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,11}$`)
+
+// Options are the governance options of the token factory. When enabled anyone can create a token by paying the
+// creation fee, otherwise the tokens are only registered by config update proposals.
+type Options struct {
+	Enabled     bool           `json:"enabled"`
+	CreationFee balance.Amount `json:"creationFee"`
+}
+
+// Token is a currency registered at runtime, only its issuer can mint it, up to the max supply, and burn it
+type Token struct {
+	Currency  balance.Currency `json:"currency"`
+	Issuer    keys.Address     `json:"issuer"`
+	MaxSupply balance.Amount   `json:"maxSupply"`
+	Supply    balance.Amount   `json:"supply"`
+	// height the token was created at, its currency can be used from the next block
+	Height int64 `json:"height"`
+}
+
+// NewToken returns the token with no supply yet, the currency id is set when the token is created in the store
+func NewToken(name, unit string, decimal int64, issuer keys.Address, maxSupply balance.Amount, height int64) (*Token, error) {
+	token := &Token{
+		Currency: balance.Currency{
+			Name:    name,
+			Chain:   chain.ONELEDGER,
+			Decimal: decimal,
+			Unit:    unit,
+		},
+		Issuer:    issuer,
+		MaxSupply: maxSupply,
+		Supply:    *balance.NewAmount(0),
+		Height:    height,
+	}
+	err := token.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Validate checks the token definition
+func (t *Token) Validate() error {
+	if !tokenName.MatchString(t.Currency.Name) {
+		return errors.Wrapf(ErrInvalidToken, "name %s, expected 2 to 12 upper case letters or digits", t.Currency.Name)
+	}
+	if t.Currency.Unit == "" {
+		return errors.Wrap(ErrInvalidToken, "missing unit")
+	}
+	if t.Currency.Decimal < 0 || t.Currency.Decimal > MaxDecimal {
+		return errors.Wrapf(ErrInvalidToken, "decimal %d, expected between 0 and %d", t.Currency.Decimal, MaxDecimal)
+	}
+	if err := t.Issuer.Err(); err != nil {
+		return errors.Wrap(ErrInvalidToken, err.Error())
+	}
+	if t.MaxSupply.BigInt().Sign() <= 0 {
+		return errors.Wrap(ErrInvalidToken, "max supply must be positive")
+	}
+	return nil
+}
+
+// Mint adds the amount to the supply, the supply can't go over the max supply
+func (t *Token) Mint(amount balance.Amount) error {
+	if amount.BigInt().Sign() <= 0 {
+		return errors.Wrap(ErrInvalidToken, "mint amount must be positive")
+	}
+	supply := t.Supply.Plus(amount)
+	if t.MaxSupply.LessThan(*supply) {
+		return errors.Wrapf(ErrMaxSupplyExceeded, "supply %s, max supply %s", supply, t.MaxSupply.String())
+	}
+	t.Supply = *supply
+	return nil
+}
+
+// Burn removes the amount from the supply
+func (t *Token) Burn(amount balance.Amount) error {
+	if amount.BigInt().Sign() <= 0 {
+		return errors.Wrap(ErrInvalidToken, "burn amount must be positive")
+	}
+	supply, err := t.Supply.Minus(amount)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidToken, "burn %s of supply %s", amount.String(), t.Supply.String())
+	}
+	t.Supply = *supply
+	return nil
+}
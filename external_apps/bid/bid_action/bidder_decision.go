@@ -35,11 +35,11 @@ func (b BidderDecisionTx) Validate(ctx *action.Context, signedTx action.SignedTx
 	}
 
 	//validate basic signature
-	err = action.ValidateBasic(signedTx.RawBytes(), bidderDecision.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), bidderDecision.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		return false, err
 	}
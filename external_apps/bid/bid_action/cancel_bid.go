@@ -34,11 +34,11 @@ func (c CancelBidTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bo
 	}
 
 	//validate basic signature
-	err = action.ValidateBasic(signedTx.RawBytes(), cancelBid.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), cancelBid.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -32,11 +32,11 @@ func (e ExpireBidTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bo
 	}
 
 	//validate basic signature
-	err = action.ValidateBasic(signedTx.RawBytes(), expireBid.Signers(), signedTx.Signatures)
+	err = action.ValidateBasic(ctx, signedTx.RawBytes(), expireBid.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
-	err = action.ValidateFee(ctx.FeePool.GetOpt(), signedTx.Fee)
+	err = action.ValidateFee(ctx.FeePool, signedTx.Fee)
 	if err != nil {
 		return false, err
 	}
@@ -4,12 +4,14 @@ import (
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/client"
 	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/external_apps/bid/bid_action"
 	"github.com/Oneledger/protocol/external_apps/bid/bid_rpc"
 	"github.com/Oneledger/protocol/log"
 	"github.com/Oneledger/protocol/serialize"
 	codes "github.com/Oneledger/protocol/status_codes"
 	"github.com/google/uuid"
+	tmrpccore "github.com/tendermint/tendermint/rpc/core"
 )
 
 func Name() string {
@@ -17,20 +19,34 @@ func Name() string {
 }
 
 type Service struct {
-	balances *balance.Store
-	logger   *log.Logger
+	balances      *balance.Store
+	logger        *log.Logger
+	accountKeeper balance.AccountKeeper
 }
 
 func NewService(
 	balances *balance.Store,
 	logger *log.Logger,
+	accountKeeper balance.AccountKeeper,
 ) *Service {
 	return &Service{
-		balances: balances,
-		logger:   logger,
+		balances:      balances,
+		logger:        logger,
+		accountKeeper: accountKeeper,
 	}
 }
 
+// nonce returns the account nonce for a new transaction of the signer
+func (s *Service) nonce(signer keys.Address) uint64 {
+	height := s.balances.State.Version()
+	unconfirmed, err := tmrpccore.UnconfirmedTxs(nil, 100)
+	if err != nil {
+		s.logger.Error("error getting unconfirmed txs", err)
+		return action.PendingNonce(s.accountKeeper, signer, height, nil)
+	}
+	return action.PendingNonce(s.accountKeeper, signer, height, unconfirmed.Txs)
+}
+
 func (s *Service) CreateBid(args bid_rpc.CreateBidRequest, reply *client.CreateTxReply) error {
 	createBid := bid_action.CreateBid{
 		BidConvId:  args.BidConvId,
@@ -54,10 +70,11 @@ func (s *Service) CreateBid(args bid_rpc.CreateBidRequest, reply *client.CreateT
 	}
 
 	tx := &action.RawTx{
-		Type: bid_action.BID_CREATE,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  bid_action.BID_CREATE,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(createBid.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -90,10 +107,11 @@ func (s *Service) CounterOffer(args bid_rpc.CounterOfferRequest, reply *client.C
 	}
 
 	tx := &action.RawTx{
-		Type: bid_action.BID_CONTER_OFFER,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  bid_action.BID_CONTER_OFFER,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(counterOffer.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -124,10 +142,11 @@ func (s *Service) CancelBid(args bid_rpc.CancelBidRequest, reply *client.CreateT
 	}
 
 	tx := &action.RawTx{
-		Type: bid_action.BID_CANCEL,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  bid_action.BID_CANCEL,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(cancelBid.Signers()[0]),
 	}
 
 	packet := tx.RawBytes()
@@ -155,10 +174,11 @@ func (s *Service) OwnerDecision(args bid_rpc.OwnerDecisionRequest, reply *client
 	}
 
 	tx := &action.RawTx{
-		Type: bid_action.BID_OWNER_DECISION,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  bid_action.BID_OWNER_DECISION,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(ownerDecision.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -190,10 +210,11 @@ func (s *Service) BidderDecision(args bid_rpc.BidderDecisionRequest, reply *clie
 	}
 
 	tx := &action.RawTx{
-		Type: bid_action.BID_BIDDER_DECISION,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  bid_action.BID_BIDDER_DECISION,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(bidderDecision.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
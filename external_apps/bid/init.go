@@ -70,7 +70,8 @@ func LoadAppData(appData *common.ExtAppData) {
 		return
 	}
 	appData.ExtServiceMap[bid_rpc_query.Name()] = bid_rpc_query.NewService(balances, currencies, domains, logger, bid_data.NewBidMasterStore(appData.ChainState))
-	appData.ExtServiceMap[bid_rpc_tx.Name()] = bid_rpc_tx.NewService(balances, logger)
+	accountKeeper := balance.NewNesterAccountKeeper(storage.NewState(appData.ChainState), balances, currencies)
+	appData.ExtServiceMap[bid_rpc_tx.Name()] = bid_rpc_tx.NewService(balances, logger, accountKeeper)
 
 	//load beginner and ender functions
 	err = appData.ExtBlockFuncs.Add(common.BlockBeginner, bid_block_func.AddExpireBidTxToQueue)
@@ -53,3 +53,16 @@ func RegisterExtApp(cs *storage.ChainState, ar action.Router, dr data.Router, es
 	}
 	return nil
 }
+
+// NewExtStores returns new instances of the external app stores bound to the given state, the stores registered by
+// RegisterExtApp for the block processing are left untouched
+func NewExtStores(cs *storage.ChainState, state *storage.State) (data.Router, error) {
+	router := data.NewStorageRouter()
+	for name, store := range common.LoadExtAppData(cs).ExtStores {
+		err := router.Add(data.Type(name), store.WithState(state))
+		if err != nil {
+			return nil, errors.Wrap(err, "error adding external store")
+		}
+	}
+	return router, nil
+}
@@ -0,0 +1,97 @@
+package identity
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+// COMMISSION_CHANGE_INTERVAL is the time in seconds a validator has to wait between two changes of its commission rate
+const COMMISSION_CHANGE_INTERVAL = int64(24 * 60 * 60)
+
+var (
+	ErrInvalidCommission       = errors.New("commission rates must be between 0 and 100, the rate and the max change rate at most the max rate")
+	ErrCommissionMaxChanged    = errors.New("the max rate and the max change rate of the commission can't be changed")
+	ErrCommissionChangeTooBig  = errors.New("commission rate change is more than the max change rate")
+	ErrCommissionChangeTooSoon = errors.New("commission rate can only be changed once a day")
+)
+
+// Commission is the percentage of the rewards of the delegators of a validator kept by the validator. The max rate
+// and the max change rate are declared with the first commission of the validator and never change.
+type Commission struct {
+	Rate          int64 `json:"rate"`
+	MaxRate       int64 `json:"maxRate"`
+	MaxChangeRate int64 `json:"maxChangeRate"`
+	// unix time of the last change of the rate
+	UpdateTime int64 `json:"updateTime"`
+}
+
+// Validate checks the rates of the commission are consistent
+func (c Commission) Validate() error {
+	if c.MaxRate < 0 || c.MaxRate > 100 {
+		return ErrInvalidCommission
+	}
+	if c.Rate < 0 || c.Rate > c.MaxRate {
+		return ErrInvalidCommission
+	}
+	if c.MaxChangeRate < 0 || c.MaxChangeRate > c.MaxRate {
+		return ErrInvalidCommission
+	}
+	return nil
+}
+
+// ValidateChange checks the commission can be changed to the new one at the time given, in unix seconds
+func (c Commission) ValidateChange(next Commission, now int64) error {
+	if err := next.Validate(); err != nil {
+		return err
+	}
+	if next.MaxRate != c.MaxRate || next.MaxChangeRate != c.MaxChangeRate {
+		return ErrCommissionMaxChanged
+	}
+	if next.Rate == c.Rate {
+		return nil
+	}
+	if now-c.UpdateTime < COMMISSION_CHANGE_INTERVAL {
+		return ErrCommissionChangeTooSoon
+	}
+	diff := next.Rate - c.Rate
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > c.MaxChangeRate {
+		return ErrCommissionChangeTooBig
+	}
+	return nil
+}
+
+// CommissionRate returns the commission rate of the validator, the default rate when it hasn't declared any
+func (v *Validator) CommissionRate(defaultRate int64) int64 {
+	if v.Commission == nil {
+		return defaultRate
+	}
+	return v.Commission.Rate
+}
+
+// SetCommission declares the commission of the validator, or changes its rate if it already has one
+func (vs *ValidatorStore) SetCommission(addr keys.Address, commission Commission, now int64) error {
+	validator, err := vs.Get(addr)
+	if err != nil {
+		return err
+	}
+	if validator.Commission == nil {
+		err = commission.Validate()
+	} else {
+		err = validator.Commission.ValidateChange(commission, now)
+	}
+	if err != nil {
+		return err
+	}
+
+	if validator.Commission == nil || validator.Commission.Rate != commission.Rate {
+		commission.UpdateTime = now
+	} else {
+		commission.UpdateTime = validator.Commission.UpdateTime
+	}
+	validator.Commission = &commission
+	return vs.set(*validator)
+}
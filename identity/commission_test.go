@@ -0,0 +1,26 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommission_Validate(t *testing.T) {
+	assert.NoError(t, Commission{Rate: 10, MaxRate: 20, MaxChangeRate: 1}.Validate())
+	assert.Equal(t, ErrInvalidCommission, Commission{Rate: 30, MaxRate: 20, MaxChangeRate: 1}.Validate())
+	assert.Equal(t, ErrInvalidCommission, Commission{Rate: 10, MaxRate: 101, MaxChangeRate: 1}.Validate())
+	assert.Equal(t, ErrInvalidCommission, Commission{Rate: 10, MaxRate: 20, MaxChangeRate: 21}.Validate())
+	assert.Equal(t, ErrInvalidCommission, Commission{Rate: -1, MaxRate: 20, MaxChangeRate: 1}.Validate())
+}
+
+func TestCommission_ValidateChange(t *testing.T) {
+	current := Commission{Rate: 10, MaxRate: 20, MaxChangeRate: 2, UpdateTime: 1000}
+	later := current.UpdateTime + COMMISSION_CHANGE_INTERVAL
+
+	assert.NoError(t, current.ValidateChange(Commission{Rate: 12, MaxRate: 20, MaxChangeRate: 2}, later))
+	assert.NoError(t, current.ValidateChange(Commission{Rate: 10, MaxRate: 20, MaxChangeRate: 2}, current.UpdateTime))
+	assert.Equal(t, ErrCommissionChangeTooSoon, current.ValidateChange(Commission{Rate: 12, MaxRate: 20, MaxChangeRate: 2}, later-1))
+	assert.Equal(t, ErrCommissionChangeTooBig, current.ValidateChange(Commission{Rate: 7, MaxRate: 20, MaxChangeRate: 2}, later))
+	assert.Equal(t, ErrCommissionMaxChanged, current.ValidateChange(Commission{Rate: 10, MaxRate: 30, MaxChangeRate: 2}, later))
+}
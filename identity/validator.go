@@ -21,6 +21,7 @@ type Validator struct {
 	Power        int64          `json:"power"`
 	Name         string         `json:"name"`
 	Staking      balance.Amount `json:"staking"`
+	Commission   *Commission    `json:"commission,omitempty"`
 }
 
 func NewValidator(address keys.Address, stakeAddress keys.Address, pubKey keys.PublicKey, ecdsaPubKey keys.PublicKey, amount balance.Amount, name string) *Validator {
@@ -61,6 +61,18 @@ func (vs *ValidatorStore) WithState(state *storage.State) *ValidatorStore {
 	return vs
 }
 
+// BuildKey returns the full chain state key of a validator
+func (vs *ValidatorStore) BuildKey(addr keys.Address) storage.StoreKey {
+	key := make([]byte, 0, len(vs.prefix)+len(addr))
+	key = append(key, vs.prefix...)
+	return append(key, addr...)
+}
+
+// GetPrefix returns the prefix of validator keys in the chain state
+func (vs *ValidatorStore) GetPrefix() []byte {
+	return vs.prefix
+}
+
 func (vs *ValidatorStore) Get(addr keys.Address) (*Validator, error) {
 	key := append(vs.prefix, addr...)
 	value, _ := vs.store.Get(key)
@@ -7,6 +7,7 @@ import (
 	"github.com/Oneledger/protocol/data/delegation"
 	"github.com/Oneledger/protocol/data/evidence"
 	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/multisig"
 	netwkDeleg "github.com/Oneledger/protocol/data/network_delegation"
 	"github.com/Oneledger/protocol/data/rewards"
 	"github.com/Oneledger/protocol/identity"
@@ -40,6 +41,7 @@ type Service struct {
 	extStores       data.Router
 	ext             client.ExtServiceContext
 	govUpdate       *action.GovernaceUpdateAndValidate
+	multiSigs       *multisig.Store
 
 	// evm
 	stateDB *vm.CommitStateDB
@@ -48,7 +50,7 @@ type Service struct {
 func NewService(ctx client.ExtServiceContext, router action.Router, currencies *balance.CurrencySet,
 	feePool *fees.Store, domains *ons.DomainStore, govern *governance.Store, delegators *delegation.DelegationStore, evidenceStore *evidence.EvidenceStore, netwkDelegators *netwkDeleg.MasterStore, validators *identity.ValidatorStore,
 	logger *log.Logger, trackers *bitcoin.TrackerStore, proposalMaster *governance.ProposalMasterStore, rewardMaster *rewards.RewardMasterStore, extStores data.Router, govUpdate *action.GovernaceUpdateAndValidate,
-	stateDB *vm.CommitStateDB,
+	stateDB *vm.CommitStateDB, multiSigs *multisig.Store,
 ) *Service {
 	return &Service{
 		ext:             ctx,
@@ -68,6 +70,7 @@ func NewService(ctx client.ExtServiceContext, router action.Router, currencies *
 		logger:          logger,
 		govUpdate:       govUpdate,
 		stateDB:         stateDB,
+		multiSigs:       multiSigs,
 	}
 }
 
@@ -86,14 +89,15 @@ func (svc *Service) validateAndSignTx(req client.BroadcastRequest) ([]byte, erro
 
 	sigs := []action.Signature{{Signer: req.PublicKey, Signed: req.Signature}}
 	signedTx := action.SignedTx{
-		RawTx:      tx,
-		Signatures: sigs,
+		RawTx:             tx,
+		Signatures:        sigs,
+		FeePayerSignature: req.FeePayerSignature,
 	}
 
 	handler := svc.router.Handler(tx.Type)
 	ctx := action.NewContext(svc.router, nil, nil, nil, nil, svc.currencies,
 		svc.feePool, svc.validators, nil, svc.domains, svc.delegators, svc.netwkDelegators, svc.evidenceStore, svc.trackers, nil, nil, nil, svc.logger,
-		svc.proposalMaster, svc.rewardMaster, svc.govern, svc.extStores, svc.govUpdate, svc.stateDB)
+		svc.proposalMaster, svc.rewardMaster, svc.govern, svc.extStores, svc.govUpdate, svc.stateDB, nil, nil, svc.multiSigs, nil)
 
 	_, err = handler.Validate(ctx, signedTx)
 	if err != nil {
@@ -119,14 +123,15 @@ func (svc *Service) validateAndMtSignTx(req client.BroadcastMtSigRequest) ([]byt
 	}
 
 	signedTx := action.SignedTx{
-		RawTx:      tx,
-		Signatures: sigs,
+		RawTx:             tx,
+		Signatures:        sigs,
+		FeePayerSignature: req.FeePayerSignature,
 	}
 
 	handler := svc.router.Handler(tx.Type)
 	ctx := action.NewContext(svc.router, nil, nil, nil, nil, svc.currencies,
 		svc.feePool, svc.validators, nil, svc.domains, svc.delegators, svc.netwkDelegators, svc.evidenceStore, svc.trackers, nil, nil, nil, svc.logger,
-		svc.proposalMaster, svc.rewardMaster, svc.govern, svc.extStores, svc.govUpdate, svc.stateDB)
+		svc.proposalMaster, svc.rewardMaster, svc.govern, svc.extStores, svc.govUpdate, svc.stateDB, nil, nil, svc.multiSigs, nil)
 
 	_, err = handler.Validate(ctx, signedTx)
 	if err != nil {
@@ -5,6 +5,8 @@ import (
 	"github.com/Oneledger/protocol/external_apps/common"
 	"github.com/Oneledger/protocol/vm"
 	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/mempool"
 
 	"github.com/Oneledger/protocol/data"
 	"github.com/Oneledger/protocol/data/governance"
@@ -23,6 +25,7 @@ import (
 	"github.com/Oneledger/protocol/data/evidence"
 	"github.com/Oneledger/protocol/data/evm"
 	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/multisig"
 	"github.com/Oneledger/protocol/data/ons"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
@@ -33,6 +36,7 @@ import (
 	"github.com/Oneledger/protocol/service/owner"
 	"github.com/Oneledger/protocol/service/query"
 	"github.com/Oneledger/protocol/service/tx"
+	"github.com/Oneledger/protocol/storage"
 )
 
 // Context is the master context for creating new contexts
@@ -49,6 +53,7 @@ type Context struct {
 	WitnessSet      *identity.WitnessStore
 	Trackers        *bitcoin.TrackerStore
 	EthTrackers     *ethTracker.TrackerStore
+	MultiSigs       *multisig.Store
 	// configurations
 	Cfg                   config.Server
 	Currencies            *balance.CurrencySet
@@ -71,6 +76,13 @@ type Context struct {
 	Contracts     *evm.ContractStore
 	AccountKeeper balance.AccountKeeper
 	StateDB       *vm.CommitStateDB
+
+	// simulation
+	ChainState *storage.ChainState
+	ActionCtx  func(header *abci.Header, state *storage.State) *action.Context
+
+	// the pending transactions counted by the nonce queries
+	Mempool mempool.Mempool
 }
 
 // Map of services, keyed by the name/prefix of the service
@@ -80,12 +92,13 @@ func NewMap(ctx *Context) (Map, error) {
 
 	defaultMap := Map{
 		broadcast.Name(): broadcast.NewService(ctx.Services, ctx.Router, ctx.Currencies, ctx.FeePool, ctx.Domains, ctx.Govern, ctx.Delegators, ctx.EvidenceStore, ctx.NetwkDelegators,
-			ctx.ValidatorSet, ctx.Logger, ctx.Trackers, ctx.ProposalMaster, ctx.RewardMaster, ctx.ExtStores, ctx.GovUpdate, ctx.StateDB),
+			ctx.ValidatorSet, ctx.Logger, ctx.Trackers, ctx.ProposalMaster, ctx.RewardMaster, ctx.ExtStores, ctx.GovUpdate, ctx.StateDB, ctx.MultiSigs),
 		nodesvc.Name(): nodesvc.NewService(ctx.NodeContext, &ctx.Cfg, ctx.Logger),
 		owner.Name():   owner.NewService(ctx.Accounts, ctx.Logger),
 		query.Name(): query.NewService(ctx.Services, ctx.Balances, ctx.Currencies, ctx.ValidatorSet, ctx.WitnessSet, ctx.Domains, ctx.Delegators, ctx.NetwkDelegators, ctx.EvidenceStore,
-			ctx.Govern, ctx.FeePool, ctx.ProposalMaster, ctx.RewardMaster, ctx.Logger, ctx.TxTypes, ctx.Contracts, ctx.AccountKeeper),
-		tx.Name():       tx.NewService(ctx.Balances, ctx.Router, ctx.Accounts, ctx.ValidatorSet, ctx.Govern, ctx.Delegators, ctx.EvidenceStore, ctx.FeePool.GetOpt(), ctx.NodeContext, ctx.Logger),
+			ctx.Govern, ctx.FeePool, ctx.ProposalMaster, ctx.RewardMaster, ctx.Logger, ctx.TxTypes, ctx.Contracts, ctx.AccountKeeper,
+			ctx.ChainState, ctx.ActionCtx, ctx.Mempool),
+		tx.Name():       tx.NewService(ctx.Balances, ctx.Router, ctx.Accounts, ctx.ValidatorSet, ctx.Govern, ctx.Delegators, ctx.EvidenceStore, ctx.FeePool.GetOpt(), ctx.NodeContext, ctx.Logger, ctx.AccountKeeper, ctx.Mempool),
 		btc.Name():      btc.NewService(ctx.Balances, ctx.Accounts, ctx.NodeContext, ctx.ValidatorSet, ctx.Trackers, ctx.Logger),
 		ethereum.Name(): ethereum.NewService(ctx.Cfg.EthChainDriver, ctx.Router, ctx.Accounts, ctx.NodeContext, ctx.ValidatorSet, ctx.EthTrackers, ctx.Logger),
 	}
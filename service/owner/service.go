@@ -8,6 +8,7 @@ import (
 	"github.com/Oneledger/protocol/data/accounts"
 	"github.com/Oneledger/protocol/data/chain"
 	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
 	"github.com/Oneledger/protocol/log"
 	codes "github.com/Oneledger/protocol/status_codes"
 )
@@ -155,6 +156,47 @@ func (svc *Service) SignWithSecureAddress(req client.SecureSignRawTxRequest, rep
 	return nil
 }
 
+// SignMultiSig adds the signature of a member of the multisig account to the signature bundle of the raw tx
+func (svc *Service) SignMultiSig(req client.SignMultiSigRequest, reply *client.MultiSigReply) error {
+	partial := &keys.MultiSig{}
+	err := partial.Init(req.RawTx, req.Bundle.M, req.Bundle.Signers)
+	if err != nil {
+		return multisig.ErrInvalidPolicy
+	}
+	index, err := partial.GetSignerIndex(req.Address)
+	if err != nil {
+		return codes.ErrBadAddress
+	}
+
+	pkey, signed, err := svc.accounts.SignWithAddress(req.RawTx, req.Address)
+	if err != nil {
+		svc.logger.Error("error while signing with address", err)
+		if err == accounts.ErrGetAccountByAddress {
+			return codes.ErrAccountNotFound
+		}
+		return codes.ErrSigningError
+	}
+	err = partial.AddSignature(keys.Signature{Index: index, PubKey: pkey, Signed: signed})
+	if err != nil {
+		return codes.ErrSigningError
+	}
+
+	bundle := req.Bundle
+	bundle.Msg = req.RawTx
+	return svc.CombineMultiSig(client.CombineMultiSigRequest{Bundles: []keys.MultiSig{bundle, *partial}}, reply)
+}
+
+// CombineMultiSig merges the signatures of the bundles signed separately by the members of the multisig account
+func (svc *Service) CombineMultiSig(req client.CombineMultiSigRequest, reply *client.MultiSigReply) error {
+	bundle, err := multisig.Combine(req.Bundles...)
+	if err != nil {
+		svc.logger.Error("error while combining multisig bundles", err)
+		return err
+	}
+	*reply = client.MultiSigReply{Bundle: *bundle, Complete: bundle.IsValid()}
+	return nil
+}
+
 func (svc *Service) NewAccount(req client.NewAccountRequest, reply *client.NewAccountReply) error {
 
 	pubKey, privKey, err := keys.NewKeyPairFromTendermint()
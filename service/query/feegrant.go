@@ -0,0 +1,42 @@
+package query
+
+import (
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/feegrant"
+	codes "github.com/Oneledger/protocol/status_codes"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// ListFeeAllowances returns the fee allowances of the granter at the last committed height
+func (svc *Service) ListFeeAllowances(req client.ListFeeAllowancesRequest, reply *client.ListFeeAllowancesReply) error {
+	if err := req.Granter.Err(); err != nil {
+		return codes.ErrBadAddress
+	}
+	height := svc.chainState.Version
+	state, err := storage.NewVersionedState(svc.chainState, height)
+	if err != nil {
+		return codes.ErrBadHeight
+	}
+	grants := feegrant.NewStore("fg", state)
+
+	allowances := make([]*feegrant.Allowance, 0)
+	if len(req.Grantee) != 0 {
+		allowance, err := grants.Get(req.Granter, req.Grantee)
+		if err == nil {
+			allowances = append(allowances, allowance)
+		} else if err != feegrant.ErrAllowanceNotFound {
+			return err
+		}
+	} else {
+		grants.Iterate(req.Granter, func(allowance *feegrant.Allowance) bool {
+			allowances = append(allowances, allowance)
+			return false
+		})
+	}
+
+	*reply = client.ListFeeAllowancesReply{
+		Allowances: allowances,
+		Height:     height,
+	}
+	return nil
+}
@@ -10,15 +10,21 @@ import (
 
 // list single proposal by id
 func (svc *Service) ListProposal(req client.ListProposalRequest, reply *client.ListProposalsReply) error {
-	proposal, _, err := svc.proposalMaster.Proposal.QueryAllStores(req.ProposalId)
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+
+	pms := stores.proposalMaster
+	proposal, _, err := pms.Proposal.QueryAllStores(req.ProposalId)
 	if err != nil {
 		svc.logger.Error("error getting proposal", err)
 		return codes.ErrGetProposal
 	}
 
-	options := svc.proposalMaster.Proposal.GetOptionsByType(proposal.Type)
-	funds := svc.proposalMaster.ProposalFund.GetCurrentFundsForProposal(req.ProposalId)
-	stat, _ := svc.proposalMaster.ProposalVote.ResultSoFar(req.ProposalId, options.PassPercentage)
+	options := pms.Proposal.GetOptionsByType(proposal.Type)
+	funds := pms.ProposalFund.GetCurrentFundsForProposal(req.ProposalId)
+	stat, _ := pms.ProposalVote.ResultSoFar(req.ProposalId, options.PassPercentage)
 
 	ps := client.ProposalStat{
 		Proposal: *proposal,
@@ -27,7 +33,7 @@ func (svc *Service) ListProposal(req client.ListProposalRequest, reply *client.L
 	}
 	*reply = client.ListProposalsReply{
 		ProposalStats: []client.ProposalStat{ps},
-		Height:        svc.proposalMaster.Proposal.GetState().Version(),
+		Height:        stores.height,
 	}
 
 	return nil
@@ -43,8 +49,13 @@ func (svc *Service) ListProposals(req client.ListProposalsRequest, reply *client
 		}
 	}
 
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+
 	// Query in single store if specified
-	pms := svc.proposalMaster
+	pms := stores.proposalMaster
 	var proposals []governance.Proposal
 	if req.State != governance.ProposalStateInvalid {
 		proposals = pms.Proposal.FilterProposals(req.State, req.Proposer, req.ProposalType)
@@ -78,7 +89,52 @@ func (svc *Service) ListProposals(req client.ListProposalsRequest, reply *client
 
 	*reply = client.ListProposalsReply{
 		ProposalStats: proposalStats,
-		Height:        pms.Proposal.GetState().Version(),
+		Height:        stores.height,
+	}
+
+	return nil
+}
+
+// list the votes of a proposal, by validator and by network delegator
+func (svc *Service) ListProposalVotes(req client.ListProposalVotesRequest, reply *client.ListProposalVotesReply) error {
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+
+	proposal, _, err := stores.proposalMaster.Proposal.QueryAllStores(req.ProposalId)
+	if err != nil {
+		svc.logger.Error("error getting proposal", err)
+		return codes.ErrGetProposal
+	}
+
+	pvs := stores.proposalMaster.ProposalVote
+	options := stores.proposalMaster.Proposal.GetOptionsByType(proposal.Type)
+	stat, _ := pvs.ResultSoFar(req.ProposalId, options.PassPercentage)
+	delegatorVotes, err := pvs.GetDelegatorVotesByID(req.ProposalId)
+	if err != nil {
+		return codes.ErrGetProposal
+	}
+
+	// the votes are removed once the proposal is finalized
+	validatorVotes := make([]client.ValidatorVoteStat, 0)
+	_, votes, err := pvs.GetVotesByID(req.ProposalId)
+	if err == nil {
+		for _, vote := range votes {
+			validatorVotes = append(validatorVotes, client.ValidatorVoteStat{
+				Validator:      vote.Validator,
+				Opinion:        vote.Opinion,
+				Power:          vote.Power,
+				InheritedPower: governance.InheritedPower(vote, delegatorVotes),
+			})
+		}
+	}
+
+	*reply = client.ListProposalVotesReply{
+		ValidatorVotes: validatorVotes,
+		DelegatorVotes: delegatorVotes,
+		Votes:          *stat,
+		Height:         stores.height,
 	}
 
 	return nil
@@ -92,81 +148,104 @@ func (svc *Service) GetFundsForProposalByFunder(req client.GetFundsForProposalBy
 		return errors.New("invalid funder address")
 	}
 
-	amount := svc.proposalMaster.ProposalFund.GetFundsForProposalByFunder(req.ProposalId, req.Funder)
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+
+	amount := stores.proposalMaster.ProposalFund.GetFundsForProposalByFunder(req.ProposalId, req.Funder)
 	*reply = client.GetFundsForProposalByFunderReply{
 		Amount: *amount,
+		Height: stores.height,
 	}
 
 	return nil
 }
 
 func (svc *Service) GetGovernanceOptionsForHeight(req client.GovernanceOptionsRequest, reply *client.GovernanceOptionsReply) error {
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+	govern := stores.govern
 
-	feeOpt, err := svc.governance.GetFeeOption()
+	feeOpt, err := govern.GetFeeOption()
+	if err != nil {
+		return err
+	}
+	propOpt, err := govern.GetProposalOptions()
+	if err != nil {
+		return err
+	}
+	rewardOpt, err := govern.GetRewardOptions()
 	if err != nil {
 		return err
 	}
-	propOpt, err := svc.governance.GetProposalOptions()
+	ethOpt, err := govern.GetETHChainDriverOption()
 	if err != nil {
 		return err
 	}
-	rewardOpt, err := svc.governance.GetRewardOptions()
+	btcOpt, err := govern.GetBTCChainDriverOption()
 	if err != nil {
 		return err
 	}
-	ethOpt, err := svc.governance.GetETHChainDriverOption()
+	onsOpt, err := govern.GetONSOptions()
 	if err != nil {
 		return err
 	}
-	btcOpt, err := svc.governance.GetBTCChainDriverOption()
+	stakingOpt, err := govern.GetStakingOptions()
 	if err != nil {
 		return err
 	}
-	onsOpt, err := svc.governance.GetONSOptions()
+	evidenceOpt, err := govern.GetEvidenceOptions()
 	if err != nil {
 		return err
 	}
-	stakingOpt, err := svc.governance.GetStakingOptions()
+	marketOpt, err := govern.GetFeeMarketOptions()
 	if err != nil {
 		return err
 	}
-	evidenceOpt, err := svc.governance.GetEvidenceOptions()
+	gasOpt, err := govern.GetGasOptions()
 	if err != nil {
 		return err
 	}
-	luhFee, err := svc.governance.GetLUH(governance.LAST_UPDATE_HEIGHT_FEE)
+	tokenOpt, err := govern.GetTokenOptions()
 	if err != nil {
 		return err
 	}
-	luhCurrency, err := svc.governance.GetLUH(governance.LAST_UPDATE_HEIGHT_CURRENCY)
+	luhFee, err := govern.GetLUH(governance.LAST_UPDATE_HEIGHT_FEE)
 	if err != nil {
 		return err
 	}
-	luhProposal, err := svc.governance.GetLUH(governance.LAST_UPDATE_HEIGHT_PROPOSAL)
+	luhCurrency, err := govern.GetLUH(governance.LAST_UPDATE_HEIGHT_CURRENCY)
 	if err != nil {
 		return err
 	}
-	luhStaking, err := svc.governance.GetLUH(governance.LAST_UPDATE_HEIGHT_STAKING)
+	luhProposal, err := govern.GetLUH(governance.LAST_UPDATE_HEIGHT_PROPOSAL)
 	if err != nil {
 		return err
 	}
-	luhRewads, err := svc.governance.GetLUH(governance.LAST_UPDATE_HEIGHT_REWARDS)
+	luhStaking, err := govern.GetLUH(governance.LAST_UPDATE_HEIGHT_STAKING)
 	if err != nil {
 		return err
 	}
-	luhOns, err := svc.governance.GetLUH(governance.LAST_UPDATE_HEIGHT_ONS)
+	luhRewads, err := govern.GetLUH(governance.LAST_UPDATE_HEIGHT_REWARDS)
 	if err != nil {
 		return err
 	}
-	luhEth, err := svc.governance.GetLUH(governance.LAST_UPDATE_HEIGHT_ETH)
+	luhOns, err := govern.GetLUH(governance.LAST_UPDATE_HEIGHT_ONS)
 	if err != nil {
 		return err
 	}
-	luhBtc, err := svc.governance.GetLUH(governance.LAST_UPDATE_HEIGHT_BTC)
+	luhEth, err := govern.GetLUH(governance.LAST_UPDATE_HEIGHT_ETH)
 	if err != nil {
 		return err
 	}
-	luhEvidence, err := svc.governance.GetLUH(governance.LAST_UPDATE_HEIGHT_EVIDENCE)
+	luhBtc, err := govern.GetLUH(governance.LAST_UPDATE_HEIGHT_BTC)
+	if err != nil {
+		return err
+	}
+	luhEvidence, err := govern.GetLUH(governance.LAST_UPDATE_HEIGHT_EVIDENCE)
 	if err != nil {
 		return err
 	}
@@ -180,6 +259,10 @@ func (svc *Service) GetGovernanceOptionsForHeight(req client.GovernanceOptionsRe
 			RewardOptions:   *rewardOpt,
 			StakingOptions:  *stakingOpt,
 			EvidenceOptions: *evidenceOpt,
+
+			FeeMarketOptions: *marketOpt,
+			GasOptions:       *gasOpt,
+			TokenOptions:     *tokenOpt,
 		},
 		LastUpdateHeight: client.LastUpdateHeights{
 			Proposal: luhProposal,
@@ -209,3 +292,15 @@ func (svc *Service) GetProposalOptions(_ client.ListTxTypesRequest, reply *clien
 	}
 	return nil
 }
+
+func (svc *Service) GetUpgradePlan(_ client.ListTxTypesRequest, reply *client.GetUpgradePlanReply) error {
+	plan, err := svc.governance.GetUpgradePlan()
+	if err != nil {
+		return err
+	}
+	*reply = client.GetUpgradePlanReply{
+		Plan:   plan,
+		Height: svc.proposalMaster.Proposal.GetState().Version(),
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package query
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/delegation"
+	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/identity"
+	codes "github.com/Oneledger/protocol/status_codes"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// queryStores are the stores a query reads, as they were committed at height
+type queryStores struct {
+	height         int64
+	balances       *balance.Store
+	validators     *identity.ValidatorStore
+	delegators     *delegation.DelegationStore
+	domains        *ons.DomainStore
+	govern         *governance.Store
+	proposalMaster *governance.ProposalMasterStore
+}
+
+// storesAt returns the stores at the requested height, the latest committed ones when the height is zero. The
+// historical stores are read from the IAVL version of the height, ErrPrunedHeight is returned once the chain state
+// rotation has released it.
+func (svc *Service) storesAt(height int64) (*queryStores, error) {
+	latest := svc.chainState.Version
+	if height == 0 {
+		return &queryStores{
+			height:         latest,
+			balances:       svc.balances,
+			validators:     svc.validators,
+			delegators:     svc.delegators,
+			domains:        svc.ons,
+			govern:         svc.govern,
+			proposalMaster: svc.proposalMaster,
+		}, nil
+	}
+	if height < 0 || height > latest {
+		return nil, codes.ErrBadHeight
+	}
+
+	state, err := storage.NewVersionedState(svc.chainState, height)
+	if err != nil {
+		return nil, codes.ErrPrunedHeight
+	}
+	ctx := svc.actionCtx(&abci.Header{Height: height}, state)
+	return &queryStores{
+		height:         height,
+		balances:       ctx.Balances,
+		validators:     ctx.Validators,
+		delegators:     ctx.Delegators,
+		domains:        ctx.Domains,
+		govern:         ctx.GovernanceStore,
+		proposalMaster: ctx.ProposalMasterStore,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package query
+
+import (
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/multisig"
+	codes "github.com/Oneledger/protocol/status_codes"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// GetMultiSigAccount returns the threshold policy of the multisig account at the last committed height
+func (svc *Service) GetMultiSigAccount(req client.MultiSigAccountRequest, reply *client.MultiSigAccountReply) error {
+	if err := req.Address.Err(); err != nil {
+		return codes.ErrBadAddress
+	}
+	height := svc.chainState.Version
+	state, err := storage.NewVersionedState(svc.chainState, height)
+	if err != nil {
+		return codes.ErrBadHeight
+	}
+
+	account, err := multisig.NewStore("msa", state).Get(req.Address)
+	if err != nil {
+		return err
+	}
+
+	*reply = client.MultiSigAccountReply{
+		Account: account,
+		Height:  height,
+	}
+	return nil
+}
@@ -38,9 +38,11 @@ func (svc *Service) ListDelegation(req client.ListDelegationRequest, reply *clie
 			}
 			return false
 		})
+		validator, _ := delegStore.GetValidator(address)
 		delegStats := client.DelegStats{
-			Active:  *activeDelegation.Amount,
-			Pending: *pendingDelegation.Amount,
+			Active:    *activeDelegation.Amount,
+			Pending:   *pendingDelegation.Amount,
+			Validator: validator,
 		}
 
 		// get delegation rewards stats
@@ -84,6 +86,7 @@ func (svc *Service) ListDelegation(req client.ListDelegationRequest, reply *clie
 	delegStore.IterateActiveAmounts(func(addr *keys.Address, coin *balance.Coin) bool {
 		// load each result's pointer into the reply, and put the pointer to the map
 		fullDelgStats := CreateFullDelgStats(*addr, *coin.Amount, *zeroAmount, false)
+		fullDelgStats.DelegStats.Validator, _ = delegStore.GetValidator(*addr)
 		reply.AllDelegStats = append(reply.AllDelegStats, &fullDelgStats)
 		delegationMap[addr.String()] = &fullDelgStats
 		return false
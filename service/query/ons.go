@@ -8,7 +8,11 @@ import (
 )
 
 func (svc *Service) ONS_GetDomainByName(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
-	domains := svc.ons
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+	domains := stores.domains
 	if len(req.Name) <= 0 {
 		return codes.ErrBadName
 	}
@@ -24,14 +28,18 @@ func (svc *Service) ONS_GetDomainByName(req client.ONSGetDomainsRequest, reply *
 
 	*reply = client.ONSGetDomainsReply{
 		Domains: ds,
-		Height:  svc.ons.State.Version(),
+		Height:  stores.height,
 	}
 
 	return nil
 }
 
 func (svc *Service) ONS_GetDomainByOwner(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
-	domains := svc.ons
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+	domains := stores.domains
 	if req.Owner == nil {
 		return codes.ErrBadOwner
 	}
@@ -50,14 +58,18 @@ func (svc *Service) ONS_GetDomainByOwner(req client.ONSGetDomainsRequest, reply
 
 	*reply = client.ONSGetDomainsReply{
 		Domains: ds,
-		Height:  svc.ons.State.Version(),
+		Height:  stores.height,
 	}
 
 	return nil
 }
 
 func (svc *Service) ONS_GetParentDomainByOwner(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
-	domains := svc.ons
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+	domains := stores.domains
 	if req.Owner == nil {
 		return codes.ErrBadOwner
 	}
@@ -79,19 +91,23 @@ func (svc *Service) ONS_GetParentDomainByOwner(req client.ONSGetDomainsRequest,
 
 	*reply = client.ONSGetDomainsReply{
 		Domains: ds,
-		Height:  svc.ons.State.Version(),
+		Height:  stores.height,
 	}
 
 	return nil
 }
 
 func (svc *Service) ONS_GetSubDomainByName(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
-	domains := svc.ons
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+	domains := stores.domains
 	if len(req.Name) <= 0 {
 		return codes.ErrBadName
 	}
 
-	_, err := domains.Get(ons.Name(req.Name))
+	_, err = domains.Get(ons.Name(req.Name))
 	if err != nil {
 		return codes.ErrDomainNotFound
 	}
@@ -114,14 +130,18 @@ func (svc *Service) ONS_GetSubDomainByName(req client.ONSGetDomainsRequest, repl
 
 	*reply = client.ONSGetDomainsReply{
 		Domains: ds,
-		Height:  svc.ons.State.Version(),
+		Height:  stores.height,
 	}
 
 	return nil
 }
 
 func (svc *Service) ONS_GetDomainOnSale(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
-	domains := svc.ons
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+	domains := stores.domains
 	if req.OnSale == false {
 		return codes.ErrFlagNotSet
 	}
@@ -136,13 +156,17 @@ func (svc *Service) ONS_GetDomainOnSale(req client.ONSGetDomainsRequest, reply *
 
 	*reply = client.ONSGetDomainsReply{
 		Domains: ds,
-		Height:  svc.ons.State.Version(),
+		Height:  stores.height,
 	}
 	return nil
 }
 
 func (svc *Service) ONS_GetDomainByBeneficiary(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
-	domains := svc.ons
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+	domains := stores.domains
 	if req.Beneficiary == nil {
 		return codes.ErrBadAddress
 	}
@@ -157,7 +181,53 @@ func (svc *Service) ONS_GetDomainByBeneficiary(req client.ONSGetDomainsRequest,
 
 	*reply = client.ONSGetDomainsReply{
 		Domains: ds,
-		Height:  svc.ons.State.Version(),
+		Height:  stores.height,
+	}
+	return nil
+}
+
+func (svc *Service) ONS_GetDomainRecords(req client.ONSGetDomainsRequest, reply *client.ONSGetRecordsReply) error {
+	if len(req.Name) <= 0 {
+		return codes.ErrBadName
+	}
+
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+
+	d, err := stores.domains.Get(ons.GetNameFromString(req.Name))
+	if err != nil {
+		return codes.ErrDomainNotFound
+	}
+
+	*reply = client.ONSGetRecordsReply{
+		Name:    d.Name.String(),
+		Records: d.Records,
+		Height:  stores.height,
+	}
+	return nil
+}
+
+func (svc *Service) ONS_ReverseLookup(req client.ONSReverseLookupRequest, reply *client.ONSReverseLookupReply) error {
+	if req.Address == nil {
+		return codes.ErrBadAddress
+	}
+
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+
+	height := stores.height
+	d, err := stores.domains.GetPrimary(req.Address, height)
+	if err != nil {
+		return codes.ErrDomainNotFound
+	}
+
+	*reply = client.ONSReverseLookupReply{
+		Name:   d.Name.String(),
+		Height: height,
 	}
 	return nil
 }
@@ -7,6 +7,8 @@ import (
 	"github.com/Oneledger/protocol/data/evm"
 	netwkDeleg "github.com/Oneledger/protocol/data/network_delegation"
 	"github.com/Oneledger/protocol/data/rewards"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/mempool"
 
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/client"
@@ -19,6 +21,7 @@ import (
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
 	codes "github.com/Oneledger/protocol/status_codes"
+	"github.com/Oneledger/protocol/storage"
 	"github.com/Oneledger/protocol/utils"
 )
 
@@ -42,6 +45,9 @@ type Service struct {
 	txTypes         *[]action.TxTypeDescribe
 	contracts       *evm.ContractStore
 	accountKeeper   balance.AccountKeeper
+	chainState      *storage.ChainState
+	actionCtx       func(header *abci.Header, state *storage.State) *action.Context
+	mempool         mempool.Mempool
 }
 
 func Name() string {
@@ -51,6 +57,7 @@ func Name() string {
 func NewService(ctx client.ExtServiceContext, balances *balance.Store, currencies *balance.CurrencySet, validators *identity.ValidatorStore, witnesses *identity.WitnessStore,
 	domains *ons.DomainStore, delegators *delegation.DelegationStore, netwkDelegators *netwkDeleg.MasterStore, evidenceStore *evidence.EvidenceStore, govern *governance.Store, feePool *fees.Store, proposalMaster *governance.ProposalMasterStore, rewardMaster *rewards.RewardMasterStore, logger *log.Logger, txTypes *[]action.TxTypeDescribe,
 	contracts *evm.ContractStore, accountKeeper balance.AccountKeeper,
+	chainState *storage.ChainState, actionCtx func(header *abci.Header, state *storage.State) *action.Context, mempool mempool.Mempool,
 ) *Service {
 	service := &Service{
 		name:            "query",
@@ -72,6 +79,9 @@ func NewService(ctx client.ExtServiceContext, balances *balance.Store, currencie
 		governance:      govern,
 		contracts:       contracts,
 		accountKeeper:   accountKeeper,
+		chainState:      chainState,
+		actionCtx:       actionCtx,
+		mempool:         mempool,
 	}
 	return service
 }
@@ -82,8 +92,13 @@ func (svc *Service) Balance(req client.BalanceRequest, resp *client.BalanceReply
 		return codes.ErrBadAddress
 	}
 
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+
 	addr := req.Address
-	bal, err := svc.balances.GetBalance(addr, svc.currencies)
+	bal, err := stores.balances.GetBalance(addr, svc.currencies)
 
 	if err != nil {
 		svc.logger.Error("error getting balance", err)
@@ -92,21 +107,41 @@ func (svc *Service) Balance(req client.BalanceRequest, resp *client.BalanceReply
 
 	*resp = client.BalanceReply{
 		Balance: bal.String(),
-		Height:  svc.balances.State.Version(),
+		Height:  stores.height,
+	}
+	return nil
+}
+
+// Nonce returns the account nonce the next transaction of the address must carry
+func (svc *Service) Nonce(req client.NonceRequest, resp *client.NonceReply) error {
+	err := req.Address.Err()
+	if err != nil {
+		return codes.ErrBadAddress
+	}
+
+	height := svc.balances.State.Version()
+	*resp = client.NonceReply{
+		Nonce:  action.PendingNonce(svc.accountKeeper, req.Address, height, svc.mempool.ReapMaxTxs(-1)),
+		Height: height,
 	}
 	return nil
 }
 
 func (svc *Service) BalancePool(req client.BalancePoolRequest, resp *client.BalanceReply) error {
 
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+
 	poolname := req.Poolname
-	poolList, err := svc.governance.GetPoolList()
+	poolList, err := stores.govern.GetPoolList()
 	if err != nil {
 		return err
 	}
 	bal := &balance.Balance{}
 	if pool, ok := poolList[poolname]; ok {
-		bal, err = svc.balances.GetBalance(pool, svc.currencies)
+		bal, err = stores.balances.GetBalance(pool, svc.currencies)
 		if err != nil {
 			svc.logger.Error("error getting balance", err)
 			return codes.ErrGettingBalance
@@ -115,7 +150,7 @@ func (svc *Service) BalancePool(req client.BalancePoolRequest, resp *client.Bala
 
 	*resp = client.BalanceReply{
 		Balance: bal.String(),
-		Height:  svc.balances.State.Version(),
+		Height:  stores.height,
 	}
 	return nil
 }
@@ -171,8 +206,13 @@ func (svc *Service) CurrencyBalance(req client.CurrencyBalanceRequest, resp *cli
 		return codes.ErrFindingCurrency
 	}
 
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+
 	addr := req.Address
-	bal, err := svc.balances.GetBalance(addr, svc.currencies)
+	bal, err := stores.balances.GetBalance(addr, svc.currencies)
 
 	if err != nil {
 		svc.logger.Error("error getting balance", err)
@@ -184,7 +224,7 @@ func (svc *Service) CurrencyBalance(req client.CurrencyBalanceRequest, resp *cli
 	*resp = client.CurrencyBalanceReply{
 		Currency: currency.Name,
 		Balance:  coin.Humanize(),
-		Height:   svc.balances.State.Version(),
+		Height:   stores.height,
 	}
 	return nil
 }
@@ -213,12 +253,17 @@ func (svc *Service) ValidatorStatus(req client.ValidatorStatusRequest, resp *cli
 		return codes.ErrBadAddress
 	}
 
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+
 	exists := false
-	validator, err := svc.validators.Get(req.Address)
+	validator, err := stores.validators.Get(req.Address)
 	if err != nil {
 		*resp = client.ValidatorStatusReply{
 			Exists:                exists,
-			Height:                svc.balances.State.Version(),
+			Height:                stores.height,
 			Staking:               "0",
 			TotalDelegationAmount: "0",
 			SelfDelegationAmount:  "0",
@@ -229,8 +274,8 @@ func (svc *Service) ValidatorStatus(req client.ValidatorStatusRequest, resp *cli
 
 	svc.logger.Infof("Validator - %s, delegator - %s\n", validator.Address.Humanize(), validator.StakeAddress.Humanize())
 
-	totalDelegationAmount, _ := svc.delegators.GetValidatorAmount(validator.Address)
-	selfDelegationAmount, _ := svc.delegators.GetValidatorDelegationAmount(validator.Address, validator.StakeAddress)
+	totalDelegationAmount, _ := stores.delegators.GetValidatorAmount(validator.Address)
+	selfDelegationAmount, _ := stores.delegators.GetValidatorDelegationAmount(validator.Address, validator.StakeAddress)
 	delegationAmount, _ := totalDelegationAmount.Minus(*selfDelegationAmount)
 
 	if validator.Power > 0 {
@@ -239,7 +284,7 @@ func (svc *Service) ValidatorStatus(req client.ValidatorStatusRequest, resp *cli
 
 	*resp = client.ValidatorStatusReply{
 		Exists:                exists,
-		Height:                svc.balances.State.Version(),
+		Height:                stores.height,
 		Power:                 validator.Power,
 		Staking:               validator.Staking.String(),
 		TotalDelegationAmount: totalDelegationAmount.String(),
@@ -256,18 +301,23 @@ func (svc *Service) DelegationStatus(req client.DelegationStatusRequest, resp *c
 		return codes.ErrBadAddress
 	}
 
-	options, _ := svc.govern.GetStakingOptions()
+	stores, err := svc.storesAt(req.Height)
+	if err != nil {
+		return err
+	}
+
+	options, _ := stores.govern.GetStakingOptions()
 	if options == nil {
 		return codes.ErrFlagNotSet
 	}
 
-	effectiveDelegationAmount, _ := svc.delegators.GetDelegatorEffectiveAmount(req.Address)
-	withdrawableAmount, _ := svc.delegators.GetDelegatorBoundedAmount(req.Address)
+	effectiveDelegationAmount, _ := stores.delegators.GetDelegatorEffectiveAmount(req.Address)
+	withdrawableAmount, _ := stores.delegators.GetDelegatorBoundedAmount(req.Address)
 
-	height := svc.balances.State.Version()
-	maturedAmounts := svc.delegators.GetMaturedPendingAmount(req.Address, height, options.MaturityTime+1)
+	height := stores.height
+	maturedAmounts := stores.delegators.GetMaturedPendingAmount(req.Address, height, options.MaturityTime+1)
 
-	bal, err := svc.balances.GetBalance(req.Address, svc.currencies)
+	bal, err := stores.balances.GetBalance(req.Address, svc.currencies)
 
 	if err != nil {
 		svc.logger.Error("error getting balance", err)
@@ -279,6 +329,7 @@ func (svc *Service) DelegationStatus(req client.DelegationStatusRequest, resp *c
 		EffectiveDelegationAmount: effectiveDelegationAmount.String(),
 		WithdrawableAmount:        withdrawableAmount.String(),
 		MaturedAmounts:            maturedAmounts,
+		Height:                    height,
 	}
 
 	return nil
@@ -291,6 +342,29 @@ func (svc *Service) FeeOptions(_ struct{}, reply *client.FeeOptionsReply) error
 	return nil
 }
 
+// BaseFee returns the minimum gas price of the next block and the state of the fee market
+func (svc *Service) BaseFee(_ struct{}, reply *client.BaseFeeReply) error {
+	marketOpt, err := svc.governance.GetFeeMarketOptions()
+	if err != nil {
+		return err
+	}
+	minFee := svc.feePool.GetOpt().MinFee()
+	baseFee := svc.feePool.GetOpt().FeeCurrency.NewCoinFromInt(0)
+	if marketOpt.Enabled {
+		baseFee = svc.feePool.BaseFee()
+		if minFee.LessThanCoin(baseFee) {
+			minFee = baseFee
+		}
+	}
+	*reply = client.BaseFeeReply{
+		MinFee:           minFee,
+		BaseFee:          baseFee,
+		FeeMarketOptions: *marketOpt,
+		Height:           svc.balances.State.Version(),
+	}
+	return nil
+}
+
 func (svc *Service) ListTxTypes(_ client.ListTxTypesRequest, reply *client.ListTxTypesReply) error {
 	var txTypes []action.TxTypeDescribe
 	//find all const types that less than EOF marker
@@ -0,0 +1,99 @@
+package query
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/serialize"
+	codes "github.com/Oneledger/protocol/status_codes"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// SimulateTx runs the unsigned transaction against a throw-away copy of the state at the latest, or the requested,
+// height and returns the gas it uses, nothing is written back to the chain state
+func (svc *Service) SimulateTx(req client.SimulateTxRequest, resp *client.SimulateTxReply) error {
+	tx := action.RawTx{}
+	err := serialize.GetSerializer(serialize.NETWORK).Deserialize(req.RawTx, &tx)
+	if err != nil {
+		return codes.ErrSerialization
+	}
+	// the evm transactions are simulated by eth_estimateGas
+	if tx.Type == action.OLVM {
+		return codes.ErrSimulateTxType
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = svc.chainState.Version
+	}
+	state, err := storage.NewVersionedState(svc.chainState, height)
+	if err != nil {
+		return codes.ErrBadHeight
+	}
+
+	// the gas is measured whatever the limit set by the transaction is
+	if tx.Fee.Gas == 0 {
+		tx.Fee.Gas = math.MaxInt64
+	}
+
+	// the transaction is priced with the gas schedule of the state it runs on
+	gasOpt, err := governance.NewStore("g", state).GetGasOptions()
+	if err != nil {
+		return errors.Wrap(err, "failed to get gas options")
+	}
+	header := &abci.Header{Height: height + 1, Time: time.Now().UTC()}
+	ctx := svc.actionCtx(header, state.WithGas(storage.NewGasCalculatorWithOptions(math.MaxInt64, *gasOpt)))
+	ctx.Simulation = true
+
+	signedTx := action.SignedTx{RawTx: tx}
+	handler := ctx.Router.Handler(tx.Type)
+	ctx.State.SetTxType(int(tx.Type))
+	start := ctx.State.ConsumedGas()
+
+	*resp = client.SimulateTxReply{Height: height}
+
+	_, err = handler.Validate(ctx, signedTx)
+	if err != nil {
+		resp.Code, resp.Log = errorCode(err), err.Error()
+		return nil
+	}
+
+	ok, response := handler.ProcessCheck(ctx, signedTx.RawTx)
+	// the size doesn't count the signatures, the client adds them to the estimate
+	size := storage.Gas(len(signedTx.SignedBytes()))
+	feeOk, feeResponse := handler.ProcessFee(ctx, signedTx, start, size, storage.Gas(response.GasUsed))
+
+	resp.OK = ok && feeOk
+	resp.GasUsed = feeResponse.GasUsed
+	resp.Events = response.Events
+	if !ok {
+		resp.Code, resp.Log = logCode(response.Log), response.Log
+	} else if !feeOk {
+		resp.Code, resp.Log = logCode(feeResponse.Log), feeResponse.Log
+	}
+	return nil
+}
+
+func errorCode(err error) int {
+	switch e := errors.Cause(err).(type) {
+	case codes.ProtocolError:
+		return e.Code
+	case *codes.ProtocolError:
+		return e.Code
+	}
+	return codes.GeneralErr
+}
+
+func logCode(log string) int {
+	protocolErr, err := codes.UnMarshalError(log)
+	if err != nil || protocolErr.Code == 0 {
+		return codes.GeneralErr
+	}
+	return protocolErr.Code
+}
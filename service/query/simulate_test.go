@@ -0,0 +1,126 @@
+package query
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/transfer"
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/serialize"
+	codes "github.com/Oneledger/protocol/status_codes"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestService_SimulateTx(t *testing.T) {
+	olt := balance.Currency{Name: "OLT", Chain: chain.ONELEDGER, Decimal: 18, Unit: "nue"}
+	currencies := balance.NewCurrencySet()
+	require.NoError(t, currencies.Register(olt))
+	router := action.NewRouter("test")
+	require.NoError(t, transfer.EnableSend(router))
+
+	actionCtx := func(header *abci.Header, state *storage.State) *action.Context {
+		feePool := fees.NewStore("f", state)
+		feePool.SetupOpt(&fees.FeeOption{FeeCurrency: olt, MinFeeDecimal: 9})
+		return &action.Context{
+			Router:     router,
+			State:      state,
+			Header:     header,
+			Balances:   balance.NewStore("b", state),
+			Currencies: currencies,
+			FeePool:    feePool,
+			Logger:     log.NewLoggerWithPrefix(os.Stdout, "test"),
+			Forks:      config.DefaultForkParams(),
+		}
+	}
+
+	cs := storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, ""))
+	require.NoError(t, cs.SetupRotation(config.ChainStateRotationCfg{Recent: 10}))
+	from := keys.Address(ed25519.GenPrivKey().PubKey().Address())
+	to := keys.Address(ed25519.GenPrivKey().PubKey().Address())
+	balances := balance.NewStore("b", storage.NewState(cs))
+	require.NoError(t, balances.AddToAddress(from, olt.NewCoinFromInt(10)))
+	_, height := balances.State.Commit()
+
+	svc := &Service{chainState: cs, actionCtx: actionCtx}
+
+	send := transfer.Send{From: from, To: to, Amount: action.Amount{Currency: "OLT", Value: *balance.NewAmount(1000)}}
+	data, err := send.Marshal()
+	require.NoError(t, err)
+	tx := action.RawTx{
+		Type: action.SEND,
+		Data: data,
+		Fee:  action.Fee{Price: action.Amount{Currency: "OLT", Value: *balance.NewAmount(1000000000)}},
+	}
+	rawTx, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	require.NoError(t, err)
+
+	t.Run("test simulate a transfer and it reports its gas", func(t *testing.T) {
+		resp := &client.SimulateTxReply{}
+		require.NoError(t, svc.SimulateTx(client.SimulateTxRequest{RawTx: rawTx}, resp))
+		require.True(t, resp.OK, resp.Log)
+		assert.Equal(t, height, resp.Height)
+		assert.NotEmpty(t, resp.Events)
+
+		// the gas is the one the transfer uses when it is delivered unsigned on the same state
+		state, err := storage.NewVersionedState(cs, height)
+		require.NoError(t, err)
+		ctx := actionCtx(&abci.Header{Height: height + 1}, state.WithGas(storage.NewGasCalculator(math.MaxInt64)))
+		ctx.Simulation = true
+		signedTx := action.SignedTx{RawTx: tx}
+		signedTx.Fee.Gas = math.MaxInt64
+		handler := router.Handler(action.SEND)
+		ctx.State.SetTxType(int(action.SEND))
+		start := ctx.State.ConsumedGas()
+		ok, response := handler.ProcessDeliver(ctx, signedTx.RawTx)
+		require.True(t, ok, response.Log)
+		ok, feeResponse := handler.ProcessFee(ctx, signedTx, start, storage.Gas(len(signedTx.SignedBytes())), storage.Gas(response.GasUsed))
+		require.True(t, ok, feeResponse.Log)
+		assert.Equal(t, feeResponse.GasUsed, resp.GasUsed)
+		assert.True(t, resp.GasUsed > 0)
+
+		// nothing is written back to the chain state
+		assert.Equal(t, height, cs.Version)
+		balances := balance.NewStore("b", storage.NewState(cs))
+		coin, err := balances.GetBalanceForCurr(from, &olt)
+		require.NoError(t, err)
+		assert.Equal(t, olt.NewCoinFromInt(10).Amount, coin.Amount)
+		coin, err = balances.GetBalanceForCurr(to, &olt)
+		require.NoError(t, err)
+		assert.True(t, coin.Amount.BigInt().Sign() == 0)
+	})
+
+	t.Run("test simulate a transfer above the balance and it fails", func(t *testing.T) {
+		tx := tx
+		send := send
+		send.Amount = action.Amount{Currency: "OLT", Value: *olt.NewCoinFromInt(100).Amount}
+		tx.Data, err = send.Marshal()
+		require.NoError(t, err)
+		rawTx, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+		require.NoError(t, err)
+
+		resp := &client.SimulateTxReply{}
+		require.NoError(t, svc.SimulateTx(client.SimulateTxRequest{RawTx: rawTx}, resp))
+		assert.False(t, resp.OK)
+		assert.NotEmpty(t, resp.Log)
+	})
+
+	t.Run("test simulate at a missing height and it is error", func(t *testing.T) {
+		resp := &client.SimulateTxReply{}
+		err := svc.SimulateTx(client.SimulateTxRequest{RawTx: rawTx, Height: height + 10}, resp)
+		assert.Equal(t, codes.ErrBadHeight, err)
+	})
+}
@@ -0,0 +1,49 @@
+package query
+
+import (
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/tokens"
+	codes "github.com/Oneledger/protocol/status_codes"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// GetToken returns the token of the currency name, with its issuer and supply at the last committed height
+func (svc *Service) GetToken(req client.TokenRequest, reply *client.TokenReply) error {
+	height := svc.chainState.Version
+	state, err := storage.NewVersionedState(svc.chainState, height)
+	if err != nil {
+		return codes.ErrBadHeight
+	}
+
+	token, err := tokens.NewStore("tkn", state).Get(req.Name)
+	if err != nil {
+		return err
+	}
+
+	*reply = client.TokenReply{
+		Token:  token,
+		Height: height,
+	}
+	return nil
+}
+
+// ListTokens returns all the tokens created at runtime
+func (svc *Service) ListTokens(_ client.EmptyRequest, reply *client.ListTokensReply) error {
+	height := svc.chainState.Version
+	state, err := storage.NewVersionedState(svc.chainState, height)
+	if err != nil {
+		return codes.ErrBadHeight
+	}
+
+	list := make([]tokens.Token, 0)
+	tokens.NewStore("tkn", state).Iterate(func(token *tokens.Token) bool {
+		list = append(list, *token)
+		return false
+	})
+
+	*reply = client.ListTokensReply{
+		Tokens: list,
+		Height: height,
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package query
+
+import (
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/balance"
+	codes "github.com/Oneledger/protocol/status_codes"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// GetVestingAccount returns the vesting schedule of the address for the currency and how much of its balance is still
+// locked at the last committed height
+func (svc *Service) GetVestingAccount(req client.VestingAccountRequest, reply *client.VestingAccountReply) error {
+	if err := req.Address.Err(); err != nil {
+		return codes.ErrBadAddress
+	}
+	if req.Currency == "" {
+		req.Currency = "OLT"
+	}
+	currency, ok := svc.currencies.GetCurrencyByName(req.Currency)
+	if !ok {
+		return codes.ErrFindingCurrency
+	}
+	height := svc.chainState.Version
+	state, err := storage.NewVersionedState(svc.chainState, height)
+	if err != nil {
+		return codes.ErrBadHeight
+	}
+
+	balances := balance.NewStore("b", state)
+	vesting, err := balances.GetVesting(req.Address, currency.Name)
+	if err != nil {
+		return err
+	}
+	locked, err := balances.GetLockedBalance(req.Address, &currency)
+	if err != nil {
+		return err
+	}
+
+	*reply = client.VestingAccountReply{
+		Vesting: vesting,
+		Locked:  locked.Humanize(),
+		Height:  height,
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package tx
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/batch"
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/serialize"
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+// CreateRawBatch creates a batch of the messages of the raw transactions, their fees and nonces are dropped
+func (svc *Service) CreateRawBatch(args client.BatchRequest, reply *client.CreateTxReply) error {
+	b := batch.Batch{Messages: make([]batch.Message, 0, len(args.RawTxs))}
+	for _, packet := range args.RawTxs {
+		rawTx := &action.RawTx{}
+		err := serialize.GetSerializer(serialize.NETWORK).Deserialize(packet, rawTx)
+		if err != nil {
+			return codes.ErrSerialization
+		}
+		b.Messages = append(b.Messages, batch.Message{Type: rawTx.Type, Data: rawTx.Data})
+	}
+
+	signers := b.Signers()
+	if len(signers) == 0 {
+		return action.ErrInvalidBatch
+	}
+
+	data, err := b.Marshal()
+	if err != nil {
+		return codes.ErrSerialization
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type: action.BATCH,
+		Data: data,
+		Fee: action.Fee{
+			Price: args.GasPrice,
+			Gas:   args.Gas,
+		},
+		Memo:  uuidNew.String(),
+		Nonce: svc.nonce(signers[0]),
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
@@ -0,0 +1,80 @@
+package tx
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/feegrant"
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/serialize"
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+func (svc *Service) FeeGrant(args client.FeeGrantRequest, reply *client.CreateTxReply) error {
+	grant := feegrant.FeeGrant{
+		Granter:        args.Granter,
+		Grantee:        args.Grantee,
+		SpendLimit:     args.SpendLimit,
+		Expiry:         args.Expiry,
+		AllowedTxTypes: args.AllowedTxTypes,
+	}
+	data, err := grant.Marshal()
+	if err != nil {
+		return codes.ErrSerialization
+	}
+	return svc.createTx(action.FEE_GRANT, data, args.Granter, args.GasPrice, args.Gas, reply)
+}
+
+func (svc *Service) FeeRevoke(args client.FeeRevokeRequest, reply *client.CreateTxReply) error {
+	revoke := feegrant.FeeRevoke{
+		Granter: args.Granter,
+		Grantee: args.Grantee,
+	}
+	data, err := revoke.Marshal()
+	if err != nil {
+		return codes.ErrSerialization
+	}
+	return svc.createTx(action.FEE_REVOKE, data, args.Granter, args.GasPrice, args.Gas, reply)
+}
+
+// SetFeePayer sets the fee payer of the raw tx, the tx is then signed by its signers and the fee payer either signs
+// it too or pays from the allowance it granted to the first signer
+func (svc *Service) SetFeePayer(args client.SetFeePayerRequest, reply *client.CreateTxReply) error {
+	tx := &action.RawTx{}
+	err := serialize.GetSerializer(serialize.NETWORK).Deserialize(args.RawTx, tx)
+	if err != nil {
+		return codes.ErrSerialization
+	}
+	if err := args.FeePayer.Err(); err != nil {
+		return codes.ErrBadAddress
+	}
+	tx.FeePayer = args.FeePayer
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		return codes.ErrSerialization
+	}
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
+
+func (svc *Service) createTx(t action.Type, data []byte, signer action.Address, gasPrice action.Amount, gas int64, reply *client.CreateTxReply) error {
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type: t,
+		Data: data,
+		Fee: action.Fee{
+			Price: gasPrice,
+			Gas:   gas,
+		},
+		Memo:  uuidNew.String(),
+		Nonce: svc.nonce(signer),
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		return codes.ErrSerialization
+	}
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
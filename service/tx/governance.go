@@ -31,6 +31,7 @@ func (s *Service) CreateProposal(args client.CreateProposalRequest, reply *clien
 		VotingDeadline:  args.VotingDeadline,
 		PassPercentage:  args.PassPercentage,
 		ConfigUpdate:    args.ConfigUpdate,
+		Upgrade:         args.Upgrade,
 	}
 
 	data, err := createProposal.Marshal()
@@ -45,10 +46,11 @@ func (s *Service) CreateProposal(args client.CreateProposalRequest, reply *clien
 	}
 
 	tx := &action.RawTx{
-		Type: action.PROPOSAL_CREATE,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.PROPOSAL_CREATE,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(createProposal.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -81,10 +83,11 @@ func (s *Service) FundProposal(args client.FundProposalRequest, reply *client.Cr
 	}
 
 	tx := &action.RawTx{
-		Type: action.PROPOSAL_FUND,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.PROPOSAL_FUND,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(fundProposal.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -116,10 +119,11 @@ func (s *Service) CancelProposal(args client.CancelProposalRequest, reply *clien
 	}
 
 	tx := &action.RawTx{
-		Type: action.PROPOSAL_CANCEL,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.PROPOSAL_CANCEL,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(cancelProposal.Signers()[0]),
 	}
 
 	packet := tx.RawBytes()
@@ -149,10 +153,11 @@ func (s *Service) WithdrawProposalFunds(args client.WithdrawFundsRequest, reply
 	}
 
 	tx := &action.RawTx{
-		Type: action.PROPOSAL_WITHDRAW_FUNDS,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.PROPOSAL_WITHDRAW_FUNDS,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(withdrawProposal.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -166,6 +171,10 @@ func (s *Service) WithdrawProposalFunds(args client.WithdrawFundsRequest, reply
 }
 
 func (s *Service) VoteProposal(args client.VoteProposalRequest, reply *client.VoteProposalReply) error {
+	if args.Delegator {
+		return s.delegatorVoteProposal(args, reply)
+	}
+
 	// this node address is voter
 	hPub, err := s.nodeContext.ValidatorPubKey().GetHandler()
 	if err != nil {
@@ -205,10 +214,11 @@ func (s *Service) VoteProposal(args client.VoteProposalRequest, reply *client.Vo
 	}
 
 	tx := action.RawTx{
-		Type: action.PROPOSAL_VOTE,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.PROPOSAL_VOTE,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(voteProposal.Signers()[0]),
 	}
 
 	// validator signs Tx
@@ -222,3 +232,39 @@ func (s *Service) VoteProposal(args client.VoteProposalRequest, reply *client.Vo
 
 	return nil
 }
+
+// delegatorVoteProposal creates the vote of a network delegator, it is only signed by the delegator
+func (s *Service) delegatorVoteProposal(args client.VoteProposalRequest, reply *client.VoteProposalReply) error {
+	if args.Opinion == governance.OPIN_UNKNOWN {
+		return errors.New("invalid vote opinion")
+	}
+
+	voteProposal := gov.VoteProposal{
+		ProposalID: governance.ProposalID(args.ProposalId),
+		Address:    args.Address,
+		Opinion:    args.Opinion,
+	}
+
+	data, err := voteProposal.Marshal()
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	fee := action.Fee{
+		Price: args.GasPrice,
+		Gas:   args.Gas,
+	}
+
+	tx := action.RawTx{
+		Type:  action.PROPOSAL_VOTE,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(voteProposal.Signers()[0]),
+	}
+
+	*reply = client.VoteProposalReply{RawTx: tx.RawBytes()}
+
+	return nil
+}
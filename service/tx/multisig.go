@@ -0,0 +1,23 @@
+package tx
+
+import (
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/multisig"
+	"github.com/Oneledger/protocol/client"
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+// MultiSigAccountCreate creates the raw tx registering the multisig account of the members, the txs of the account
+// are then built by the other tx services with the account address as the signer
+func (svc *Service) MultiSigAccountCreate(args client.MultiSigAccountCreateRequest, reply *client.CreateTxReply) error {
+	create := multisig.CreateAccount{
+		Creator:   args.Creator,
+		Threshold: args.Threshold,
+		Members:   args.Members,
+	}
+	data, err := create.Marshal()
+	if err != nil {
+		return codes.ErrSerialization
+	}
+	return svc.createTx(action.MULTISIG_ACCOUNT_CREATE, data, args.Creator, args.GasPrice, args.Gas, reply)
+}
@@ -14,6 +14,7 @@ func (s *Service) AddNetworkDelegation(args client.NetworkDelegateRequest, reply
 	networkDelegation := nwd.AddNetworkDelegation{
 		DelegationAddress: args.DelegationAddress,
 		Amount:            args.Amount,
+		Validator:         args.Validator,
 	}
 
 	data, err := networkDelegation.Marshal()
@@ -28,10 +29,11 @@ func (s *Service) AddNetworkDelegation(args client.NetworkDelegateRequest, reply
 	}
 
 	tx := &action.RawTx{
-		Type: action.ADD_NETWORK_DELEGATE,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.ADD_NETWORK_DELEGATE,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(networkDelegation.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -62,10 +64,11 @@ func (s *Service) NetworkUndelegate(args client.NetUndelegateRequest, reply *cli
 	}
 	uuidNew, _ := uuid.NewUUID()
 	tx := &action.RawTx{
-		Type: action.NETWORK_UNDELEGATE,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.NETWORK_UNDELEGATE,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(undelegate.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -97,7 +100,8 @@ func (s *Service) WithdrawDelegRewards(args client.WithdrawDelegRewardsRequest,
 			Price: action.Amount{Currency: "OLT", Value: *feeAmount.Amount},
 			Gas:   80000,
 		},
-		Memo: uuidNew.String(),
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(withdraw.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -129,7 +133,8 @@ func (s *Service) ReinvestDelegRewards(args client.ReinvestDelegRewardsRequest,
 			Price: action.Amount{Currency: "OLT", Value: *feeAmount.Amount},
 			Gas:   20000,
 		},
-		Memo: uuidNew.String(),
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(invest.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -34,10 +34,11 @@ func (s *Service) ONS_CreateRawCreate(args client.ONSCreateRequest, reply *clien
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_CREATE,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.DOMAIN_CREATE,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(domainCreate.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -71,10 +72,11 @@ func (s *Service) ONS_CreateRawUpdate(args client.ONSUpdateRequest, reply *clien
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_UPDATE,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.DOMAIN_UPDATE,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(domainUpdate.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -107,10 +109,11 @@ func (s *Service) ONS_CreateRawRenew(args client.ONSRenewRequest, reply *client.
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_RENEW,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.DOMAIN_RENEW,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(renewDomain.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -144,10 +147,11 @@ func (s *Service) ONS_CreateRawSale(args client.ONSSaleRequest, reply *client.Cr
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_SELL,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.DOMAIN_SELL,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(domainSale.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -181,10 +185,11 @@ func (s *Service) ONS_CreateRawBuy(args client.ONSPurchaseRequest, reply *client
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_PURCHASE,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.DOMAIN_PURCHASE,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(domainPurchase.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -217,10 +222,11 @@ func (s *Service) ONS_CreateRawSend(args client.ONSSendRequest, reply *client.Cr
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_SEND,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.DOMAIN_SEND,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(domainSend.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -252,10 +258,11 @@ func (s *Service) ONS_CreateRawDeleteSub(args client.ONSDeleteSubRequest, reply
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_DELETE_SUB,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.DOMAIN_DELETE_SUB,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(del.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -269,3 +276,41 @@ func (s *Service) ONS_CreateRawDeleteSub(args client.ONSDeleteSubRequest, reply
 	}
 	return nil
 }
+
+func (s *Service) ONS_CreateRawSetRecord(args client.ONSSetRecordRequest, reply *client.CreateTxReply) error {
+
+	name := ons2.GetNameFromString(args.Name)
+	record := ons.DomainSetRecord{
+		Owner:      args.Owner,
+		Name:       name,
+		RecordType: args.RecordType,
+		Key:        args.Key,
+		Value:      args.Value,
+	}
+	data, err := record.Marshal()
+	if err != nil {
+		s.logger.Error("error in serializing domain record object", err)
+		return codes.ErrSerialization
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	fee := action.Fee{args.GasPrice, args.Gas}
+	tx := &action.RawTx{
+		Type:  action.DOMAIN_SET_RECORD,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(record.Signers()[0]),
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		s.logger.Error("error in serializing domain record transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{
+		RawTx: packet,
+	}
+	return nil
+}
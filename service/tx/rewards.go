@@ -33,10 +33,11 @@ func (s *Service) WithdrawRewards(args client.WithdrawRewardsRequest, reply *cli
 	uuidNew, _ := uuid.NewUUID()
 	feeAmount := s.feeOpt.MinFee()
 	tx := &action.RawTx{
-		Type: action.WITHDRAW_REWARD,
-		Data: data,
-		Fee:  action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
-		Memo: uuidNew.String(),
+		Type:  action.WITHDRAW_REWARD,
+		Data:  data,
+		Fee:   action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
+		Memo:  uuidNew.String(),
+		Nonce: s.nonce(withdrawRewards.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
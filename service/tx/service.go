@@ -21,6 +21,7 @@ import (
 	"github.com/Oneledger/protocol/serialize"
 	codes "github.com/Oneledger/protocol/status_codes"
 	"github.com/google/uuid"
+	"github.com/tendermint/tendermint/mempool"
 )
 
 func Name() string {
@@ -38,6 +39,8 @@ type Service struct {
 	feeOpt        *fees.FeeOption
 	logger        *log.Logger
 	nodeContext   node.Context
+	accountKeeper balance.AccountKeeper
+	mempool       mempool.Mempool
 }
 
 func NewService(
@@ -51,6 +54,8 @@ func NewService(
 	feeOpt *fees.FeeOption,
 	nodeCtx node.Context,
 	logger *log.Logger,
+	accountKeeper balance.AccountKeeper,
+	mempool mempool.Mempool,
 ) *Service {
 	return &Service{
 		balances:      balances,
@@ -63,9 +68,17 @@ func NewService(
 		evidenceStore: evidenceStore,
 		feeOpt:        feeOpt,
 		logger:        logger,
+		accountKeeper: accountKeeper,
+		mempool:       mempool,
 	}
 }
 
+// nonce returns the account nonce for a new transaction of the signer
+func (svc *Service) nonce(signer keys.Address) uint64 {
+	height := svc.balances.State.Version()
+	return action.PendingNonce(svc.accountKeeper, signer, height, svc.mempool.ReapMaxTxs(-1))
+}
+
 func getSendTxContext(args client.SendTxRequest) (data []byte, t action.Type, err error) {
 	msg := transfer.Send{
 		From:   keys.Address(args.From),
@@ -90,10 +103,11 @@ func (svc *Service) SendTx(args client.SendTxRequest, reply *client.CreateTxRepl
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := action.RawTx{
-		Type: t,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  t,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: svc.nonce(args.From),
 	}
 
 	if _, err := svc.accounts.GetAccount(args.From); err != nil {
@@ -104,7 +118,7 @@ func (svc *Service) SendTx(args client.SendTxRequest, reply *client.CreateTxRepl
 	if err != nil {
 		return err
 	}
-	signatures := []action.Signature{{pubKey, signed}}
+	signatures := []action.Signature{{Signer: pubKey, Signed: signed}}
 	signedTx := &action.SignedTx{
 		RawTx:      tx,
 		Signatures: signatures,
@@ -136,10 +150,11 @@ func (svc *Service) CreateRawSend(args client.SendTxRequest, reply *client.Creat
 
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: t,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  t,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: svc.nonce(args.From),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -170,10 +185,11 @@ func (svc *Service) CreateRawSendPool(args client.SendPoolTxRequest, reply *clie
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := action.RawTx{
-		Type: action.SENDPOOL,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:  action.SENDPOOL,
+		Data:  data,
+		Fee:   fee,
+		Memo:  uuidNew.String(),
+		Nonce: svc.nonce(sendpool.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -228,7 +244,8 @@ func (svc *Service) Vote(args client.VoteRequest, reply *client.VoteReply) error
 			},
 			Gas: 100000,
 		},
-		Memo: uuidNew.String(),
+		Memo:  uuidNew.String(),
+		Nonce: svc.nonce(allegation.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -298,7 +315,8 @@ func (svc *Service) Allegation(args client.AllegationRequest, reply *client.Alle
 			},
 			Gas: 100000,
 		},
-		Memo: uuidNew.String(),
+		Memo:  uuidNew.String(),
+		Nonce: svc.nonce(allegation.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -351,7 +369,8 @@ func (svc *Service) Release(args client.ReleaseRequest, reply *client.ReleaseRep
 			},
 			Gas: 100000,
 		},
-		Memo: uuidNew.String(),
+		Memo:  uuidNew.String(),
+		Nonce: svc.nonce(release.Signers()[0]),
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -398,10 +417,11 @@ func (svc *Service) Stake(args client.StakeRequest, reply *client.StakeReply) er
 	feeAmount := svc.feeOpt.MinFee()
 
 	tx := action.RawTx{
-		Type: action.STAKE,
-		Data: data,
-		Fee:  action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
-		Memo: uuidNew.String(),
+		Type:  action.STAKE,
+		Data:  data,
+		Fee:   action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
+		Memo:  uuidNew.String(),
+		Nonce: svc.nonce(apply.Signers()[0]),
 	}
 	rawData := tx.RawBytes()
 	h, err := svc.nodeContext.PrivVal().GetHandler()
@@ -412,7 +432,7 @@ func (svc *Service) Stake(args client.StakeRequest, reply *client.StakeReply) er
 	vpubkey := h.PubKey()
 	vsinged, err := h.Sign(rawData)
 
-	signatures := []action.Signature{{vpubkey, vsinged}}
+	signatures := []action.Signature{{Signer: vpubkey, Signed: vsinged}}
 	signedTx := &action.SignedTx{
 		RawTx:      tx,
 		Signatures: signatures,
@@ -454,10 +474,11 @@ func (svc *Service) Unstake(args client.UnstakeRequest, reply *client.UnstakeRep
 	feeAmount := svc.feeOpt.MinFee()
 
 	tx := action.RawTx{
-		Type: action.UNSTAKE,
-		Data: data,
-		Fee:  action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
-		Memo: uuidNew.String(),
+		Type:  action.UNSTAKE,
+		Data:  data,
+		Fee:   action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
+		Memo:  uuidNew.String(),
+		Nonce: svc.nonce(apply.Signers()[0]),
 	}
 	rawData := tx.RawBytes()
 	h, err := svc.nodeContext.PrivVal().GetHandler()
@@ -468,7 +489,7 @@ func (svc *Service) Unstake(args client.UnstakeRequest, reply *client.UnstakeRep
 	vpubkey := h.PubKey()
 	vsinged, err := h.Sign(rawData)
 
-	signatures := []action.Signature{{vpubkey, vsinged}}
+	signatures := []action.Signature{{Signer: vpubkey, Signed: vsinged}}
 	signedTx := &action.SignedTx{
 		RawTx:      tx,
 		Signatures: signatures,
@@ -485,6 +506,65 @@ func (svc *Service) Unstake(args client.UnstakeRequest, reply *client.UnstakeRep
 	return nil
 }
 
+func (svc *Service) EditValidator(args client.EditValidatorRequest, reply *client.EditValidatorReply) error {
+	pubkey := svc.nodeContext.ValidatorPubKey()
+	handler, _ := pubkey.GetHandler()
+	address := handler.Address()
+
+	svc.logger.Infof("Validator - %s, delegator - %s, commission rate - %d\n",
+		address, args.Address, args.CommissionRate,
+	)
+
+	apply := staking.EditValidator{
+		ValidatorAddress: address,
+		StakeAddress:     args.Address,
+		CommissionRate:   args.CommissionRate,
+		MaxRate:          args.MaxRate,
+		MaxChangeRate:    args.MaxChangeRate,
+	}
+
+	data, err := apply.Marshal()
+	if err != nil {
+		svc.logger.Error("error in serializing EditValidator object", err)
+		return codes.ErrSerialization
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	feeAmount := svc.feeOpt.MinFee()
+
+	tx := action.RawTx{
+		Type:  action.EDIT_VALIDATOR,
+		Data:  data,
+		Fee:   action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
+		Memo:  uuidNew.String(),
+		Nonce: svc.nonce(apply.Signers()[0]),
+	}
+	rawData := tx.RawBytes()
+	h, err := svc.nodeContext.PrivVal().GetHandler()
+	if err != nil {
+		svc.logger.Error("error get validator handler", err)
+		return codes.ErrLoadingNodeKey
+	}
+	vpubkey := h.PubKey()
+	vsinged, err := h.Sign(rawData)
+
+	signatures := []action.Signature{{Signer: vpubkey, Signed: vsinged}}
+	signedTx := &action.SignedTx{
+		RawTx:      tx,
+		Signatures: signatures,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(signedTx)
+	if err != nil {
+		svc.logger.Error("error in serializing signed transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.EditValidatorReply{RawTx: packet}
+
+	return nil
+}
+
 func (svc *Service) Withdraw(args client.WithdrawRequest, reply *client.WithdrawReply) error {
 	pubkey := svc.nodeContext.ValidatorPubKey()
 	handler, _ := pubkey.GetHandler()
@@ -510,10 +590,11 @@ func (svc *Service) Withdraw(args client.WithdrawRequest, reply *client.Withdraw
 	feeAmount := svc.feeOpt.MinFee()
 
 	tx := action.RawTx{
-		Type: action.WITHDRAW,
-		Data: data,
-		Fee:  action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
-		Memo: uuidNew.String(),
+		Type:  action.WITHDRAW,
+		Data:  data,
+		Fee:   action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
+		Memo:  uuidNew.String(),
+		Nonce: svc.nonce(apply.Signers()[0]),
 	}
 	rawData := tx.RawBytes()
 	h, err := svc.nodeContext.PrivVal().GetHandler()
@@ -524,7 +605,7 @@ func (svc *Service) Withdraw(args client.WithdrawRequest, reply *client.Withdraw
 	vpubkey := h.PubKey()
 	vsinged, err := h.Sign(rawData)
 
-	signatures := []action.Signature{{vpubkey, vsinged}}
+	signatures := []action.Signature{{Signer: vpubkey, Signed: vsinged}}
 	signedTx := &action.SignedTx{
 		RawTx:      tx,
 		Signatures: signatures,
@@ -0,0 +1,52 @@
+package tx
+
+import (
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/tokens"
+	"github.com/Oneledger/protocol/client"
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+// TokenCreate creates the raw tx registering a new token, the creator pays the governance creation fee
+func (svc *Service) TokenCreate(args client.TokenCreateRequest, reply *client.CreateTxReply) error {
+	create := tokens.CreateToken{
+		Creator:   args.Creator,
+		Name:      args.Name,
+		Unit:      args.Unit,
+		Decimal:   args.Decimal,
+		Issuer:    args.Issuer,
+		MaxSupply: args.MaxSupply,
+	}
+	data, err := create.Marshal()
+	if err != nil {
+		return codes.ErrSerialization
+	}
+	return svc.createTx(action.TOKEN_CREATE, data, args.Creator, args.GasPrice, args.Gas, reply)
+}
+
+// TokenMint creates the raw tx minting new tokens to the recipient, signed by the issuer
+func (svc *Service) TokenMint(args client.TokenMintRequest, reply *client.CreateTxReply) error {
+	mint := tokens.MintToken{
+		Issuer: args.Issuer,
+		To:     args.To,
+		Amount: args.Amount,
+	}
+	data, err := mint.Marshal()
+	if err != nil {
+		return codes.ErrSerialization
+	}
+	return svc.createTx(action.TOKEN_MINT, data, args.Issuer, args.GasPrice, args.Gas, reply)
+}
+
+// TokenBurn creates the raw tx burning tokens of the issuer
+func (svc *Service) TokenBurn(args client.TokenBurnRequest, reply *client.CreateTxReply) error {
+	burn := tokens.BurnToken{
+		Issuer: args.Issuer,
+		Amount: args.Amount,
+	}
+	data, err := burn.Marshal()
+	if err != nil {
+		return codes.ErrSerialization
+	}
+	return svc.createTx(action.TOKEN_BURN, data, args.Issuer, args.GasPrice, args.Gas, reply)
+}
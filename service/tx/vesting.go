@@ -0,0 +1,27 @@
+package tx
+
+import (
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/vesting"
+	"github.com/Oneledger/protocol/client"
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+// CreateVesting creates the raw tx sending the coins of the funder to the beneficiary, locked by the vesting schedule
+func (svc *Service) CreateVesting(args client.CreateVestingRequest, reply *client.CreateTxReply) error {
+	create := vesting.CreateVesting{
+		Funder:       args.Funder,
+		Beneficiary:  args.Beneficiary,
+		Amount:       args.Amount,
+		StartHeight:  args.StartHeight,
+		CliffHeight:  args.CliffHeight,
+		EndHeight:    args.EndHeight,
+		Schedule:     args.Schedule,
+		PeriodLength: args.PeriodLength,
+	}
+	data, err := create.Marshal()
+	if err != nil {
+		return codes.ErrSerialization
+	}
+	return svc.createTx(action.CREATE_VESTING, data, args.Funder, args.GasPrice, args.Gas, reply)
+}
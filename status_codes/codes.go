@@ -8,6 +8,9 @@ const (
 	GeneralErr       = 999 // all errors without error code
 	InvalidParams    = 1001
 	IncorrectAddress = 100101
+	IncorrectHeight  = 100102
+	UnsupportedTx    = 100103
+	PrunedHeight     = 100104
 
 	IOError        = 1002
 	IOErrorNodeKey = 100201
@@ -83,6 +86,8 @@ const (
 	TxErrGasOverflow        = 300111
 	TxErrInvalidExtTx       = 300112
 	TxErrMaliciousValidator = 300113
+	TxErrInvalidNonce       = 300114
+	TxErrInvalidBatch       = 300115
 
 	ExternalErr                        = 400100
 	ExternalErrBitcoinTxNotFound       = 400101
@@ -124,6 +129,8 @@ const (
 	NetDelgErrFinalizingDelgRewards         = 600508
 	NetDelgErrAddingWithdrawAmountToBalance = 600509
 	NetDelgErrReinvest                      = 600510
+	NetDelgErrValidatorNotFound             = 600511
+	NetDelgErrValidatorMismatch             = 600512
 
 	GovErr                                = 7001
 	GovErrGetProposalOptions              = 700101
@@ -186,12 +193,45 @@ const (
 	TxErrGetStakingOptions                = 700158
 	TxErrInvalidOptions                   = 700159
 	TxErrEvidenceError                    = 700160
+	GovErrInvalidUpgradePlan              = 700161
+	GovErrFinalizeCodeChangeFailed        = 700162
+	GovErrNoActiveDelegation              = 700163
 
 	//Rewards Error
 	RewardsUnableToGetMaturedAmount = 800001
 	RewardsUnableToWithdraw         = 800002
 	RewardsYearRewardsMissing       = 800003
 
+	//Fee grants
+	FeeGrantErrNotFound          = 810001
+	FeeGrantErrExpired           = 810002
+	FeeGrantErrTxTypeNotAllowed  = 810003
+	FeeGrantErrSpendLimitReached = 810004
+	FeeGrantErrInvalidFeePayer   = 810005
+
+	//Multisig accounts
+	MultiSigErrAccountNotFound     = 820001
+	MultiSigErrAccountExists       = 820002
+	MultiSigErrInvalidPolicy       = 820003
+	MultiSigErrPolicyMismatch      = 820004
+	MultiSigErrInvalidSignature    = 820005
+	MultiSigErrNotEnoughSignatures = 820006
+
+	//Vesting accounts
+	VestingErrInvalidSchedule = 830001
+	VestingErrLockedBalance   = 830002
+	VestingErrAccountExists   = 830003
+	VestingErrNotFound        = 830004
+
+	//Token factory
+	TokenErrNotFound          = 840001
+	TokenErrExists            = 840002
+	TokenErrInvalid           = 840003
+	TokenErrNotIssuer         = 840004
+	TokenErrMaxSupplyExceeded = 840005
+	TokenErrCreationDisabled  = 840006
+	TokenErrInvalidOptions    = 840007
+
 	TxErrVMExecution = 900001
 
 	//Ethereum Errors
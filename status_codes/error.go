@@ -80,6 +80,9 @@ var (
 	ErrGetProposal     = ProtocolError{InternalErrorGettingProposal, "error getting proposal"}
 	ErrFindingCurrency = ProtocolError{CurrencyNotFound, "error finding currency"}
 	ErrGetTx           = ProtocolError{TxNotFound, "error get tx from tendermint"}
+	ErrBadHeight       = ProtocolError{IncorrectHeight, "state of the height is not available"}
+	ErrPrunedHeight    = ProtocolError{PrunedHeight, "state of the height has been pruned"}
+	ErrSimulateTxType  = ProtocolError{UnsupportedTx, "tx type can't be simulated"}
 
 	// ONS errors
 	ErrBadName                   = ProtocolError{ONSErrDomainMissing, "domain name not provided"}
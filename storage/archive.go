@@ -0,0 +1,448 @@
+/*
+   ____             _              _                      _____           _                  _
+  / __ \           | |            | |                    |  __ \         | |                | |
+ | |  | |_ __   ___| |     ___  __| | __ _  ___ _ __     | |__) | __ ___ | |_ ___   ___ ___ | |
+ | |  | | '_ \ / _ \ |    / _ \/ _` |/ _` |/ _ \ '__|    |  ___/ '__/ _ \| __/ _ \ / __/ _ \| |
+ | |__| | | | |  __/ |___|  __/ (_| | (_| |  __/ |       | |   | | | (_) | || (_) | (_| (_) | |
+  \____/|_| |_|\___|______\___|\__,_|\__, |\___|_|       |_|   |_|  \___/ \__\___/ \___\___/|_|
+                                      __/ |
+                                     |___/
+
+
+Copyright 2017 - 2019 OneLedger
+
+	Archive of the chain state versions
+
+	The diff of every committed version is appended to a separate database
+	before the rotation gets a chance to delete the version from the IAVL
+	tree. A value is kept under its key and the version it was written at,
+	so the value of a key at a version is the last one written at or before
+	it. The first archived version is a full copy of the chain state, as is
+	any version following a gap, e.g. after the archive was turned off for
+	a while.
+*/
+
+package storage
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+var (
+	archiveDataPrefix    = []byte("d")
+	archiveKeyPrefix     = []byte("k")
+	archiveVersionPrefix = []byte("v")
+	archiveGapPrefix     = []byte("g")
+	archiveFirstKey      = []byte("mfirst")
+	archiveLastKey       = []byte("mlast")
+
+	archiveDeleted = []byte{0}
+	archiveSet     = []byte{1}
+)
+
+// archiveVersion is the record of an archived version, the keys it changed are kept to drop the version when the
+// chain state is rolled back
+type archiveVersion struct {
+	Hash     []byte   `json:"hash"`
+	Keys     [][]byte `json:"keys,omitempty"`
+	Snapshot bool     `json:"snapshot,omitempty"`
+}
+
+// Archive is the append-only store of the chain state diffs, keyed by height
+type Archive struct {
+	db tmdb.DB
+
+	first int64
+	last  int64
+
+	// the changes of the version being delivered, written by Commit
+	pending map[string][]byte
+
+	mux sync.RWMutex
+}
+
+// NewArchive opens the archive kept in the database
+func NewArchive(db tmdb.DB) (*Archive, error) {
+	archive := &Archive{
+		db:      db,
+		pending: make(map[string][]byte),
+	}
+	var err error
+	archive.first, err = archive.getVersion(archiveFirstKey)
+	if err != nil {
+		return nil, err
+	}
+	archive.last, err = archive.getVersion(archiveLastKey)
+	if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// Range returns the first and last archived versions, both zero when nothing was archived yet
+func (a *Archive) Range() (first, last int64) {
+	a.mux.RLock()
+	defer a.mux.RUnlock()
+	return a.first, a.last
+}
+
+// Has tells whether the state of the version can be read from the archive
+func (a *Archive) Has(version int64) bool {
+	a.mux.RLock()
+	defer a.mux.RUnlock()
+	if a.last == 0 || version < a.first || version > a.last {
+		return false
+	}
+	return !a.inGap(version)
+}
+
+// record keeps the change of the version being delivered, a nil value is a deletion
+func (a *Archive) record(key, value []byte) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	a.pending[string(key)] = value
+}
+
+// Commit appends the changes recorded since the last commit as the diff of the version
+func (a *Archive) Commit(version int64, hash []byte) error {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	defer func() {
+		a.pending = make(map[string][]byte)
+	}()
+
+	if a.last != 0 && version != a.last+1 {
+		return errors.Errorf("archive is at version %d, can't append version %d", a.last, version)
+	}
+
+	keys := make([]string, 0, len(a.pending))
+	for key := range a.pending {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	batch := a.db.NewBatch()
+	defer batch.Close()
+
+	record := archiveVersion{Hash: hash, Keys: make([][]byte, 0, len(keys))}
+	for _, key := range keys {
+		a.setData(batch, []byte(key), version, a.pending[key])
+		record.Keys = append(record.Keys, []byte(key))
+	}
+	err := a.finish(batch, version, record)
+	if err != nil {
+		return err
+	}
+	if a.first == 0 {
+		a.first = version
+	}
+	a.last = version
+	return nil
+}
+
+// Snapshot archives a full copy of the tree of the version, it starts the archive or follows the versions that
+// couldn't be archived
+func (a *Archive) Snapshot(version int64, hash []byte, iterable Iterable) error {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	batch := a.db.NewBatch()
+	defer batch.Close()
+
+	if a.last != 0 && version <= a.last {
+		return errors.Errorf("archive is at version %d, can't snapshot version %d", a.last, version)
+	}
+	// the versions between the last archived one and the snapshot are missing
+	if a.last != 0 && version > a.last+1 {
+		batch.Set(prefixed(archiveGapPrefix, encodeVersion(a.last+1)), encodeVersion(version))
+	}
+
+	present := make(map[string]bool)
+	iterable.Iterate(func(key, value []byte) bool {
+		a.setData(batch, key, version, value)
+		present[string(key)] = true
+		return false
+	})
+	// the keys archived before the gap and removed since then
+	for _, key := range a.allKeys() {
+		if !present[string(key)] {
+			a.setData(batch, key, version, nil)
+		}
+	}
+	err := a.finish(batch, version, archiveVersion{Hash: hash, Snapshot: true})
+	if err != nil {
+		return err
+	}
+	if a.first == 0 {
+		a.first = version
+	}
+	a.last = version
+	a.pending = make(map[string][]byte)
+	return nil
+}
+
+// Truncate drops the versions after the given one, once the chain state is rolled back to it
+func (a *Archive) Truncate(version int64) error {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	for a.last > version && a.last >= a.first && a.last != 0 {
+		record, err := a.getRecord(a.last)
+		if err != nil {
+			return err
+		}
+		batch := a.db.NewBatch()
+		keys := record.Keys
+		if record.Snapshot {
+			keys = a.allKeys()
+		}
+		for _, key := range keys {
+			batch.Delete(dataKey(key, a.last))
+		}
+		batch.Delete(prefixed(archiveVersionPrefix, encodeVersion(a.last)))
+
+		last := a.last - 1
+		if gapStart, ok := a.gapEndingAt(a.last); ok {
+			batch.Delete(prefixed(archiveGapPrefix, encodeVersion(gapStart)))
+			last = gapStart - 1
+		}
+		if last < a.first {
+			a.first, last = 0, 0
+		}
+		batch.Set(archiveFirstKey, encodeVersion(a.first))
+		batch.Set(archiveLastKey, encodeVersion(last))
+		err = batch.WriteSync()
+		batch.Close()
+		if err != nil {
+			return errors.Wrap(err, "failed to truncate archive")
+		}
+		a.last = last
+	}
+	a.pending = make(map[string][]byte)
+	return nil
+}
+
+// Hash returns the root hash of the chain state at the version
+func (a *Archive) Hash(version int64) []byte {
+	record, err := a.getRecord(version)
+	if err != nil {
+		return nil
+	}
+	return record.Hash
+}
+
+// Get returns the value of the key at the version, nil if it wasn't set
+func (a *Archive) Get(version int64, key []byte) []byte {
+	itr, err := a.db.ReverseIterator(dataKey(key, 0), dataKey(key, version+1))
+	if err != nil {
+		return nil
+	}
+	defer itr.Close()
+	if !itr.Valid() {
+		return nil
+	}
+	value := itr.Value()
+	if len(value) == 0 || value[0] != archiveSet[0] {
+		return nil
+	}
+	return value[1:]
+}
+
+// IterateRange iterates the keys set at the version between start, included, and end, excluded
+func (a *Archive) IterateRange(version int64, start, end []byte, ascending bool, fn func(key, value []byte) bool) bool {
+	var itrEnd []byte
+	if end != nil {
+		itrEnd = prefixed(archiveKeyPrefix, end)
+	} else {
+		itrEnd = []byte{archiveKeyPrefix[0] + 1}
+	}
+	itrStart := prefixed(archiveKeyPrefix, start)
+
+	var itr tmdb.Iterator
+	var err error
+	if ascending {
+		itr, err = a.db.Iterator(itrStart, itrEnd)
+	} else {
+		itr, err = a.db.ReverseIterator(itrStart, itrEnd)
+	}
+	if err != nil {
+		return false
+	}
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		key := itr.Key()[len(archiveKeyPrefix):]
+		value := a.Get(version, key)
+		if value == nil {
+			continue
+		}
+		if fn(key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Tree returns a read only view of the chain state at the version
+func (a *Archive) Tree(version int64) (*ArchiveTree, error) {
+	if !a.Has(version) {
+		return nil, ErrVersionNotFound
+	}
+	return &ArchiveTree{archive: a, version: version}, nil
+}
+
+// Close closes the archive database
+func (a *Archive) Close() error {
+	return a.db.Close()
+}
+
+func (a *Archive) setData(batch tmdb.Batch, key []byte, version int64, value []byte) {
+	if value == nil {
+		batch.Set(dataKey(key, version), archiveDeleted)
+		return
+	}
+	batch.Set(dataKey(key, version), append(append([]byte{}, archiveSet...), value...))
+	batch.Set(prefixed(archiveKeyPrefix, key), []byte{})
+}
+
+func (a *Archive) finish(batch tmdb.Batch, version int64, record archiveVersion) error {
+	dat, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize archive version")
+	}
+	batch.Set(prefixed(archiveVersionPrefix, encodeVersion(version)), dat)
+	first := a.first
+	if first == 0 {
+		first = version
+	}
+	batch.Set(archiveFirstKey, encodeVersion(first))
+	batch.Set(archiveLastKey, encodeVersion(version))
+	err = batch.WriteSync()
+	if err != nil {
+		return errors.Wrapf(err, "failed to archive version %d", version)
+	}
+	return nil
+}
+
+func (a *Archive) getRecord(version int64) (*archiveVersion, error) {
+	dat, err := a.db.Get(prefixed(archiveVersionPrefix, encodeVersion(version)))
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) == 0 {
+		return nil, ErrVersionNotFound
+	}
+	record := &archiveVersion{}
+	err = json.Unmarshal(dat, record)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize archive version")
+	}
+	return record, nil
+}
+
+func (a *Archive) getVersion(key []byte) (int64, error) {
+	dat, err := a.db.Get(key)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read archive")
+	}
+	if len(dat) != 8 {
+		return 0, nil
+	}
+	return int64(binary.BigEndian.Uint64(dat)), nil
+}
+
+// inGap tells whether the version falls in a range of versions that were never archived
+func (a *Archive) inGap(version int64) bool {
+	itr, err := a.db.ReverseIterator(prefixed(archiveGapPrefix, encodeVersion(0)), prefixed(archiveGapPrefix, encodeVersion(version+1)))
+	if err != nil {
+		return false
+	}
+	defer itr.Close()
+	if !itr.Valid() {
+		return false
+	}
+	return version < int64(binary.BigEndian.Uint64(itr.Value()))
+}
+
+func (a *Archive) gapEndingAt(version int64) (int64, bool) {
+	itr, err := a.db.ReverseIterator(prefixed(archiveGapPrefix, encodeVersion(0)), prefixed(archiveGapPrefix, encodeVersion(version)))
+	if err != nil {
+		return 0, false
+	}
+	defer itr.Close()
+	if !itr.Valid() || int64(binary.BigEndian.Uint64(itr.Value())) != version {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(itr.Key()[len(archiveGapPrefix):])), true
+}
+
+func (a *Archive) allKeys() [][]byte {
+	keys := make([][]byte, 0)
+	itr, err := a.db.Iterator(archiveKeyPrefix, []byte{archiveKeyPrefix[0] + 1})
+	if err != nil {
+		return keys
+	}
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, append([]byte{}, itr.Key()[len(archiveKeyPrefix):]...))
+	}
+	return keys
+}
+
+// ArchiveTree is the view of the chain state at an archived version, it reads like the IAVL tree of the version
+type ArchiveTree struct {
+	archive *Archive
+	version int64
+}
+
+func (t *ArchiveTree) Version() int64 {
+	return t.version
+}
+
+func (t *ArchiveTree) Hash() []byte {
+	return t.archive.Hash(t.version)
+}
+
+func (t *ArchiveTree) Get(key []byte) (int64, []byte) {
+	return 0, t.archive.Get(t.version, key)
+}
+
+func (t *ArchiveTree) Has(key []byte) bool {
+	return t.archive.Get(t.version, key) != nil
+}
+
+func (t *ArchiveTree) Iterate(fn func(key, value []byte) bool) bool {
+	return t.archive.IterateRange(t.version, nil, nil, true, fn)
+}
+
+func (t *ArchiveTree) IterateRange(start, end []byte, ascending bool, fn func(key, value []byte) bool) bool {
+	return t.archive.IterateRange(t.version, start, end, ascending, fn)
+}
+
+// dataKey is the key of a value written at a version, the length of the key comes first so that the versions of
+// a key are never mixed with the ones of a longer key sharing its prefix
+func dataKey(key []byte, version int64) []byte {
+	buf := make([]byte, 0, len(archiveDataPrefix)+4+len(key)+8)
+	buf = append(buf, archiveDataPrefix...)
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(key)))
+	buf = append(buf, length...)
+	buf = append(buf, key...)
+	return append(buf, encodeVersion(version)...)
+}
+
+func encodeVersion(version int64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(version))
+	return buf
+}
+
+func prefixed(prefix, key []byte) []byte {
+	return append(append(make([]byte, 0, len(prefix)+len(key)), prefix...), key...)
+}
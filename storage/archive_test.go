@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/config"
+)
+
+func TestArchive_PrunedVersions(t *testing.T) {
+	cs := NewChainState("ArchiveTest", db.NewDB("archive_chain", db.MemDBBackend, ""))
+	// only the last version is kept in the tree
+	assert.NoError(t, cs.SetupRotation(config.ChainStateRotationCfg{Recent: 0, Every: 0, Cycles: 0}))
+
+	// a version committed before the archive is turned on is archived in full
+	_ = cs.Set(StoreKey("a"), []byte("a1"))
+	_ = cs.Set(StoreKey("ab"), []byte("ab1"))
+	cs.Commit()
+
+	archive, err := NewArchive(db.NewDB("archive", db.MemDBBackend, ""))
+	assert.NoError(t, err)
+	assert.NoError(t, cs.SetupArchive(archive))
+	first, last := archive.Range()
+	assert.Equal(t, int64(1), first)
+	assert.Equal(t, int64(1), last)
+
+	for v := 2; v <= 4; v++ {
+		_ = cs.Set(StoreKey("a"), []byte("a"+strconv.Itoa(v)))
+		if v == 3 {
+			_, _ = cs.Delete(StoreKey("ab"))
+			_ = cs.Set(StoreKey("c"), []byte("c3"))
+		}
+		cs.Commit()
+	}
+	assert.False(t, cs.VersionExists(2))
+	assert.True(t, cs.Readable(2))
+	assert.False(t, cs.Readable(5))
+
+	state, err := NewVersionedState(cs, 2)
+	assert.NoError(t, err)
+	value, _ := state.Get(StoreKey("a"))
+	assert.Equal(t, []byte("a2"), value)
+	assert.True(t, state.Exists(StoreKey("ab")))
+	assert.False(t, state.Exists(StoreKey("c")))
+	assert.Equal(t, archive.Hash(2), state.RootHash())
+
+	_, value = cs.GetVersioned(3, StoreKey("ab"))
+	assert.Nil(t, value)
+	_, value = cs.GetVersioned(1, StoreKey("a"))
+	assert.Equal(t, []byte("a1"), value)
+
+	keys := make([]string, 0)
+	_, err = cs.IterateRangeVersioned(3, []byte("a"), []byte("d"), true, func(key, value []byte) bool {
+		keys = append(keys, string(key))
+		return false
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "c"}, keys)
+
+	// rolling back drops the archived versions after the height
+	assert.NoError(t, archive.Truncate(2))
+	assert.False(t, archive.Has(3))
+	assert.True(t, archive.Has(2))
+	_, value = cs.GetVersioned(2, StoreKey("a"))
+	assert.Equal(t, []byte("a2"), value)
+}
+
+func TestArchive_Gap(t *testing.T) {
+	archive, err := NewArchive(db.NewDB("archive_gap", db.MemDBBackend, ""))
+	assert.NoError(t, err)
+
+	tree := NewChainState("ArchiveGapTest", db.NewDB("archive_gap_chain", db.MemDBBackend, ""))
+	_ = tree.Set(StoreKey("a"), []byte("a1"))
+	_ = tree.Set(StoreKey("b"), []byte("b1"))
+	hash, version := tree.Commit()
+	assert.NoError(t, archive.Snapshot(version, hash, tree.Delivered.ImmutableTree))
+
+	// the archive was off for versions 2 and 3
+	_, _ = tree.Delete(StoreKey("b"))
+	tree.Commit()
+	tree.Commit()
+	hash, version = tree.Commit()
+	assert.NoError(t, archive.Snapshot(version, hash, tree.Delivered.ImmutableTree))
+
+	assert.True(t, archive.Has(1))
+	assert.False(t, archive.Has(2))
+	assert.False(t, archive.Has(3))
+	assert.True(t, archive.Has(4))
+	assert.Equal(t, []byte("b1"), archive.Get(1, []byte("b")))
+	assert.Nil(t, archive.Get(4, []byte("b")))
+
+	assert.NoError(t, archive.Truncate(1))
+	first, last := archive.Range()
+	assert.Equal(t, int64(1), first)
+	assert.Equal(t, int64(1), last)
+}
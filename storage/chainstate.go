@@ -47,6 +47,9 @@ type ChainState struct {
 
 	ChainStateRotation ChainStateRotationSetting
 
+	// archive keeps the versions deleted by the rotation, nil unless the node runs in archive mode
+	archive *Archive
+
 	sync.RWMutex
 }
 
@@ -101,12 +104,51 @@ func (state *ChainState) SetupRotation(chainStateRotationCfg config.ChainStateRo
 
 }
 
+// SetupArchive streams the diff of every committed version into the archive, the versions the rotation deletes
+// from the tree are then read from it. The current version is archived in full when the archive doesn't follow it.
+func (state *ChainState) SetupArchive(archive *Archive) error {
+	state.Lock()
+	defer state.Unlock()
+
+	state.archive = archive
+	_, last := archive.Range()
+	if last > state.Version {
+		// the chain state was rolled back while the archive was off
+		err := archive.Truncate(state.Version)
+		if err != nil {
+			return err
+		}
+		_, last = archive.Range()
+	}
+	if last == state.Version || state.Version == 0 {
+		return nil
+	}
+	log.Info("Archiving chain state", "version", state.Version, "last archived", last)
+	return state.archiveSnapshot()
+}
+
+// Archive returns the archive of the chain state, nil unless the node runs in archive mode
+func (state *ChainState) Archive() *Archive {
+	return state.archive
+}
+
+func (state *ChainState) archiveSnapshot() error {
+	tree, err := state.Delivered.GetImmutable(state.Version)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the tree to archive")
+	}
+	return state.archive.Snapshot(state.Version, state.Hash, tree)
+}
+
 // Do this only for the Delivery side
 func (state *ChainState) Set(key StoreKey, val []byte) error {
 	state.Lock()
 	defer state.Unlock()
 
 	state.Delivered.Set(key, val)
+	if state.archive != nil {
+		state.archive.record(key, val)
+	}
 
 	return nil
 }
@@ -154,10 +196,56 @@ func (state *ChainState) GetLatestVersioned(key StoreKey) (int64, []byte) {
 
 }
 
+// GetVersioned returns the value of key at the given version, read from the archive once the rotation deleted
+// the version
 func (state *ChainState) GetVersioned(version int64, key StoreKey) (int64, []byte) {
+	if state.archive != nil && !state.Delivered.VersionExists(version) {
+		return 0, state.archive.Get(version, key)
+	}
 	return state.Delivered.GetVersioned(key, version)
 }
 
+// GetVersionedWithProof returns the value of key at the given version, along with an IAVL range proof
+// of its existence, or of its absence if the key is not set at that version
+func (state *ChainState) GetVersionedWithProof(version int64, key StoreKey) ([]byte, *iavl.RangeProof, error) {
+	if !state.Delivered.VersionExists(version) {
+		return nil, nil, ErrVersionNotFound
+	}
+	return state.Delivered.GetVersionedWithProof(key, version)
+}
+
+// IterateRangeVersioned iterates the committed tree of the given version
+func (state *ChainState) IterateRangeVersioned(version int64, start, end []byte, ascending bool, fn func(key, value []byte) bool) (stop bool, err error) {
+	tree, err := state.GetTree(version)
+	if err != nil {
+		return false, err
+	}
+	return tree.IterateRange(start, end, ascending, fn), nil
+}
+
+// VersionExists checks whether the given version is still available, i.e. not yet removed by the rotation
+func (state *ChainState) VersionExists(version int64) bool {
+	return state.Delivered.VersionExists(version)
+}
+
+// Readable checks whether the state of the given version can be read, from the tree or from the archive
+func (state *ChainState) Readable(version int64) bool {
+	return state.Delivered.VersionExists(version) || (state.archive != nil && state.archive.Has(version))
+}
+
+// GetTree returns the read only view of the given version, from the archive once the rotation deleted it from
+// the tree
+func (state *ChainState) GetTree(version int64) (VersionedTree, error) {
+	tree, err := state.Delivered.GetImmutable(version)
+	if err == nil {
+		return tree, nil
+	}
+	if state.archive != nil {
+		return state.archive.Tree(version)
+	}
+	return nil, ErrVersionNotFound
+}
+
 // TODO: Should be against the commit tree, not the delivered one!!!
 func (state *ChainState) Exists(key StoreKey) bool {
 	state.RLock()
@@ -166,12 +254,18 @@ func (state *ChainState) Exists(key StoreKey) bool {
 }
 
 func (state *ChainState) Delete(key StoreKey) (bool, error) {
+	state.Lock()
+	defer state.Unlock()
+
 	_, ok := state.Delivered.Remove(key)
 	if !ok {
 		err := errors.New("Failed to delete the item from chainstate")
 		log.Error(err.Error())
 		return false, err
 	}
+	if state.archive != nil {
+		state.archive.record(key, nil)
+	}
 	return ok, nil
 }
 
@@ -189,6 +283,14 @@ func (state *ChainState) Commit() ([]byte, int64) {
 	state.LastVersion, state.Version = state.Version, version
 	state.LastHash, state.Hash = state.Hash, hash
 
+	// the diff is archived before the rotation deletes anything
+	if state.archive != nil {
+		err := state.archive.Commit(version, hash)
+		if err != nil {
+			panic(errors.Wrap(err, "failed to archive, version: "+strconv.FormatInt(version, 10)))
+		}
+	}
+
 	release := state.LastVersion - state.ChainStateRotation.recent
 
 	if release > 0 {
@@ -239,5 +341,11 @@ func (state *ChainState) loadDB(db tmdb.DB) ([]byte, int64) {
 func (state *ChainState) ClearFrom(version int64) error {
 	version, err := state.Delivered.LoadVersionForOverwriting(version)
 	log.Info("cleared version after: ", version)
-	return err
+	if err != nil {
+		return err
+	}
+	if state.archive != nil {
+		return state.archive.Truncate(version)
+	}
+	return nil
 }
@@ -202,3 +202,29 @@ func TestChainState_Rotation(t *testing.T) {
 	assert.Equal(t, correct, counter, "These should be equal")
 
 }
+
+func TestChainState_GetVersionedWithProof(t *testing.T) {
+	state := NewChainState("Proof", db.NewDB("test", db.MemDBBackend, ""))
+
+	key := StoreKey("proofKey")
+	value := []byte("proofValue")
+	err := state.Set(key, value)
+	assert.NoError(t, err)
+	hash, version := state.Commit()
+
+	result, proof, err := state.GetVersionedWithProof(version, key)
+	assert.NoError(t, err)
+	assert.Equal(t, value, result)
+	assert.NoError(t, proof.Verify(hash))
+	assert.NoError(t, proof.VerifyItem(key, value))
+
+	missing := StoreKey("missingKey")
+	result, proof, err = state.GetVersionedWithProof(version, missing)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	assert.NoError(t, proof.Verify(hash))
+	assert.NoError(t, proof.VerifyAbsence(missing))
+
+	_, _, err = state.GetVersionedWithProof(version+1, key)
+	assert.Equal(t, ErrVersionNotFound, err)
+}
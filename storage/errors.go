@@ -17,7 +17,8 @@ package storage
 import "errors"
 
 var (
-	ErrNotFound       = errors.New("key not found")
-	ErrSetFailed      = errors.New("failed to set data")
-	ErrExceedGasLimit = errors.New("gas exceeds limit")
+	ErrNotFound        = errors.New("key not found")
+	ErrSetFailed       = errors.New("failed to set data")
+	ErrExceedGasLimit  = errors.New("gas exceeds limit")
+	ErrVersionNotFound = errors.New("version does not exist or has been pruned")
 )
@@ -14,8 +14,78 @@ const (
 	DELETE     Gas = 50
 	FLAT       Gas = 1
 	CONTRACT   Gas = 1
+
+	// NO_TX_TYPE prices the operations run outside of a transaction without a multiplier
+	NO_TX_TYPE = -1
 )
 
+// GasSchedule is the gas cost of each operation on the store
+type GasSchedule struct {
+	StoreBytes Gas `json:"storeBytes"`
+	ReadFlat   Gas `json:"readFlat"`
+	ReadBytes  Gas `json:"readBytes"`
+	WriteFlat  Gas `json:"writeFlat"`
+	WriteBytes Gas `json:"writeBytes"`
+	VerifySig  Gas `json:"verifySig"`
+	HashBytes  Gas `json:"hashBytes"`
+	CheckExist Gas `json:"checkExist"`
+	Delete     Gas `json:"delete"`
+}
+
+// TxGasMultiplier scales the gas used by the transactions of a type, in percent
+type TxGasMultiplier struct {
+	TxType  int   `json:"txType"`
+	Percent int64 `json:"percent"`
+}
+
+// GasOptions is the gas schedule set by the governance, the multipliers re-price the transactions of a type
+type GasOptions struct {
+	Schedule      GasSchedule       `json:"schedule"`
+	TxMultipliers []TxGasMultiplier `json:"txMultipliers"`
+}
+
+// DefaultGasOptions returns the gas schedule used before it was set by the governance
+func DefaultGasOptions() GasOptions {
+	return GasOptions{
+		Schedule: GasSchedule{
+			StoreBytes: STOREBYTES,
+			ReadFlat:   READFLAT,
+			ReadBytes:  READBYTES,
+			WriteFlat:  WRITEFLAT,
+			WriteBytes: WRITEBYTES,
+			VerifySig:  VERIFYSIG,
+			HashBytes:  HASHBYTES,
+			CheckExist: CHECKEXIST,
+			Delete:     DELETE,
+		},
+		TxMultipliers: []TxGasMultiplier{},
+	}
+}
+
+// Multiplier returns the gas multiplier of the transaction type in percent, 100 when it has none
+func (opt *GasOptions) Multiplier(txType int) int64 {
+	for _, m := range opt.TxMultipliers {
+		if m.TxType == txType {
+			return m.Percent
+		}
+	}
+	return 100
+}
+
+// SetMultiplier sets the gas multiplier of the transaction type, 100 removes it
+func (opt *GasOptions) SetMultiplier(txType int, percent int64) {
+	multipliers := make([]TxGasMultiplier, 0, len(opt.TxMultipliers)+1)
+	for _, m := range opt.TxMultipliers {
+		if m.TxType != txType {
+			multipliers = append(multipliers, m)
+		}
+	}
+	if percent != 100 {
+		multipliers = append(multipliers, TxGasMultiplier{TxType: txType, Percent: percent})
+	}
+	opt.TxMultipliers = multipliers
+}
+
 // Calculate the gas used for each action, will be embedded with GasStore.
 type GasCalculator interface {
 	// Consume amount of Gas for the Category
@@ -32,13 +102,21 @@ type GasCalculator interface {
 
 	// GetLeft return total gas left, used right now in olvm (frankenstein update)
 	GetLeft() uint64
+
+	// Get the gas schedule the operations are priced with
+	GetSchedule() GasSchedule
+
+	// Apply the multiplier of the transaction type to the gas consumed next
+	SetTxType(txType int)
 }
 
 var _ GasCalculator = &gasCalculator{}
 
 type gasCalculator struct {
-	limit    Gas
-	consumed Gas
+	limit      Gas
+	consumed   Gas
+	opt        GasOptions
+	multiplier int64
 }
 
 func (g gasCalculator) IsEnough() bool {
@@ -50,6 +128,9 @@ func (g gasCalculator) IsEnough() bool {
 
 func (g *gasCalculator) Consume(amount, category Gas, allowOverflow bool) bool {
 	currentGasCost := amount * category
+	if g.multiplier != 100 {
+		currentGasCost = Gas(int64(currentGasCost) * g.multiplier / 100)
+	}
 	if allowOverflow {
 		g.consumed += currentGasCost
 		return true
@@ -82,10 +163,25 @@ func (g gasCalculator) GetLeft() uint64 {
 	return uint64(availableGas)
 }
 
+func (g gasCalculator) GetSchedule() GasSchedule {
+	return g.opt.Schedule
+}
+
+func (g *gasCalculator) SetTxType(txType int) {
+	g.multiplier = g.opt.Multiplier(txType)
+}
+
 func NewGasCalculator(limit Gas) GasCalculator {
+	return NewGasCalculatorWithOptions(limit, DefaultGasOptions())
+}
+
+// NewGasCalculatorWithOptions returns a calculator pricing the operations with the gas schedule of the governance
+func NewGasCalculatorWithOptions(limit Gas, opt GasOptions) GasCalculator {
 	return &gasCalculator{
-		limit:    limit,
-		consumed: 0,
+		limit:      limit,
+		consumed:   0,
+		opt:        opt,
+		multiplier: 100,
 	}
 }
 
@@ -114,7 +210,7 @@ func NewGasStore(store SessionedDirectStorage, gc GasCalculator) *GasStore {
 }
 
 func (g *GasStore) Set(key StoreKey, value []byte) error {
-	ok := g.GasCalculator.Consume(Gas(1), WRITEFLAT, false)
+	ok := g.GasCalculator.Consume(Gas(1), g.GetSchedule().WriteFlat, false)
 	if !ok {
 		return ErrExceedGasLimit
 	}
@@ -122,12 +218,12 @@ func (g *GasStore) Set(key StoreKey, value []byte) error {
 	if err != nil {
 		return err
 	}
-	g.GasCalculator.Consume(Gas(len(value)), WRITEBYTES, true)
+	g.GasCalculator.Consume(Gas(len(value)), g.GetSchedule().WriteBytes, true)
 	return nil
 }
 
 func (g *GasStore) Get(key StoreKey) ([]byte, error) {
-	ok := g.GasCalculator.Consume(Gas(1), READFLAT, false)
+	ok := g.GasCalculator.Consume(Gas(1), g.GetSchedule().ReadFlat, false)
 	if !ok {
 		//log.Error(ErrExceedGasLimit.Error())
 		return nil, ErrExceedGasLimit
@@ -136,12 +232,12 @@ func (g *GasStore) Get(key StoreKey) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	ok = g.GasCalculator.Consume(Gas(len(value)), READBYTES, true)
+	ok = g.GasCalculator.Consume(Gas(len(value)), g.GetSchedule().ReadBytes, true)
 	return value, nil
 }
 
 func (g *GasStore) Exists(key StoreKey) bool {
-	ok := g.GasCalculator.Consume(Gas(1), CHECKEXIST, false)
+	ok := g.GasCalculator.Consume(Gas(1), g.GetSchedule().CheckExist, false)
 	if !ok {
 		log.Error(ErrExceedGasLimit.Error())
 		return false
@@ -152,7 +248,7 @@ func (g *GasStore) Exists(key StoreKey) bool {
 }
 
 func (g *GasStore) Delete(key StoreKey) (bool, error) {
-	ok := g.GasCalculator.Consume(Gas(1), DELETE, false)
+	ok := g.GasCalculator.Consume(Gas(1), g.GetSchedule().Delete, false)
 	if !ok {
 		log.Error(ErrExceedGasLimit.Error())
 		return false, ErrExceedGasLimit
@@ -161,6 +257,14 @@ func (g *GasStore) Delete(key StoreKey) (bool, error) {
 	return g.SessionedDirectStorage.Delete(key)
 }
 
+func (g *GasStore) unset(key StoreKey) {
+	if u, ok := g.SessionedDirectStorage.(unsetter); ok {
+		u.unset(key)
+		return
+	}
+	_, _ = g.SessionedDirectStorage.Delete(key)
+}
+
 // NoGasStore skip gas consume because EVM will calculate it and apply at fee processor
 type NoGasStore struct {
 	SessionedDirectStorage
@@ -198,3 +302,11 @@ func (g *NoGasStore) Exists(key StoreKey) bool {
 func (g *NoGasStore) Delete(key StoreKey) (bool, error) {
 	return g.SessionedDirectStorage.Delete(key)
 }
+
+func (g *NoGasStore) unset(key StoreKey) {
+	if u, ok := g.SessionedDirectStorage.(unsetter); ok {
+		u.unset(key)
+		return
+	}
+	_, _ = g.SessionedDirectStorage.Delete(key)
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestGasStore_Schedule(t *testing.T) {
+	opt := DefaultGasOptions()
+	opt.Schedule.WriteFlat = 1000
+	opt.SetMultiplier(1, 200)
+
+	state := NewState(NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	state = state.WithGas(NewGasCalculatorWithOptions(1000000, opt))
+
+	assert.NoError(t, state.Set(StoreKey("key"), []byte("value")))
+	assert.Equal(t, 1000+5*WRITEBYTES, state.ConsumedGas())
+
+	// the multiplier of the transaction type scales the schedule
+	state.SetTxType(1)
+	start := state.ConsumedGas()
+	assert.NoError(t, state.Set(StoreKey("key"), []byte("value")))
+	assert.Equal(t, 2*(1000+5*WRITEBYTES), state.ConsumedGas()-start)
+
+	state.SetTxType(NO_TX_TYPE)
+	start = state.ConsumedGas()
+	state.ConsumeVerifySigGas(1)
+	assert.Equal(t, VERIFYSIG, state.ConsumedGas()-start)
+}
+
+func TestGasOptions_SetMultiplier(t *testing.T) {
+	opt := DefaultGasOptions()
+	assert.Equal(t, int64(100), opt.Multiplier(1))
+
+	opt.SetMultiplier(1, 150)
+	opt.SetMultiplier(1, 300)
+	assert.Len(t, opt.TxMultipliers, 1)
+	assert.Equal(t, int64(300), opt.Multiplier(1))
+
+	// back to the schedule price
+	opt.SetMultiplier(1, 100)
+	assert.Len(t, opt.TxMultipliers, 0)
+}
@@ -100,6 +100,10 @@ func (c *sessionCache) Delete(key StoreKey) (bool, error) {
 	return true, nil
 }
 
+func (c *sessionCache) unset(key StoreKey) {
+	delete(c.store, string(key))
+}
+
 func (c *sessionCache) GetIterable() Iterable {
 	return c
 }
@@ -183,6 +187,10 @@ func (c *cacheSession) Delete(key StoreKey) (bool, error) {
 	return true, nil
 }
 
+func (c *cacheSession) unset(key StoreKey) {
+	delete(c.store, string(key))
+}
+
 func (c *cacheSession) GetIterable() Iterable {
 	return c
 }
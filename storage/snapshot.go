@@ -0,0 +1,570 @@
+/*
+   ____             _              _                      _____           _                  _
+  / __ \           | |            | |                    |  __ \         | |                | |
+ | |  | |_ __   ___| |     ___  __| | __ _  ___ _ __     | |__) | __ ___ | |_ ___   ___ ___ | |
+ | |  | | '_ \ / _ \ |    / _ \/ _` |/ _` |/ _ \ '__|    |  ___/ '__/ _ \| __/ _ \ / __/ _ \| |
+ | |__| | | | |  __/ |___|  __/ (_| | (_| |  __/ |       | |   | | | (_) | || (_) | (_| (_) | |
+  \____/|_| |_|\___|______\___|\__,_|\__, |\___|_|       |_|   |_|  \___/ \__\___/ \___\___/|_|
+                                      __/ |
+                                     |___/
+
+
+Copyright 2017 - 2019 OneLedger
+
+	Snapshots of the chain state for state sync
+
+	Every "interval" versions the committed IAVL tree is exported into a list of
+	chunk files, each chunk is hashed and the snapshot hash is the hash of all
+	the chunk hashes. A joining node with an empty chain state is offered a
+	snapshot, applies its chunks in order and verifies the resulting root hash
+	against the trusted app hash.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/iavl"
+
+	"github.com/Oneledger/protocol/config"
+)
+
+const (
+	SnapshotFormat uint32 = 1
+
+	snapshotMetadataFile = "metadata.json"
+	snapshotChunkExt     = ".chunk"
+	snapshotMinChunkSize = 1 << 10
+)
+
+var (
+	ErrSnapshotNotFound     = errors.New("snapshot not found")
+	ErrSnapshotFormat       = errors.New("unsupported snapshot format")
+	ErrSnapshotNoRestore    = errors.New("no snapshot restore in progress")
+	ErrSnapshotChunkOrder   = errors.New("snapshot chunk applied out of order")
+	ErrSnapshotChunkHash    = errors.New("snapshot chunk hash mismatch")
+	ErrSnapshotAppHash      = errors.New("restored chain state does not match the trusted app hash")
+	ErrSnapshotStateNotNull = errors.New("chain state is not empty, can't restore a snapshot")
+)
+
+// Snapshot describes a snapshot of the chain state at a given height
+type Snapshot struct {
+	Height      int64    `json:"height"`
+	Format      uint32   `json:"format"`
+	Chunks      uint32   `json:"chunks"`
+	Hash        []byte   `json:"hash"`
+	AppHash     []byte   `json:"appHash"`
+	ChunkHashes [][]byte `json:"chunkHashes"`
+}
+
+// Metadata is the part of the snapshot which is not covered by its hash, it is sent along the snapshot
+// when offered to peers
+func (s *Snapshot) Metadata() ([]byte, error) {
+	return json.Marshal(s.ChunkHashes)
+}
+
+// SetMetadata restores the chunk hashes of a snapshot received from a peer
+func (s *Snapshot) SetMetadata(metadata []byte) error {
+	return json.Unmarshal(metadata, &s.ChunkHashes)
+}
+
+// Validate checks that the chunk hashes add up to the snapshot hash
+func (s *Snapshot) Validate() error {
+	if s.Format != SnapshotFormat {
+		return ErrSnapshotFormat
+	}
+	if int(s.Chunks) != len(s.ChunkHashes) || s.Chunks == 0 {
+		return errors.Errorf("snapshot has %d chunks but %d chunk hashes", s.Chunks, len(s.ChunkHashes))
+	}
+	if !bytes.Equal(s.Hash, hashChunkHashes(s.ChunkHashes)) {
+		return errors.New("snapshot hash does not match its chunk hashes")
+	}
+	return nil
+}
+
+// SnapshotManager takes snapshots of the chain state and restores it from the snapshot of a peer
+type SnapshotManager struct {
+	cs  *ChainState
+	dir string
+
+	interval   int64
+	keepRecent int64
+	chunkSize  int64
+
+	// only one snapshot is taken at a time, a new one is skipped while the previous is still running
+	taking bool
+	mux    sync.Mutex
+
+	restore *snapshotRestore
+}
+
+type snapshotRestore struct {
+	snapshot *Snapshot
+	importer *iavl.Importer
+	next     uint32
+}
+
+// NewSnapshotManager creates the snapshot directory under the given db directory
+func NewSnapshotManager(cs *ChainState, dbDir string, cfg config.SnapshotCfg) (*SnapshotManager, error) {
+	if cfg.Interval < 0 || cfg.KeepRecent < 0 || cfg.ChunkSize < 0 {
+		return nil, errors.New("found negative value in snapshot config")
+	}
+	chunkSize := cfg.ChunkSize
+	if chunkSize < snapshotMinChunkSize {
+		chunkSize = snapshotMinChunkSize
+	}
+
+	dir := filepath.Join(dbDir, "snapshots")
+	if err := os.MkdirAll(dir, config.DirPerms); err != nil {
+		return nil, errors.Wrap(err, "failed to create snapshot directory")
+	}
+
+	return &SnapshotManager{
+		cs:         cs,
+		dir:        dir,
+		interval:   cfg.Interval,
+		keepRecent: cfg.KeepRecent,
+		chunkSize:  chunkSize,
+	}, nil
+}
+
+// Enabled tells whether snapshots are taken periodically
+func (sm *SnapshotManager) Enabled() bool {
+	return sm.interval > 0
+}
+
+// Take starts a snapshot of the given version if it falls on the configured interval, the tree is read and
+// exported in the background so it does not block the commit.
+func (sm *SnapshotManager) Take(version int64) {
+	if !sm.Enabled() || version <= 0 || version%sm.interval != 0 {
+		return
+	}
+
+	sm.mux.Lock()
+	if sm.taking {
+		sm.mux.Unlock()
+		log.Info("skip snapshot, previous snapshot still in progress", "version", version)
+		return
+	}
+	sm.taking = true
+	sm.mux.Unlock()
+
+	go func() {
+		defer sm.done()
+
+		exporter, hash, err := sm.export(version)
+		if err != nil {
+			log.Error("failed to export tree for snapshot", "version", version, "err", err)
+			return
+		}
+		if exporter == nil {
+			return
+		}
+		defer exporter.Close()
+
+		snapshot, err := sm.write(version, hash, exporter)
+		if err != nil {
+			log.Error("failed to take snapshot", "version", version, "err", err)
+			_ = os.RemoveAll(sm.heightDir(version))
+			return
+		}
+		log.Info("snapshot taken", "version", version, "chunks", snapshot.Chunks)
+
+		sm.prune()
+	}()
+}
+
+// export opens an exporter on the given version, nil for an empty tree. The chain state read lock keeps the commit
+// from changing the versions of the tree meanwhile, then the exporter holds a reader on the version, which keeps the
+// rotation from deleting it.
+func (sm *SnapshotManager) export(version int64) (*iavl.Exporter, []byte, error) {
+	sm.cs.RLock()
+	defer sm.cs.RUnlock()
+
+	tree, err := sm.cs.Delivered.GetImmutable(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tree.Size() == 0 {
+		return nil, nil, nil
+	}
+	return tree.Export(), tree.Hash(), nil
+}
+
+func (sm *SnapshotManager) done() {
+	sm.mux.Lock()
+	sm.taking = false
+	sm.mux.Unlock()
+}
+
+func (sm *SnapshotManager) write(version int64, appHash []byte, exporter *iavl.Exporter) (*Snapshot, error) {
+	dir := sm.heightDir(version)
+	if err := os.MkdirAll(dir, config.DirPerms); err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		Height:      version,
+		Format:      SnapshotFormat,
+		AppHash:     appHash,
+		ChunkHashes: make([][]byte, 0),
+	}
+
+	buf := &bytes.Buffer{}
+	flush := func() error {
+		if buf.Len() == 0 {
+			return nil
+		}
+		hash := sha256.Sum256(buf.Bytes())
+		err := ioutil.WriteFile(sm.chunkPath(version, snapshot.Chunks), buf.Bytes(), config.FilePerms)
+		if err != nil {
+			return err
+		}
+		snapshot.ChunkHashes = append(snapshot.ChunkHashes, hash[:])
+		snapshot.Chunks++
+		buf.Reset()
+		return nil
+	}
+
+	for {
+		node, err := exporter.Next()
+		if err == iavl.ExportDone {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		encodeExportNode(buf, node)
+		if int64(buf.Len()) >= sm.chunkSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	snapshot.Hash = hashChunkHashes(snapshot.ChunkHashes)
+
+	// the metadata is written last, a snapshot directory without it is incomplete and never listed
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	tmp := filepath.Join(dir, snapshotMetadataFile+".tmp")
+	if err := ioutil.WriteFile(tmp, data, config.FilePerms); err != nil {
+		return nil, err
+	}
+	return snapshot, os.Rename(tmp, filepath.Join(dir, snapshotMetadataFile))
+}
+
+// prune removes all but the "keepRecent" latest snapshots
+func (sm *SnapshotManager) prune() {
+	if sm.keepRecent == 0 {
+		return
+	}
+	snapshots, err := sm.List()
+	if err != nil {
+		log.Error("failed to list snapshots for pruning", "err", err)
+		return
+	}
+	for i := int(sm.keepRecent); i < len(snapshots); i++ {
+		err := os.RemoveAll(sm.heightDir(snapshots[i].Height))
+		if err != nil {
+			log.Error("failed to prune snapshot", "height", snapshots[i].Height, "err", err)
+		}
+	}
+}
+
+// List returns all the complete snapshots on disk, latest first
+func (sm *SnapshotManager) List() ([]*Snapshot, error) {
+	entries, err := ioutil.ReadDir(sm.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*Snapshot, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		height, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+		snapshot, err := sm.Get(height)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Height > snapshots[j].Height
+	})
+	return snapshots, nil
+}
+
+// Get reads the metadata of the snapshot at the given height
+func (sm *SnapshotManager) Get(height int64) (*Snapshot, error) {
+	return ReadSnapshot(sm.heightDir(height))
+}
+
+// ReadSnapshot reads the metadata of the snapshot stored in dir
+func ReadSnapshot(dir string) (*Snapshot, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, snapshotMetadataFile))
+	if os.IsNotExist(err) {
+		return nil, ErrSnapshotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &Snapshot{}
+	err = json.Unmarshal(data, snapshot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read snapshot metadata")
+	}
+	return snapshot, nil
+}
+
+// LoadChunk returns the raw chunk of a local snapshot
+func (sm *SnapshotManager) LoadChunk(height int64, format uint32, index uint32) ([]byte, error) {
+	if format != SnapshotFormat {
+		return nil, ErrSnapshotFormat
+	}
+	snapshot, err := sm.Get(height)
+	if err != nil {
+		return nil, err
+	}
+	if index >= snapshot.Chunks {
+		return nil, errors.Errorf("chunk %d out of range, snapshot has %d chunks", index, snapshot.Chunks)
+	}
+	return ioutil.ReadFile(sm.chunkPath(height, index))
+}
+
+// Offer starts the restore of a snapshot into an empty chain state, the app hash comes from the
+// light client verified header and is what the restored tree is checked against.
+func (sm *SnapshotManager) Offer(snapshot *Snapshot, appHash []byte) error {
+	if err := snapshot.Validate(); err != nil {
+		return err
+	}
+	if !bytes.Equal(snapshot.AppHash, appHash) {
+		return ErrSnapshotAppHash
+	}
+
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+
+	if sm.restore != nil {
+		sm.restore.importer.Close()
+		sm.restore = nil
+	}
+	if sm.cs.Version > 0 {
+		return ErrSnapshotStateNotNull
+	}
+
+	importer, err := sm.cs.Delivered.Import(snapshot.Height)
+	if err != nil {
+		return errors.Wrap(err, "failed to start chain state import")
+	}
+	sm.restore = &snapshotRestore{
+		snapshot: snapshot,
+		importer: importer,
+	}
+	return nil
+}
+
+// ApplyChunk imports the next chunk of the offered snapshot, once the last chunk is applied the
+// chain state is loaded at the snapshot height and done is true.
+func (sm *SnapshotManager) ApplyChunk(index uint32, chunk []byte) (done bool, err error) {
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+
+	r := sm.restore
+	if r == nil {
+		return false, ErrSnapshotNoRestore
+	}
+	if index != r.next {
+		return false, ErrSnapshotChunkOrder
+	}
+	hash := sha256.Sum256(chunk)
+	if !bytes.Equal(hash[:], r.snapshot.ChunkHashes[index]) {
+		return false, ErrSnapshotChunkHash
+	}
+
+	reader := bytes.NewReader(chunk)
+	for reader.Len() > 0 {
+		node, err := decodeExportNode(reader)
+		if err != nil {
+			sm.abort()
+			return false, errors.Wrapf(err, "failed to decode chunk %d", index)
+		}
+		err = r.importer.Add(node)
+		if err != nil {
+			sm.abort()
+			return false, errors.Wrapf(err, "failed to import chunk %d", index)
+		}
+	}
+	r.next++
+
+	if r.next < r.snapshot.Chunks {
+		return false, nil
+	}
+
+	sm.restore = nil
+	err = r.importer.Commit()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to commit chain state import")
+	}
+
+	sm.cs.Lock()
+	defer sm.cs.Unlock()
+	hash2 := sm.cs.Delivered.Hash()
+	if !bytes.Equal(hash2, r.snapshot.AppHash) {
+		return false, ErrSnapshotAppHash
+	}
+	sm.cs.LastVersion, sm.cs.Version = sm.cs.Delivered.Version(), sm.cs.Delivered.Version()
+	sm.cs.LastHash, sm.cs.Hash = hash2, hash2
+	sm.cs.TreeHeight = sm.cs.Delivered.Height()
+
+	log.Info("chain state restored from snapshot", "version", sm.cs.Version, "hash", fmt.Sprintf("%X", hash2))
+	// an archive node serves the history from the restored version on
+	if sm.cs.archive != nil {
+		err = sm.cs.archiveSnapshot()
+		if err != nil {
+			return false, errors.Wrap(err, "failed to archive the restored chain state")
+		}
+	}
+	return true, nil
+}
+
+// Restore restores an empty chain state from the snapshot stored in dir, copied from the snapshot directory of
+// another node, the restored tree has to match the trusted app hash.
+func (sm *SnapshotManager) Restore(dir string, appHash []byte) (*Snapshot, error) {
+	snapshot, err := ReadSnapshot(dir)
+	if err != nil {
+		return nil, err
+	}
+	err = sm.Offer(snapshot, appHash)
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk, err := ioutil.ReadFile(chunkPath(dir, i))
+		if err != nil {
+			sm.Abort()
+			return nil, errors.Wrapf(err, "failed to read chunk %d", i)
+		}
+		_, err = sm.ApplyChunk(i, chunk)
+		if err != nil {
+			sm.Abort()
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
+
+// Abort drops the restore in progress, if any
+func (sm *SnapshotManager) Abort() {
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+	sm.abort()
+}
+
+func (sm *SnapshotManager) abort() {
+	if sm.restore != nil {
+		sm.restore.importer.Close()
+		sm.restore = nil
+	}
+}
+
+func (sm *SnapshotManager) heightDir(height int64) string {
+	return filepath.Join(sm.dir, strconv.FormatInt(height, 10))
+}
+
+func (sm *SnapshotManager) chunkPath(height int64, index uint32) string {
+	return chunkPath(sm.heightDir(height), index)
+}
+
+func chunkPath(dir string, index uint32) string {
+	return filepath.Join(dir, strconv.FormatUint(uint64(index), 10)+snapshotChunkExt)
+}
+
+func hashChunkHashes(hashes [][]byte) []byte {
+	h := sha256.New()
+	for _, hash := range hashes {
+		h.Write(hash)
+	}
+	return h.Sum(nil)
+}
+
+// export nodes are encoded as: height | uvarint version | uvarint key length | key | uvarint value length | value
+func encodeExportNode(w *bytes.Buffer, node *iavl.ExportNode) {
+	var tmp [binary.MaxVarintLen64]byte
+
+	w.WriteByte(byte(node.Height))
+	n := binary.PutUvarint(tmp[:], uint64(node.Version))
+	w.Write(tmp[:n])
+	n = binary.PutUvarint(tmp[:], uint64(len(node.Key)))
+	w.Write(tmp[:n])
+	w.Write(node.Key)
+	n = binary.PutUvarint(tmp[:], uint64(len(node.Value)))
+	w.Write(tmp[:n])
+	w.Write(node.Value)
+}
+
+func decodeExportNode(r *bytes.Reader) (*iavl.ExportNode, error) {
+	height, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	version, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	key, err := readBytes(r)
+	if err != nil {
+		return nil, err
+	}
+	value, err := readBytes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &iavl.ExportNode{
+		Key:     key,
+		Version: int64(version),
+		Height:  int8(height),
+	}
+	// only leaves carry a value
+	if node.Height == 0 {
+		node.Value = value
+	}
+	return node, nil
+}
+
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	l, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if l > uint64(r.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := make([]byte, l)
+	_, err = io.ReadFull(r, b)
+	return b, err
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/config"
+)
+
+func waitForSnapshot(t *testing.T, sm *SnapshotManager, height int64) *Snapshot {
+	for i := 0; i < 100; i++ {
+		snapshot, err := sm.Get(height)
+		if err == nil {
+			return snapshot
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("snapshot at height %d not taken", height)
+	return nil
+}
+
+func TestSnapshotManager_Restore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := config.SnapshotCfg{Interval: 2, KeepRecent: 1, ChunkSize: 1024}
+
+	source := NewChainState("SnapshotSource", db.NewDB("source", db.MemDBBackend, ""))
+	sm, err := NewSnapshotManager(source, dir, cfg)
+	assert.NoError(t, err)
+
+	var hash []byte
+	var version int64
+	for v := 0; v < 4; v++ {
+		for i := 0; i < 200; i++ {
+			key := StoreKey("key_" + strconv.Itoa(v) + "_" + strconv.Itoa(i))
+			_ = source.Set(key, []byte("value_"+strconv.Itoa(i)))
+		}
+		hash, version = source.Commit()
+		sm.Take(version)
+		if version%cfg.Interval == 0 {
+			waitForSnapshot(t, sm, version)
+		}
+	}
+
+	snapshots, err := sm.List()
+	assert.NoError(t, err)
+	// only the latest one is kept
+	assert.Len(t, snapshots, 1)
+	snapshot := snapshots[0]
+	assert.Equal(t, version, snapshot.Height)
+	assert.Equal(t, hash, snapshot.AppHash)
+	assert.True(t, snapshot.Chunks > 1)
+
+	target := NewChainState("SnapshotTarget", db.NewDB("target", db.MemDBBackend, ""))
+	restoreDir, err := ioutil.TempDir("", "snapshot_restore_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(restoreDir)
+	rm, err := NewSnapshotManager(target, restoreDir, cfg)
+	assert.NoError(t, err)
+
+	// the app hash from the header has to match
+	assert.Equal(t, ErrSnapshotAppHash, rm.Offer(snapshot, []byte("wrong")))
+	assert.NoError(t, rm.Offer(snapshot, hash))
+
+	// a corrupted chunk is refused
+	chunk, err := sm.LoadChunk(snapshot.Height, SnapshotFormat, 0)
+	assert.NoError(t, err)
+	_, err = rm.ApplyChunk(0, append([]byte{0}, chunk...))
+	assert.Equal(t, ErrSnapshotChunkHash, err)
+
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk, err := sm.LoadChunk(snapshot.Height, SnapshotFormat, i)
+		assert.NoError(t, err)
+		done, err := rm.ApplyChunk(i, chunk)
+		assert.NoError(t, err)
+		assert.Equal(t, i == snapshot.Chunks-1, done)
+	}
+
+	assert.Equal(t, version, target.Version)
+	assert.Equal(t, hash, target.Hash)
+	value, err := target.Get(StoreKey("key_0_10"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value_10"), value)
+}
+
+func TestSnapshotManager_RestoreFromDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot_dir_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := config.SnapshotCfg{Interval: 1, ChunkSize: 1024}
+
+	source := NewChainState("SnapshotDirSource", db.NewDB("source", db.MemDBBackend, ""))
+	sm, err := NewSnapshotManager(source, filepath.Join(dir, "source"), cfg)
+	assert.NoError(t, err)
+	for i := 0; i < 200; i++ {
+		_ = source.Set(StoreKey("key_"+strconv.Itoa(i)), []byte("value_"+strconv.Itoa(i)))
+	}
+	hash, version := source.Commit()
+	sm.Take(version)
+	waitForSnapshot(t, sm, version)
+
+	target := NewChainState("SnapshotDirTarget", db.NewDB("target", db.MemDBBackend, ""))
+	rm, err := NewSnapshotManager(target, filepath.Join(dir, "target"), cfg)
+	assert.NoError(t, err)
+
+	// the snapshot directory copied from the source node
+	_, err = rm.Restore(sm.heightDir(version), []byte("wrong"))
+	assert.Equal(t, ErrSnapshotAppHash, err)
+	snapshot, err := rm.Restore(sm.heightDir(version), hash)
+	assert.NoError(t, err)
+	assert.Equal(t, version, snapshot.Height)
+
+	assert.Equal(t, version, target.Version)
+	assert.Equal(t, hash, target.Hash)
+	value, err := target.Get(StoreKey("key_10"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value_10"), value)
+
+	// a restored chain state can't be restored again
+	_, err = rm.Restore(sm.heightDir(version), hash)
+	assert.Equal(t, ErrSnapshotStateNotNull, err)
+}
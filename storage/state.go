@@ -3,19 +3,44 @@ package storage
 import (
 	"bytes"
 	"sync"
+
+	"github.com/tendermint/iavl"
 )
 
 var _ Store = &State{}
 var _ Iterable = &State{}
 
+var _ VersionedTree = &iavl.ImmutableTree{}
+var _ VersionedTree = &ArchiveTree{}
+
+// VersionedTree is the read only view of a committed version of the chain state, either its IAVL tree or the
+// archive once the rotation deleted it
+type VersionedTree interface {
+	Version() int64
+	Hash() []byte
+	Get(key []byte) (int64, []byte)
+	Has(key []byte) bool
+	Iterate(fn func(key, value []byte) bool) bool
+	IterateRange(start, end []byte, ascending bool, fn func(key, value []byte) bool) bool
+}
+
 type State struct {
 	cs        *ChainState
 	cache     SessionedDirectStorage
 	gc        GasCalculator
 	txSession Session
 	mux       sync.RWMutex
+
+	// tree is set for states reading a past version of the chain state, see NewVersionedState
+	tree VersionedTree
+
+	// recorder is told the previous value of the keys written, see Record
+	recorder Recorder
 }
 
+// Recorder is called before the state writes a key with the value the key had, nil if it had none
+type Recorder func(key StoreKey, prev []byte)
+
 func NewState(state *ChainState) *State {
 	return &State{
 		cs:    state,
@@ -24,12 +49,28 @@ func NewState(state *ChainState) *State {
 	}
 }
 
+// NewVersionedState returns a state reading the chain state as it was committed at the given version,
+// writes stay in its cache and are never written back to the chain state
+func NewVersionedState(state *ChainState, version int64) (*State, error) {
+	tree, err := state.GetTree(version)
+	if err != nil {
+		return nil, ErrVersionNotFound
+	}
+	return &State{
+		cs:    state,
+		cache: NewSessionedDirectStorage(SESSION_CACHE, "state"),
+		gc:    NewGasCalculator(0),
+		tree:  tree,
+	}, nil
+}
+
 func (s *State) WithGas(gc GasCalculator) *State {
 	gs := NewGasStore(s.cache, gc)
 	return &State{
 		cs:    s.cs,
 		cache: gs,
 		gc:    gc,
+		tree:  s.tree,
 	}
 }
 
@@ -71,13 +112,24 @@ func (s *State) DiscardTxSession() {
 }
 
 func (s State) Version() int64 {
+	if s.tree != nil {
+		return s.tree.Version()
+	}
 	return s.cs.Version
 }
 
 func (s State) RootHash() []byte {
+	if s.tree != nil {
+		return s.tree.Hash()
+	}
 	return s.cs.Hash
 }
 
+// IsVersioned tells whether the state reads a past version of the chain state
+func (s *State) IsVersioned() bool {
+	return s.tree != nil
+}
+
 func (s *State) DumpState() {
 	s.cache.DumpState()
 }
@@ -100,10 +152,54 @@ func (s *State) Get(key StoreKey) ([]byte, error) {
 	}
 
 	// if didn't get result in cache, get from ChainState
+	if s.tree != nil {
+		_, value := s.tree.Get(key)
+		return value, nil
+	}
 	return s.cs.Get(key)
 }
 
+// Record calls the recorder before every write of the state until the returned function is called, the writes
+// can be undone by setting back the recorded values
+func (s *State) Record(recorder Recorder) (stop func()) {
+	previous := s.recorder
+	s.recorder = recorder
+	return func() {
+		s.recorder = previous
+	}
+}
+
+func (s *State) record(key StoreKey) {
+	if s.recorder == nil {
+		return
+	}
+	prev, err := s.Get(key)
+	if err != nil || len(prev) == 0 {
+		prev = nil
+	}
+	s.recorder(key, prev)
+}
+
+// Restore writes back a value told to the recorder, a key which had none is dropped from the session so it reads as
+// before
+func (s *State) Restore(key StoreKey, prev []byte) error {
+	var store Store = s.cache
+	if s.txSession != nil {
+		store = s.txSession
+	}
+	if prev != nil {
+		return store.Set(key, prev)
+	}
+	if u, ok := store.(unsetter); ok {
+		u.unset(key)
+		return nil
+	}
+	_, err := store.Delete(key)
+	return err
+}
+
 func (s *State) Set(key StoreKey, value []byte) error {
+	s.record(key)
 	if s.txSession != nil {
 		return s.txSession.Set(key, value)
 	}
@@ -126,6 +222,9 @@ func (s *State) Exists(key StoreKey) bool {
 	exist := s.cache.Exists(key)
 	if !exist {
 		// if not existed in cache, check ChainState
+		if s.tree != nil {
+			return s.tree.Has(key)
+		}
 		return s.cs.Exists(key)
 	}
 
@@ -133,6 +232,7 @@ func (s *State) Exists(key StoreKey) bool {
 }
 
 func (s *State) Delete(key StoreKey) (bool, error) {
+	s.record(key)
 
 	if s.txSession != nil {
 		return s.txSession.Delete(key)
@@ -150,10 +250,15 @@ func (s *State) GetIterable() Iterable {
 
 func (s *State) Iterate(fn func(key []byte, value []byte) bool) (stopped bool) {
 	keys := make([]StoreKey, 0, 100)
-	s.cs.Iterate(func(key, value []byte) bool {
+	collect := func(key, value []byte) bool {
 		keys = append(keys, key)
 		return false
-	})
+	}
+	if s.tree != nil {
+		s.tree.Iterate(collect)
+	} else {
+		s.cs.Iterate(collect)
+	}
 
 	for _, key := range keys {
 		value, err := s.Get(key)
@@ -170,10 +275,15 @@ func (s *State) Iterate(fn func(key []byte, value []byte) bool) (stopped bool) {
 
 func (s *State) IterateRange(start, end []byte, ascending bool, fn func(key, value []byte) bool) (stop bool) {
 	keys := make([]StoreKey, 0, 100)
-	s.cs.IterateRange(start, end, ascending, func(key, value []byte) bool {
+	collect := func(key, value []byte) bool {
 		keys = append(keys, key)
 		return false
-	})
+	}
+	if s.tree != nil {
+		s.tree.IterateRange(start, end, ascending, collect)
+	} else {
+		s.cs.IterateRange(start, end, ascending, collect)
+	}
 	//todo: we can't get the key for anything that's only in the cache,
 	for _, key := range keys {
 		value, err := s.Get(key)
@@ -189,6 +299,10 @@ func (s *State) IterateRange(start, end []byte, ascending bool, fn func(key, val
 }
 
 func (s State) Write() bool {
+	if s.tree != nil {
+		// a past version is read only
+		return false
+	}
 	s.mux.Lock()
 	defer s.mux.Unlock()
 	s.cache.GetIterable().Iterate(func(key []byte, value []byte) bool {
@@ -204,6 +318,9 @@ func (s State) Write() bool {
 }
 
 func (s *State) Commit() (hash []byte, version int64) {
+	if s.tree != nil {
+		panic("commit on a versioned state")
+	}
 
 	s.Write()
 	s.cache = NewSessionedDirectStorage(SESSION_CACHE, "state")
@@ -221,11 +338,16 @@ func (s *State) ConsumeUpfront(gas Gas) bool {
 }
 
 func (s *State) ConsumeVerifySigGas(gas Gas) bool {
-	return s.gc.Consume(gas, VERIFYSIG, true)
+	return s.gc.Consume(gas, s.gc.GetSchedule().VerifySig, true)
 }
 
 func (s *State) ConsumeStorageGas(gas Gas) bool {
-	return s.gc.Consume(gas, STOREBYTES, true)
+	return s.gc.Consume(gas, s.gc.GetSchedule().StoreBytes, true)
+}
+
+// SetTxType prices the gas consumed next with the multiplier of the transaction type
+func (s *State) SetTxType(txType int) {
+	s.gc.SetTxType(txType)
 }
 
 func (s *State) ConsumeContractGas(gas Gas) bool {
@@ -233,7 +355,7 @@ func (s *State) ConsumeContractGas(gas Gas) bool {
 }
 
 func (s *State) GetAtHeight(version int64, key StoreKey) ([]byte, error) {
-	t, err := s.cs.Delivered.GetImmutable(version)
+	t, err := s.cs.GetTree(version)
 	if err != nil {
 		return []byte{}, err
 	}
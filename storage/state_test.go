@@ -7,6 +7,8 @@ import (
 
 	"github.com/magiconair/properties/assert"
 	"github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/config"
 )
 
 var testcase = make(map[string][]byte)
@@ -118,3 +120,89 @@ func TestState_CommitTxSession(t *testing.T) {
 func getCacheDB() db.DB {
 	return db.NewDB("test", db.MemDBBackend, "")
 }
+
+func TestState_NewVersionedState(t *testing.T) {
+	cs := NewChainState("test", getCacheDB())
+	_ = cs.SetupRotation(config.ChainStateRotationCfg{Recent: 10})
+	state := NewState(cs)
+
+	state.Set([]byte("a_1"), []byte("first"))
+	_, version := state.Commit()
+
+	state.Set([]byte("a_1"), []byte("second"))
+	state.Set([]byte("a_2"), []byte("second"))
+	state.Commit()
+
+	old, err := NewVersionedState(cs, version)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, old.Version(), version)
+
+	value, _ := old.Get([]byte("a_1"))
+	assert.Equal(t, value, []byte("first"))
+	assert.Equal(t, old.Exists([]byte("a_2")), false)
+
+	// writes stay in the cache of the versioned state
+	old.Set([]byte("a_2"), []byte("cached"))
+	value, _ = old.Get([]byte("a_2"))
+	assert.Equal(t, value, []byte("cached"))
+	value, _ = state.Get([]byte("a_2"))
+	assert.Equal(t, value, []byte("second"))
+
+	_, err = NewVersionedState(cs, version+10)
+	assert.Equal(t, err, ErrVersionNotFound)
+}
+
+func TestState_Record(t *testing.T) {
+	state := NewState(NewChainState("test", getCacheDB()))
+	state.Set(StoreKey("a"), []byte("1"))
+
+	recorded := make(map[string][]byte)
+	stop := state.Record(func(key StoreKey, prev []byte) {
+		recorded[string(key)] = prev
+	})
+	state.Set(StoreKey("a"), []byte("2"))
+	state.Set(StoreKey("b"), []byte("3"))
+	stop()
+	state.Set(StoreKey("c"), []byte("4"))
+
+	assert.Equal(t, len(recorded), 2)
+	assert.Equal(t, recorded["a"], []byte("1"))
+	assert.Equal(t, recorded["b"], []byte(nil))
+}
+
+func TestState_Restore(t *testing.T) {
+	state := NewState(NewChainState("test", getCacheDB()))
+	state.Set(StoreKey("a"), []byte("1"))
+	state.Set(StoreKey("b"), []byte("2"))
+	state.Commit()
+
+	recorded := make(map[string][]byte)
+	state.BeginTxSession()
+	state.Delete(StoreKey("a"))
+	stop := state.Record(func(key StoreKey, prev []byte) {
+		recorded[string(key)] = prev
+	})
+	state.Set(StoreKey("a"), []byte("3"))
+	state.Delete(StoreKey("b"))
+	state.Set(StoreKey("c"), []byte("4"))
+	stop()
+	assert.Equal(t, recorded["a"], []byte(TOMBSTONE))
+	assert.Equal(t, recorded["b"], []byte("2"))
+	assert.Equal(t, recorded["c"], []byte(nil))
+
+	for key, prev := range recorded {
+		assert.Equal(t, state.Restore(StoreKey(key), prev), nil)
+	}
+	value, _ := state.Get(StoreKey("a"))
+	assert.Equal(t, value, []byte(TOMBSTONE))
+	value, _ = state.Get(StoreKey("b"))
+	assert.Equal(t, value, []byte("2"))
+	value, _ = state.Get(StoreKey("c"))
+	assert.Equal(t, value, []byte(nil))
+	assert.Equal(t, state.Exists(StoreKey("c")), false)
+
+	state.CommitTxSession()
+	state.Commit()
+	assert.Equal(t, state.Exists(StoreKey("a")), false)
+	assert.Equal(t, state.Exists(StoreKey("c")), false)
+}
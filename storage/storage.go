@@ -38,6 +38,11 @@ type Session interface {
 	Commit() bool
 }
 
+// unsetter drops a key written to a session, the key reads from the storage below again
+type unsetter interface {
+	unset(key StoreKey)
+}
+
 // NewStorageSession creates a new SessionStorage
 func NewStorageDB(flavor, name string, DBDir, DBType string) SessionedStorage {
 
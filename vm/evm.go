@@ -109,7 +109,14 @@ type EVMTransaction struct {
 	isSimulation bool
 
 	// debug olvm
-	debug bool
+	debug  bool
+	tracer ethvm.Tracer
+
+	// native contracts bound to the transaction, the call recorded by the value transfer and the one the next native
+	// contract runs, see NativeContract
+	natives     map[ethcmn.Address]NativeContract
+	pendingCall *NativeCall
+	nativeCall  *NativeCall
 }
 
 func NewEVMTransaction(stateDB *CommitStateDB, gaspool *ethcore.GasPool, header *abci.Header, from keys.Address, to *keys.Address, nonce uint64, value *big.Int, data []byte, accessList *ethtypes.AccessList, gas uint64, gasPrice *big.Int, isSimulation bool) *EVMTransaction {
@@ -133,6 +140,11 @@ func (etx *EVMTransaction) SetVMDebug(debug bool) {
 	etx.debug = debug
 }
 
+// SetTracer attaches a tracer to the vm execution, it takes precedence over the state db one
+func (etx *EVMTransaction) SetTracer(tracer ethvm.Tracer) {
+	etx.tracer = tracer
+}
+
 func (etx *EVMTransaction) NewEVM() *ethvm.EVM {
 	blockCtx := ethvm.BlockContext{
 		CanTransfer: ethcore.CanTransfer,
@@ -149,7 +161,10 @@ func (etx *EVMTransaction) NewEVM() *ethvm.EVM {
 	vmConfig := ethvm.Config{
 		ExtraEips: make([]int, 0),
 	}
-	if etx.debug {
+	if tracer := etx.getTracer(); tracer != nil {
+		vmConfig.Debug = true
+		vmConfig.Tracer = tracer
+	} else if etx.debug {
 		vmConfig.Debug = true
 		vmConfig.Tracer = ethvm.NewMarkdownLogger(&ethvm.LogConfig{
 			Debug:     true,
@@ -162,7 +177,18 @@ func (etx *EVMTransaction) NewEVM() *ethvm.EVM {
 		GasPrice: etx.gasPrice,
 	}
 
-	return ethvm.NewEVM(blockCtx, txCtx, etx.stateDB, ethConfig, vmConfig)
+	evm := ethvm.NewEVM(blockCtx, txCtx, etx.stateDB, ethConfig, vmConfig)
+	if len(etx.natives) > 0 {
+		evm.Context.Transfer = etx.transfer()
+	}
+	return evm
+}
+
+func (etx *EVMTransaction) getTracer() ethvm.Tracer {
+	if etx.tracer != nil {
+		return etx.tracer
+	}
+	return etx.stateDB.tracer
 }
 
 func (etx *EVMTransaction) From() ethcmn.Address {
@@ -209,6 +235,9 @@ func (etx *EVMTransaction) GasPrice() *big.Int {
 }
 
 func (etx *EVMTransaction) Apply() (*ExecutionResult, error) {
+	unlock := etx.lockNative()
+	defer unlock()
+
 	executionResult, err := ApplyMessage(etx.NewEVM(), etx, etx.gaspool)
 
 	if !etx.IsFake() {
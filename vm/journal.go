@@ -3,6 +3,7 @@ package vm
 import (
 	"math/big"
 
+	"github.com/Oneledger/protocol/storage"
 	ethcmn "github.com/ethereum/go-ethereum/common"
 )
 
@@ -376,3 +377,20 @@ func (ch accessListAddSlotChange) revert(s *CommitStateDB) {
 func (ch accessListAddSlotChange) dirtied() *ethcmn.Address {
 	return nil
 }
+
+// nativeStateChange is a key of the native stores written by a native contract
+type nativeStateChange struct {
+	state *storage.State
+	key   storage.StoreKey
+	prev  []byte
+}
+
+func (ch nativeStateChange) revert(s *CommitStateDB) {
+	if err := ch.state.Restore(ch.key, ch.prev); err != nil {
+		s.setError(err)
+	}
+}
+
+func (ch nativeStateChange) dirtied() *ethcmn.Address {
+	return nil
+}
@@ -0,0 +1,191 @@
+package vm
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/Oneledger/protocol/storage"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	ErrNativeUnavailable = errors.New("native contract is not available")
+	ErrNativeReadOnly    = errors.New("native contract write in read only call")
+)
+
+// NativeContract is a precompiled contract backed by a native module. The geth precompiles are global and don't know
+// their caller, so the native contracts are reserved at an address with RegisterNativeContract, added to the geth
+// precompiles with EnableNativeContracts on the chains scheduling them, and bound to the EVM transaction running them
+// with SetNativeContracts. The reserved addresses are empty accounts for the transactions without native contracts.
+type NativeContract interface {
+	// RequiredGas returns the gas used to run the input
+	RequiredGas(input []byte) uint64
+
+	// Run runs the input for the call, the writes of the native stores are journaled with JournalState
+	Run(call *NativeCall, input []byte) ([]byte, error)
+}
+
+// NativeCall is a call to a native contract, the calls other than CALL are read only and leave the caller unset. geth
+// doesn't tell whether a CALL is made from a STATICCALL frame, so a native contract only writes the delegation of
+// its caller.
+type NativeCall struct {
+	Contract ethcmn.Address
+	Caller   ethcmn.Address
+	Value    *big.Int
+	ReadOnly bool
+	StateDB  *CommitStateDB
+}
+
+var (
+	nativeAddresses     = make(map[ethcmn.Address]bool)
+	nativeAddressesList []ethcmn.Address
+
+	nativeEnable  sync.Once
+	nativeEnabled bool
+
+	// nativeMu is held by the EVM transaction with native contracts running, the one the dispatchers run, the other
+	// transactions read lock it once the dispatchers are enabled so they run them as empty accounts
+	nativeMu sync.RWMutex
+	nativeTx *EVMTransaction
+)
+
+// RegisterNativeContract reserves the address for a native contract, it must be called before
+// EnableNativeContracts, usually from an init function
+func RegisterNativeContract(addr ethcmn.Address) {
+	if nativeAddresses[addr] {
+		return
+	}
+	nativeAddresses[addr] = true
+	nativeAddressesList = append(nativeAddressesList, addr)
+}
+
+// EnableNativeContracts adds a dispatcher to the native contract bound to the transaction running at the reserved
+// addresses. geth only looks the precompiles up in its global tables, so it must be called once before any EVM
+// transaction runs and only by the chains scheduling the native contracts. The dispatchers are left out of the geth
+// precompile addresses, see activePrecompiles.
+func EnableNativeContracts() {
+	nativeEnable.Do(func() {
+		for _, addr := range nativeAddressesList {
+			ethvm.PrecompiledContractsBerlin[addr] = &nativeDispatcher{address: addr}
+		}
+		nativeEnabled = true
+	})
+}
+
+// IsNativeContract tells whether the address is reserved for a native contract
+func IsNativeContract(addr ethcmn.Address) bool {
+	return nativeAddresses[addr]
+}
+
+// SetNativeContracts binds the native contracts run by the transaction, the reserved addresses without a contract
+// fail with ErrNativeUnavailable
+func (etx *EVMTransaction) SetNativeContracts(contracts map[ethcmn.Address]NativeContract) {
+	etx.natives = contracts
+}
+
+// activePrecompiles returns the precompiles warm in the access list of the transaction, the native contracts are
+// only when the transaction has them
+func (etx *EVMTransaction) activePrecompiles(rules params.Rules) []ethcmn.Address {
+	precompiles := ethvm.ActivePrecompiles(rules)
+	if len(etx.natives) == 0 {
+		return precompiles
+	}
+	return append(append(make([]ethcmn.Address, 0, len(precompiles)+len(nativeAddressesList)), precompiles...), nativeAddressesList...)
+}
+
+// transfer is the value transfer of the EVM running the native contracts, a CALL transfers the value right before
+// running the precompile so it records the caller of the native contract called
+func (etx *EVMTransaction) transfer() ethvm.TransferFunc {
+	return func(db ethvm.StateDB, sender, recipient ethcmn.Address, amount *big.Int) {
+		if IsNativeContract(recipient) {
+			etx.pendingCall = &NativeCall{
+				Contract: recipient,
+				Caller:   sender,
+				Value:    new(big.Int).Set(amount),
+			}
+		}
+		ethcore.Transfer(db, sender, recipient, amount)
+	}
+}
+
+// lockNative makes the transaction the one the native contracts dispatch to until the returned function is called,
+// the transactions without native contracts only wait for the one running
+func (etx *EVMTransaction) lockNative() (unlock func()) {
+	if len(etx.natives) == 0 {
+		if !nativeEnabled {
+			return func() {}
+		}
+		nativeMu.RLock()
+		return nativeMu.RUnlock
+	}
+	nativeMu.Lock()
+	nativeTx = etx
+	return func() {
+		nativeTx = nil
+		etx.pendingCall = nil
+		etx.nativeCall = nil
+		nativeMu.Unlock()
+	}
+}
+
+// JournalState records the writes made by fn to the state in the journal of the state db, they are undone when the
+// EVM reverts the call
+func (s *CommitStateDB) JournalState(state *storage.State, fn func() error) error {
+	stop := state.Record(func(key storage.StoreKey, prev []byte) {
+		s.journal.append(nativeStateChange{
+			state: state,
+			key:   append(storage.StoreKey{}, key...),
+			prev:  prev,
+		})
+	})
+	defer stop()
+	return fn()
+}
+
+// nativeDispatcher is the geth precompile registered at a native contract address, it runs the contract bound to
+// the EVM transaction holding nativeMu
+type nativeDispatcher struct {
+	address ethcmn.Address
+}
+
+// RequiredGas is called right before Run, it takes the call recorded by the value transfer of a CALL, the other
+// calls are read only and have no caller
+func (d *nativeDispatcher) RequiredGas(input []byte) uint64 {
+	if nativeTx == nil || len(nativeTx.natives) == 0 {
+		return 0
+	}
+	call := nativeTx.pendingCall
+	nativeTx.pendingCall = nil
+	if call == nil || call.Contract != d.address {
+		call = &NativeCall{Contract: d.address, Value: new(big.Int), ReadOnly: true}
+	}
+	nativeTx.nativeCall = call
+
+	contract := nativeTx.natives[d.address]
+	if contract == nil {
+		return 0
+	}
+	return contract.RequiredGas(input)
+}
+
+func (d *nativeDispatcher) Run(input []byte) ([]byte, error) {
+	if nativeTx == nil || len(nativeTx.natives) == 0 {
+		// an empty account
+		return nil, nil
+	}
+	contract := nativeTx.natives[d.address]
+	if contract == nil {
+		return nil, ErrNativeUnavailable
+	}
+	call := nativeTx.nativeCall
+	nativeTx.nativeCall = nil
+	if call == nil || call.Contract != d.address {
+		call = &NativeCall{Contract: d.address, Value: new(big.Int), ReadOnly: true}
+	}
+	call.StateDB = nativeTx.stateDB
+	return contract.Run(call, input)
+}
@@ -47,6 +47,9 @@ type StateTransition struct {
 	data       []byte
 	state      ethvm.StateDB
 	evm        *ethvm.EVM
+
+	// precompiles warm in the access list
+	precompiles []ethcmn.Address
 }
 
 // Message represents a message sent to a contract.
@@ -150,6 +153,8 @@ func NewStateTransition(evm *ethvm.EVM, msg *EVMTransaction, gp *ethcore.GasPool
 		value:    msg.Value(),
 		data:     msg.Data(),
 		state:    evm.StateDB,
+
+		precompiles: msg.activePrecompiles(evm.ChainConfig().Rules(evm.Context.BlockNumber)),
 	}
 }
 
@@ -261,7 +266,7 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 
 	// Set up the initial access list.
 	if rules := st.evm.ChainConfig().Rules(st.evm.Context.BlockNumber); rules.IsBerlin {
-		st.state.PrepareAccessList(msg.From(), msg.To(), ethvm.ActivePrecompiles(rules), msg.AccessList())
+		st.state.PrepareAccessList(msg.From(), msg.To(), st.precompiles, msg.AccessList())
 	}
 
 	var (
@@ -71,6 +71,9 @@ type CommitStateDB struct {
 	// Bloom buffer for logs bloom bytes
 	bloomBuffer []byte
 	bloom       Bloom
+
+	// tracer used by every evm created on top of this state db (debug only)
+	tracer ethvm.Tracer
 }
 
 // NewCommitStateDB returns a reference to a newly initialized CommitStateDB
@@ -197,6 +200,9 @@ func (s *CommitStateDB) Finalise(deleteEmptyObjects bool) error {
 		s.logger.Detail("VM: starting to process finalise entry", stateEntry.address, "dirty", isDirty)
 
 		switch {
+		case isDirty && nativeEnabled && IsNativeContract(stateEntry.address) && stateEntry.stateObject.empty():
+			// the native contract addresses are touched by every call and hold nothing, they are not stored
+
 		case stateEntry.stateObject.suicided || (isDirty && deleteEmptyObjects && stateEntry.stateObject.empty()):
 			// If the state object has been removed, don't bother syncing it and just
 			// remove it from the store.
@@ -249,6 +255,11 @@ func (s *CommitStateDB) SetBlockStore(blockStore *store.BlockStore) {
 	s.blockStore = blockStore
 }
 
+// SetTracer sets the tracer for the upcoming vm executions, nil switches tracing off
+func (s *CommitStateDB) SetTracer(tracer ethvm.Tracer) {
+	s.tracer = tracer
+}
+
 // GetBlockStore current to fetch a blocks
 func (s *CommitStateDB) GetBlockStore() *store.BlockStore {
 	return s.blockStore
@@ -0,0 +1,267 @@
+package vm
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+)
+
+const CallTracerName = "callTracer"
+
+var (
+	_ ethvm.Tracer = (*CallTracer)(nil)
+
+	errExecutionReverted = "execution reverted"
+	errInternalFailure   = "internal failure"
+)
+
+// callFrame is a single (sub)call reported by the CallTracer, fields follow the geth callTracer output
+type callFrame struct {
+	Type    string          `json:"type"`
+	From    ethcmn.Address  `json:"from"`
+	To      *ethcmn.Address `json:"to,omitempty"`
+	Value   *hexutil.Big    `json:"value,omitempty"`
+	Gas     *hexutil.Uint64 `json:"gas,omitempty"`
+	GasUsed *hexutil.Uint64 `json:"gasUsed,omitempty"`
+	Input   hexutil.Bytes   `json:"input"`
+	Output  *hexutil.Bytes  `json:"output,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Time    string          `json:"time,omitempty"`
+	Calls   []*callFrame    `json:"calls,omitempty"`
+
+	gasIn   uint64
+	gasCost uint64
+	outOff  uint64
+	outLen  uint64
+}
+
+func (f *callFrame) setGas(gas uint64) {
+	g := hexutil.Uint64(gas)
+	f.Gas = &g
+}
+
+func (f *callFrame) setGasUsed(gasUsed uint64) {
+	g := hexutil.Uint64(gasUsed)
+	f.GasUsed = &g
+}
+
+func (f *callFrame) setOutput(output []byte) {
+	out := hexutil.Bytes(output)
+	f.Output = &out
+}
+
+func (f *callFrame) addCall(call *callFrame) {
+	f.Calls = append(f.Calls, call)
+}
+
+// CallTracer is a native port of the geth javascript "callTracer", it records the call tree of a
+// transaction with the gas, input and output of every call
+type CallTracer struct {
+	precompiles map[ethcmn.Address]struct{}
+
+	callstack []*callFrame
+	descended bool
+
+	result *callFrame
+}
+
+func NewCallTracer() *CallTracer {
+	return &CallTracer{
+		callstack: []*callFrame{{}},
+	}
+}
+
+func (t *CallTracer) CaptureStart(env *ethvm.EVM, from ethcmn.Address, to ethcmn.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.precompiles = make(map[ethcmn.Address]struct{})
+	rules := env.ChainConfig().Rules(env.Context.BlockNumber)
+	for _, addr := range ethvm.ActivePrecompiles(rules) {
+		t.precompiles[addr] = struct{}{}
+	}
+
+	t.result = &callFrame{
+		Type:  "CALL",
+		From:  from,
+		To:    &to,
+		Input: ethcmn.CopyBytes(input),
+	}
+	if create {
+		t.result.Type = "CREATE"
+	}
+	if value != nil {
+		t.result.Value = (*hexutil.Big)(new(big.Int).Set(value))
+	}
+	t.result.setGas(gas)
+}
+
+func (t *CallTracer) CaptureState(env *ethvm.EVM, pc uint64, op ethvm.OpCode, gas, cost uint64, scope *ethvm.ScopeContext, rData []byte, depth int, err error) {
+	if err != nil {
+		t.fault(err)
+		return
+	}
+
+	stack := scope.Stack
+	memory := scope.Memory
+	contract := scope.Contract
+
+	// only the system opcodes are interesting
+	syscall := (op & 0xf0) == 0xf0
+
+	switch {
+	case syscall && (op == ethvm.CREATE || op == ethvm.CREATE2):
+		inOff, inLen := stack.Back(1).Uint64(), stack.Back(2).Uint64()
+		t.callstack = append(t.callstack, &callFrame{
+			Type:    op.String(),
+			From:    contract.Address(),
+			Input:   memory.GetCopy(int64(inOff), int64(inLen)),
+			Value:   (*hexutil.Big)(stack.Back(0).ToBig()),
+			gasIn:   gas,
+			gasCost: cost,
+		})
+		t.descended = true
+		return
+
+	case syscall && op == ethvm.SELFDESTRUCT:
+		to := ethcmn.Address(stack.Back(0).Bytes20())
+		t.callstack[len(t.callstack)-1].addCall(&callFrame{
+			Type:    op.String(),
+			From:    contract.Address(),
+			To:      &to,
+			Input:   hexutil.Bytes{},
+			Value:   (*hexutil.Big)(env.StateDB.GetBalance(contract.Address())),
+			gasIn:   gas,
+			gasCost: cost,
+		})
+		return
+
+	case syscall && (op == ethvm.CALL || op == ethvm.CALLCODE || op == ethvm.DELEGATECALL || op == ethvm.STATICCALL):
+		to := ethcmn.Address(stack.Back(1).Bytes20())
+		// precompiles are just fancy opcodes
+		if _, ok := t.precompiles[to]; ok {
+			return
+		}
+		off := 1
+		if op == ethvm.DELEGATECALL || op == ethvm.STATICCALL {
+			off = 0
+		}
+		inOff, inLen := stack.Back(2+off).Uint64(), stack.Back(3+off).Uint64()
+		call := &callFrame{
+			Type:    op.String(),
+			From:    contract.Address(),
+			To:      &to,
+			Input:   memory.GetCopy(int64(inOff), int64(inLen)),
+			gasIn:   gas,
+			gasCost: cost,
+			outOff:  stack.Back(4 + off).Uint64(),
+			outLen:  stack.Back(5 + off).Uint64(),
+		}
+		if op != ethvm.DELEGATECALL && op != ethvm.STATICCALL {
+			call.Value = (*hexutil.Big)(stack.Back(2).ToBig())
+		}
+		t.callstack = append(t.callstack, call)
+		t.descended = true
+		return
+	}
+
+	// the first opcode of an inner call tells the gas it really got (stipend, 63/64 rule)
+	if t.descended {
+		if depth >= len(t.callstack) {
+			t.callstack[len(t.callstack)-1].setGas(gas)
+		}
+		t.descended = false
+	}
+
+	if syscall && op == ethvm.REVERT {
+		t.callstack[len(t.callstack)-1].Error = errExecutionReverted
+		return
+	}
+
+	if depth == len(t.callstack)-1 {
+		// the last call returned, collect its results and add it to the parent
+		call := t.callstack[len(t.callstack)-1]
+		t.callstack = t.callstack[:len(t.callstack)-1]
+
+		ret := stack.Back(0)
+		if call.Type == ethvm.CREATE.String() || call.Type == ethvm.CREATE2.String() {
+			call.setGasUsed(call.gasIn - call.gasCost - gas)
+			if !ret.IsZero() {
+				to := ethcmn.Address(ret.Bytes20())
+				call.To = &to
+				call.setOutput(env.StateDB.GetCode(to))
+			} else if call.Error == "" {
+				call.Error = errInternalFailure
+			}
+		} else {
+			if call.Gas != nil {
+				call.setGasUsed(call.gasIn - call.gasCost + uint64(*call.Gas) - gas)
+			}
+			if !ret.IsZero() {
+				call.setOutput(memory.GetCopy(int64(call.outOff), int64(call.outLen)))
+			} else if call.Error == "" {
+				call.Error = errInternalFailure
+			}
+		}
+		t.callstack[len(t.callstack)-1].addCall(call)
+	}
+}
+
+func (t *CallTracer) CaptureFault(env *ethvm.EVM, pc uint64, op ethvm.OpCode, gas, cost uint64, scope *ethvm.ScopeContext, depth int, err error) {
+	t.fault(err)
+}
+
+func (t *CallTracer) fault(err error) {
+	// the topmost call already reverted, don't handle the fault again
+	if t.callstack[len(t.callstack)-1].Error != "" {
+		return
+	}
+	call := t.callstack[len(t.callstack)-1]
+	t.callstack = t.callstack[:len(t.callstack)-1]
+	call.Error = err.Error()
+
+	// all the available gas is consumed
+	if call.Gas != nil {
+		call.setGasUsed(uint64(*call.Gas))
+	}
+
+	if len(t.callstack) > 0 {
+		t.callstack[len(t.callstack)-1].addCall(call)
+		return
+	}
+	// the last call failed too, leave it on the stack
+	t.callstack = append(t.callstack, call)
+}
+
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) {
+	if t.result == nil {
+		return
+	}
+	t.result.setGasUsed(gasUsed)
+	t.result.setOutput(ethcmn.CopyBytes(output))
+	t.result.Time = d.String()
+	if err != nil {
+		t.result.Error = err.Error()
+	}
+}
+
+// GetResult returns the json encoded call tree
+func (t *CallTracer) GetResult() (json.RawMessage, error) {
+	if t.result == nil {
+		return nil, errors.New("no vm execution was traced")
+	}
+
+	result := *t.result
+	if len(t.callstack) > 0 {
+		result.Calls = t.callstack[0].Calls
+		if t.callstack[0].Error != "" {
+			result.Error = t.callstack[0].Error
+		}
+	}
+	if result.Error != "" && (result.Error != errExecutionReverted || result.Output == nil || len(*result.Output) == 0) {
+		result.Output = nil
+	}
+	return json.Marshal(&result)
+}
@@ -0,0 +1,84 @@
+package vm
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/Oneledger/protocol/data/accounts"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallTracer_GetResult(t *testing.T) {
+	// contract Incrementor {
+	//     uint256 public incr;
+	//
+	//     function increment() public {
+	//         incr = incr.add(1);
+	//     }
+	// }
+	acc, _ := accounts.GenerateNewAccount(1, "test")
+	ctx := assemblyCtxData(acc, "OLT", 18, true, false, false, nil)
+	ethAcc, _ := ctx.StateDB.GetAccountKeeper().NewAccountWithAddress(acc.Address())
+	ctx.StateDB.GetAccountKeeper().SetAccount(*ethAcc)
+	code := ethcmn.FromHex("0x608060405234801561001057600080fd5b5061018c806100206000396000f3fe608060405234801561001057600080fd5b50600436106100365760003560e01c8063119fbbd41461003b578063d09de08a14610059575b600080fd5b610043610063565b60405161005091906100ac565b60405180910390f35b610061610069565b005b60005481565b61007f600160005461008790919063ffffffff16565b600081905550565b6000818361009591906100c7565b905092915050565b6100a68161011d565b82525050565b60006020820190506100c1600083018461009d565b92915050565b60006100d28261011d565b91506100dd8361011d565b9250827fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff0382111561011257610111610127565b5b828201905092915050565b6000819050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fdfea2646970667358221220206bd15026183641523bfcf445666088e5c5960b19f01272714e3ac8211f4f2264736f6c63430008060033")
+	gas := uint64(3000000)
+	value := big.NewInt(0)
+
+	accRef := ethvm.AccountRef(ethcmn.BytesToAddress(acc.Address()))
+	nonce := getNonce(ctx, acc.Address())
+	evm := NewEVMTransaction(ctx.StateDB, new(ethcore.GasPool).AddGas(SimulationBlockGasLimit), ctx.Header, acc.Address(), nil, nonce, value, code, nil, gas, DefaultGasPrice, true).NewEVM()
+	_, contractAddr, _, err := evm.Create(accRef, code, gas, value)
+	assert.NoError(t, err, "Set contract failed")
+
+	type frame struct {
+		Type    string `json:"type"`
+		From    string `json:"from"`
+		To      string `json:"to"`
+		GasUsed string `json:"gasUsed"`
+		Input   string `json:"input"`
+		Error   string `json:"error"`
+	}
+
+	t.Run("test call tracer on a successful call", func(t *testing.T) {
+		tracer := NewCallTracer()
+		ctx.StateDB.SetTracer(tracer)
+		defer ctx.StateDB.SetTracer(nil)
+
+		evm := NewEVMTransaction(ctx.StateDB, new(ethcore.GasPool).AddGas(SimulationBlockGasLimit), ctx.Header, acc.Address(), nil, nonce, value, nil, nil, gas, DefaultGasPrice, true).NewEVM()
+		input := ethcmn.FromHex("0xd09de08a") // "increment()"
+		_, _, err := evm.Call(accRef, contractAddr, input, gas, value)
+		assert.NoError(t, err, "Execute contract failed")
+
+		raw, err := tracer.GetResult()
+		assert.NoError(t, err)
+		result := frame{}
+		assert.NoError(t, json.Unmarshal(raw, &result))
+		assert.Equal(t, "CALL", result.Type)
+		assert.Equal(t, ethcmn.BytesToAddress(acc.Address()), ethcmn.HexToAddress(result.From))
+		assert.Equal(t, contractAddr, ethcmn.HexToAddress(result.To))
+		assert.Equal(t, "0xd09de08a", result.Input)
+		assert.Equal(t, "0x57f3", result.GasUsed)
+		assert.Empty(t, result.Error)
+	})
+
+	t.Run("test call tracer on an unknown method", func(t *testing.T) {
+		tracer := NewCallTracer()
+		ctx.StateDB.SetTracer(tracer)
+		defer ctx.StateDB.SetTracer(nil)
+
+		evm := NewEVMTransaction(ctx.StateDB, new(ethcore.GasPool).AddGas(SimulationBlockGasLimit), ctx.Header, acc.Address(), nil, nonce, value, nil, nil, gas, DefaultGasPrice, true).NewEVM()
+		input := ethcmn.FromHex("0xdeadbeef")
+		_, _, err := evm.Call(accRef, contractAddr, input, gas, value)
+		assert.Error(t, err, "Execute contract not failed")
+
+		raw, err := tracer.GetResult()
+		assert.NoError(t, err)
+		result := frame{}
+		assert.NoError(t, json.Unmarshal(raw, &result))
+		assert.Equal(t, "execution reverted", result.Error)
+	})
+}
@@ -1,9 +1,11 @@
 package web3
 
 import (
+	"path/filepath"
 	"reflect"
 	"unsafe"
 
+	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/app/node"
 	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/consensus"
@@ -12,10 +14,15 @@ import (
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/log"
 	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/web3/debug"
 	"github.com/Oneledger/protocol/web3/eth"
 	"github.com/Oneledger/protocol/web3/net"
+	"github.com/Oneledger/protocol/web3/personal"
+	"github.com/Oneledger/protocol/web3/txpool"
 	rpctypes "github.com/Oneledger/protocol/web3/types"
+	"github.com/Oneledger/protocol/web3/wallet"
 	"github.com/Oneledger/protocol/web3/web3"
+	abci "github.com/tendermint/tendermint/abci/types"
 	cs "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
@@ -33,6 +40,8 @@ type Context struct {
 	cfg         *config.Server
 	chainstate  *storage.ChainState
 	currencies  *balance.CurrencySet
+	actionCtx   rpctypes.ActionContextBuilder
+	wallet      *wallet.Wallet
 
 	services map[string]rpctypes.Web3Service
 }
@@ -41,18 +50,22 @@ type Context struct {
 func NewContext(
 	logger *log.Logger, node *consensus.Node,
 	feePool *fees.Store, nodeContext *node.Context, cfg *config.Server,
-	chainstate *storage.ChainState, currencies *balance.CurrencySet,
+	chainstate *storage.ChainState, currencies *balance.CurrencySet, actionCtx rpctypes.ActionContextBuilder,
 ) rpctypes.Web3Context {
-	ctx := &Context{logger, node, feePool, nodeContext, cfg, chainstate, currencies, make(map[string]rpctypes.Web3Service, 0)}
+	ctx := &Context{logger, node, feePool, nodeContext, cfg, chainstate, currencies, actionCtx, wallet.NewWallet(filepath.Join(cfg.RootDir(), wallet.KeyStorePath)), make(map[string]rpctypes.Web3Service, 0)}
 	ctx.defaultRegisterForAll()
 	return ctx
 }
 
 // defaultRegisterForAll regs services from the namespaces
 func (ctx *Context) defaultRegisterForAll() {
-	ctx.RegisterService("eth", eth.NewService(ctx))
+	ethService := eth.NewService(ctx)
+	ctx.RegisterService("eth", ethService)
 	ctx.RegisterService("net", net.NewService(ctx))
 	ctx.RegisterService("web3", web3.NewService(ctx))
+	ctx.RegisterService("debug", debug.NewService(ctx))
+	ctx.RegisterService("personal", personal.NewService(ctx, ethService))
+	ctx.RegisterService("txpool", txpool.NewService(ctx))
 }
 
 // RegisterService used to register service. NOTE: Must be called by service
@@ -128,6 +141,7 @@ func (ctx *Context) GetFeePool() *fees.Store {
 	fstore := fees.NewStore("f", ctx.getImmortalState())
 	opts := *ctx.feePool.GetOpt()
 	fstore.SetupOpt(&opts)
+	fstore.SetupMarketOpt(ctx.feePool.GetMarketOpt())
 	return fstore
 }
 
@@ -138,3 +152,15 @@ func (ctx *Context) GetNodeContext() *node.Context {
 func (ctx *Context) GetConfig() *config.Server {
 	return ctx.cfg
 }
+
+func (ctx *Context) GetChainState() *storage.ChainState {
+	return ctx.chainstate
+}
+
+func (ctx *Context) GetActionContext(header *abci.Header, state *storage.State) *action.Context {
+	return ctx.actionCtx(header, state)
+}
+
+func (ctx *Context) GetWallet() *wallet.Wallet {
+	return ctx.wallet
+}
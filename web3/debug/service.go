@@ -0,0 +1,112 @@
+package debug
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	tmrpccore "github.com/tendermint/tendermint/rpc/core"
+
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	rpcutils "github.com/Oneledger/protocol/web3/utils"
+)
+
+var _ rpctypes.Web3Service = (*Service)(nil)
+
+type Service struct {
+	ctx    rpctypes.Web3Context
+	logger *log.Logger
+}
+
+func NewService(ctx rpctypes.Web3Context) *Service {
+	return &Service{ctx: ctx, logger: log.NewLoggerWithPrefix(os.Stdout, "debug")}
+}
+
+// TraceTransaction replays the block up to the given transaction and returns the trace of its execution
+func (svc *Service) TraceTransaction(hash common.Hash, config *TraceConfig) (interface{}, error) {
+	svc.logger.Debug("debug_traceTransaction", "hash", hash)
+
+	tx, err := tmrpccore.Tx(nil, hash.Bytes(), false)
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s not found", hash.Hex())
+	}
+	block := svc.ctx.GetBlockStore().LoadBlock(tx.Height)
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", tx.Height)
+	}
+
+	env, err := svc.newReplayEnv(block)
+	if err != nil {
+		return nil, err
+	}
+	for _, prev := range block.Txs[:tx.Index] {
+		if _, err := env.deliver(prev, nil); err != nil {
+			return nil, err
+		}
+	}
+	return env.traceTx(block.Txs[tx.Index], config)
+}
+
+// TraceBlockByNumber replays the block and returns the trace of every transaction in it
+func (svc *Service) TraceBlockByNumber(number rpc.BlockNumber, config *TraceConfig) ([]*TxTraceResult, error) {
+	svc.logger.Debug("debug_traceBlockByNumber", "number", number)
+
+	height := svc.getStateHeight(number.Int64())
+	block := svc.ctx.GetBlockStore().LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", height)
+	}
+
+	env, err := svc.newReplayEnv(block)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*TxTraceResult, len(block.Txs))
+	for i, tx := range block.Txs {
+		result, err := env.traceTx(tx, config)
+		if err != nil {
+			results[i] = &TxTraceResult{Error: err.Error()}
+			continue
+		}
+		results[i] = &TxTraceResult{Result: result}
+	}
+	return results, nil
+}
+
+// TraceCall executes the call on top of the state of the given block and returns its trace
+func (svc *Service) TraceCall(args rpctypes.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceConfig) (interface{}, error) {
+	svc.logger.Debug("debug_traceCall", "args", args, "block", blockNrOrHash)
+
+	height, err := rpctypes.StateAndHeaderByNumberOrHash(svc.ctx.GetBlockStore(), blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	height = svc.getStateHeight(height)
+	block := svc.ctx.GetBlockStore().LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", height)
+	}
+
+	return svc.traceCall(block, args, config)
+}
+
+func (svc *Service) getStateHeight(height int64) int64 {
+	switch height {
+	case rpctypes.LatestBlockNumber, rpctypes.PendingBlockNumber:
+		return svc.ctx.GetChainState().Version
+	case rpctypes.EarliestBlockNumber:
+		return rpctypes.InitialBlockNumber
+	}
+	return height
+}
+
+func (svc *Service) getVersionedState(version int64) (*storage.State, error) {
+	state, err := storage.NewVersionedState(svc.ctx.GetChainState(), version)
+	if err != nil {
+		return nil, fmt.Errorf("state at height %d is not available, it may have been pruned", version)
+	}
+	return state.WithGas(rpcutils.NewBlockGasCalculator(svc.ctx.GetGenesisDoc(), state)), nil
+}
@@ -0,0 +1,217 @@
+package debug
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/olvm"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/vm"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	rpcutils "github.com/Oneledger/protocol/web3/utils"
+)
+
+const defaultTraceTimeout = 5 * time.Second
+
+var ErrNotOLVMTx = errors.New("not an olvm transaction")
+
+// replayEnv re-executes the transactions of a block on top of the state of the previous one, only the
+// transactions are replayed, changes done by the begin block are not part of it
+type replayEnv struct {
+	ctx *action.Context
+}
+
+func (svc *Service) newReplayEnv(block *tmtypes.Block) (*replayEnv, error) {
+	state, err := svc.getVersionedState(block.Height - 1)
+	if err != nil {
+		return nil, err
+	}
+	ctx := svc.ctx.GetActionContext(newBlockHeader(block), state)
+	ctx.StateDB.SetBlockHash(common.BytesToHash(block.Hash()))
+	return &replayEnv{ctx: ctx}, nil
+}
+
+// deliver runs the transaction the same way the block processing does, with the tracer attached to the vm
+func (env *replayEnv) deliver(rawTx tmtypes.Tx, tracer ethvm.Tracer) (action.Response, error) {
+	return rpcutils.DeliverTx(env.ctx, rawTx, tracer)
+}
+
+// traceTx delivers the transaction with the tracer chosen by the config and returns the formatted trace
+func (env *replayEnv) traceTx(rawTx tmtypes.Tx, config *TraceConfig) (interface{}, error) {
+	tx := &action.SignedTx{}
+	err := serialize.GetSerializer(serialize.NETWORK).Deserialize(rawTx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize transaction %X: %s", rawTx.Hash(), err)
+	}
+	if tx.Type != action.OLVM {
+		// still apply it, the following transactions depend on its changes
+		if _, err := env.deliver(rawTx, nil); err != nil {
+			return nil, err
+		}
+		return nil, ErrNotOLVMTx
+	}
+
+	tracer, err := newTracer(config)
+	if err != nil {
+		return nil, err
+	}
+	response, err := env.deliver(rawTx, tracer)
+	if err != nil {
+		return nil, err
+	}
+	return tracer.result(uint64(response.GasUsed))
+}
+
+func (svc *Service) traceCall(block *tmtypes.Block, args rpctypes.CallArgs, config *TraceConfig) (interface{}, error) {
+	state, err := svc.getVersionedState(block.Height)
+	if err != nil {
+		return nil, err
+	}
+	header := newBlockHeader(block)
+	actionCtx := svc.ctx.GetActionContext(header, state)
+	stateDB := actionCtx.StateDB
+	stateDB.SetBlockHash(common.BytesToHash(block.Hash()))
+
+	from := keys.Address(args.From.Bytes())
+
+	var to *keys.Address
+	if args.To != nil {
+		to = new(keys.Address)
+		*to = args.To.Bytes()
+	}
+
+	gasPrice := vm.DefaultGasPrice
+	if args.GasPrice != nil {
+		gasPrice = args.GasPrice.ToInt()
+	}
+
+	gas := vm.SimulationBlockGasLimit
+	if args.Gas != 0 {
+		gas = uint64(args.Gas)
+	}
+
+	value := new(big.Int)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+
+	// Set infinite balance to the fake caller account.
+	fromAcc := stateDB.GetOrNewStateObject(args.From)
+	fromAcc.SetBalance(math.MaxBig256)
+	nonce := stateDB.GetNonce(args.From)
+
+	evmTx := vm.NewEVMTransaction(stateDB, new(ethcore.GasPool).AddGas(math.MaxUint64), header, from, to, nonce, value, args.Data, nil, gas, gasPrice, true)
+
+	tracer, err := newTracer(config)
+	if err != nil {
+		return nil, err
+	}
+	evmTx.SetTracer(tracer)
+	evmTx.SetNativeContracts(olvm.NativeContracts(actionCtx))
+
+	result, err := evmTx.Apply()
+	if err != nil {
+		return nil, err
+	}
+	return tracer.result(result.UsedGas)
+}
+
+// tracer wraps the tracer picked by the config, it aborts the vm once the timeout is reached
+type tracer struct {
+	ethvm.Tracer
+
+	timer   *time.Timer
+	timeout time.Duration
+	stopped uint32
+}
+
+func newTracer(config *TraceConfig) (*tracer, error) {
+	if config == nil {
+		config = &TraceConfig{}
+	}
+
+	t := &tracer{timeout: defaultTraceTimeout}
+	if config.Timeout != nil {
+		timeout, err := time.ParseDuration(*config.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %s: %s", *config.Timeout, err)
+		}
+		t.timeout = timeout
+	}
+
+	switch {
+	case config.Tracer == nil:
+		t.Tracer = ethvm.NewStructLogger(config.LogConfig)
+	case *config.Tracer == vm.CallTracerName:
+		t.Tracer = vm.NewCallTracer()
+	default:
+		return nil, fmt.Errorf("tracer %s is not supported, only the struct logger and %s are available", *config.Tracer, vm.CallTracerName)
+	}
+	return t, nil
+}
+
+func (t *tracer) CaptureStart(env *ethvm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	if t.timer == nil {
+		t.timer = time.AfterFunc(t.timeout, func() {
+			atomic.StoreUint32(&t.stopped, 1)
+		})
+	}
+	t.Tracer.CaptureStart(env, from, to, create, input, gas, value)
+}
+
+func (t *tracer) CaptureState(env *ethvm.EVM, pc uint64, op ethvm.OpCode, gas, cost uint64, scope *ethvm.ScopeContext, rData []byte, depth int, err error) {
+	if atomic.LoadUint32(&t.stopped) > 0 {
+		env.Cancel()
+		return
+	}
+	t.Tracer.CaptureState(env, pc, op, gas, cost, scope, rData, depth, err)
+}
+
+// result stops the timer and formats the output of the wrapped tracer
+func (t *tracer) result(gasUsed uint64) (interface{}, error) {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	if atomic.LoadUint32(&t.stopped) > 0 {
+		return nil, fmt.Errorf("execution aborted (timeout = %v)", t.timeout)
+	}
+
+	switch tracer := t.Tracer.(type) {
+	case *ethvm.StructLogger:
+		returnVal := fmt.Sprintf("%x", tracer.Output())
+		// the geth implementation returns the revert data as the return value
+		if tracer.Error() != nil && tracer.Error() != ethvm.ErrExecutionReverted {
+			returnVal = ""
+		}
+		return &ExecutionResult{
+			Gas:         gasUsed,
+			Failed:      tracer.Error() != nil,
+			ReturnValue: returnVal,
+			StructLogs:  FormatLogs(tracer.StructLogs()),
+		}, nil
+	case *vm.CallTracer:
+		return tracer.GetResult()
+	}
+	return nil, errors.New("unknown tracer")
+}
+
+func newBlockHeader(block *tmtypes.Block) *abci.Header {
+	return &abci.Header{
+		ChainID:         block.ChainID,
+		Height:          block.Height,
+		Time:            block.Time,
+		ProposerAddress: block.ProposerAddress,
+	}
+}
@@ -4,11 +4,9 @@ import (
 	"bytes"
 	"math/big"
 
-	"github.com/Oneledger/protocol/data/accounts"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/keys"
 	rpctypes "github.com/Oneledger/protocol/web3/types"
-	rpcutils "github.com/Oneledger/protocol/web3/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -18,25 +16,8 @@ import (
 func (svc *Service) Accounts() ([]common.Address, error) {
 	svc.logger.Debug("eth_accounts")
 
-	addresses := make([]common.Address, 0) // return [] instead of nil if empty
-
-	// NOTE: Maybe we do not need to implement this handler, but current keystore has ed25519 keys which is not
-	// supported in this api, after a new structure need to think what to do with this
-	wallet, err := accounts.NewWalletKeyStore("keystore/")
-	if err != nil {
-		return addresses, err
-	}
-
-	oltAddresses, err := wallet.ListAddresses()
-	if err != nil {
-		return addresses, err
-	}
-
-	for _, oltAddress := range oltAddresses {
-		addresses = append(addresses, common.BytesToAddress(oltAddress))
-	}
-
-	return addresses, nil
+	// NOTE: the keystore also holds ed25519 keys, their addresses can't sign the ethereum transactions
+	return svc.ctx.GetWallet().Accounts()
 }
 
 func (svc *Service) getFeePoolBalance() (*big.Int, error) {
@@ -64,27 +45,12 @@ func (svc *Service) GetBalance(address common.Address, blockNrOrHash rpc.BlockNu
 		return (*hexutil.Big)(big.NewInt(0)), nil
 	}
 
-	var (
-		blockNum       int64
-		pendingBalance = big.NewInt(0)
-	)
+	var blockNum int64
 
 	switch height {
 	case rpctypes.PendingBlockNumber:
-		blockNum = svc.getState().Version()
-		svc.logger.Debug("eth_getBalance", "height", blockNum, "pending")
-		chainID, err := svc.ChainId()
-		if err != nil {
-			return nil, err
-		}
-		rpcutils.GetPendingTxsWithCallback(svc.GetMempool(), (*big.Int)(&chainID), func(tx *rpctypes.Transaction) bool {
-			if bytes.Equal(tx.From.Bytes(), address.Bytes()) {
-				pendingBalance = new(big.Int).Sub(pendingBalance, tx.Value.ToInt())
-			} else if tx.To != nil && bytes.Equal(tx.To.Bytes(), address.Bytes()) {
-				pendingBalance = new(big.Int).Add(pendingBalance, tx.Value.ToInt())
-			}
-			return false
-		})
+		svc.logger.Debug("eth_getBalance", "height", svc.getState().Version(), "pending")
+		return svc.getPendingBalance(address)
 	case rpctypes.LatestBlockNumber:
 		blockNum = svc.getState().Version()
 		svc.logger.Debug("eth_getBalance", "height", blockNum, "latest")
@@ -115,7 +81,23 @@ func (svc *Service) GetBalance(address common.Address, blockNrOrHash rpc.BlockNu
 		}
 	}
 
-	// involve pending balance
-	total := new(big.Int).Add(balance, pendingBalance)
-	return (*hexutil.Big)(total), nil
+	return (*hexutil.Big)(balance), nil
+}
+
+// getPendingBalance returns the balance of the address once the mempool transactions are applied
+func (svc *Service) getPendingBalance(address common.Address) (*hexutil.Big, error) {
+	actionCtx, err := svc.pendingContext()
+	if err != nil {
+		return nil, err
+	}
+	balance := new(big.Int).Set(actionCtx.StateDB.GetBalance(address))
+
+	// zero address pool
+	if address == (common.Address{}) {
+		coin, err := actionCtx.FeePool.Get(keys.Address(fees.POOL_KEY))
+		if err == nil && coin.Amount != nil {
+			balance.Add(balance, coin.Amount.BigInt())
+		}
+	}
+	return (*hexutil.Big)(balance), nil
 }
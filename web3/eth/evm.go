@@ -7,12 +7,14 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/olvm"
 	"github.com/Oneledger/protocol/data/evm"
 	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
 	"github.com/Oneledger/protocol/utils"
 	"github.com/Oneledger/protocol/vm"
 	rpctypes "github.com/Oneledger/protocol/web3/types"
-	rpcutils "github.com/Oneledger/protocol/web3/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -20,6 +22,7 @@ import (
 	ethvm "github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/rpc"
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 func (svc *Service) GetStorageAt(address common.Address, key string, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
@@ -194,32 +197,43 @@ func (svc *Service) EstimateGas(call rpctypes.CallArgs) (hexutil.Uint64, error)
 	return hexutil.Uint64(hi), nil
 }
 
-func (svc *Service) callContract(call rpctypes.CallArgs, height int64) (*vm.ExecutionResult, error) {
-	var (
-		blockNum  uint64
-		isPending bool
-	)
+// callContext returns the action context the calls run on, the pending one has the mempool transactions applied
+func (svc *Service) callContext(height int64) (*action.Context, error) {
+	if height == rpctypes.PendingBlockNumber {
+		return svc.pendingContext()
+	}
+
+	var blockNum int64
 	switch height {
 	case rpctypes.EarliestBlockNumber:
 		blockNum = rpctypes.InitialBlockNumber
 	default:
-		blockNum = uint64(svc.getState().Version())
-		isPending = true
+		blockNum = svc.getState().Version()
 	}
 
-	block := svc.GetBlockStore().LoadBlock(int64(blockNum))
+	block := svc.GetBlockStore().LoadBlock(blockNum)
 	if block == nil {
 		return nil, errors.New("failed to get block")
 	}
-	stateDB := svc.GetStateDB()
-	stateDB.SetBlockHash(common.BytesToHash(block.Hash()))
-
 	header := &abci.Header{
 		ChainID: block.ChainID,
 		Height:  block.Height,
 		Time:    block.Time,
 	}
 
+	actionCtx := svc.ctx.GetActionContext(header, storage.NewState(svc.ctx.GetChainState()))
+	actionCtx.StateDB.SetBlockHash(common.BytesToHash(block.Hash()))
+	return actionCtx, nil
+}
+
+func (svc *Service) callContract(call rpctypes.CallArgs, height int64) (*vm.ExecutionResult, error) {
+	actionCtx, err := svc.callContext(height)
+	if err != nil {
+		return nil, err
+	}
+	header := actionCtx.Header
+	stateDB := actionCtx.StateDB
+
 	from := keys.Address(call.From.Bytes())
 
 	var to *keys.Address
@@ -249,7 +263,7 @@ func (svc *Service) callContract(call rpctypes.CallArgs, height int64) (*vm.Exec
 		data = []byte(call.Data)
 	}
 
-	nonce := svc.getNonce(common.BytesToAddress(from.Bytes()), blockNum, utils.HashToBigInt(block.ChainID), isPending)
+	nonce := stateDB.GetNonce(common.BytesToAddress(from.Bytes()))
 
 	svc.logger.Debug("eth_callContract", "from", from, "to", to, "gasPrice", gasPrice, "gas", gas, "value", value, "data", data, "nonce", nonce)
 
@@ -258,6 +272,7 @@ func (svc *Service) callContract(call rpctypes.CallArgs, height int64) (*vm.Exec
 	fromAcc.SetBalance(math.MaxBig256)
 
 	evmTx := vm.NewEVMTransaction(stateDB, new(ethcore.GasPool).AddGas(math.MaxUint64), header, from, to, nonce, value, data, nil, gas, gasPrice, true)
+	evmTx.SetNativeContracts(olvm.NativeContracts(actionCtx))
 
 	timeout := 10 * time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
@@ -278,17 +293,12 @@ func (svc *Service) callContract(call rpctypes.CallArgs, height int64) (*vm.Exec
 	return result, err
 }
 
-func (svc *Service) getNonce(address common.Address, height uint64, chainID *big.Int, isPending bool) uint64 {
-	var (
-		nonce        uint64
-		pendingNonce uint64
-	)
+// getNonce returns the nonce of the next transaction of the address, counting the ones waiting in the mempool
+// when pending
+func (svc *Service) getNonce(address common.Address, height uint64, isPending bool) uint64 {
+	var pending tmtypes.Txs
 	if isPending {
-		pendingNonce = rpcutils.GetPendingTxCountByAddress(svc.GetMempool(), address)
-	}
-	ethAcc, err := svc.ctx.GetAccountKeeper().GetVersionedAccount(address.Bytes(), int64(height))
-	if err == nil {
-		nonce = ethAcc.Sequence
+		pending = svc.GetMempool().ReapMaxTxs(-1)
 	}
-	return nonce + pendingNonce
+	return action.PendingNonce(svc.ctx.GetAccountKeeper(), address.Bytes(), int64(height), pending)
 }
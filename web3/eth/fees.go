@@ -0,0 +1,182 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	tmrpccore "github.com/tendermint/tendermint/rpc/core"
+
+	"github.com/Oneledger/protocol/data/fees"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+)
+
+const (
+	// the suggested priority fee is the maxPriorityFeePercentile of the rewards paid by the transactions of the
+	// last maxPriorityFeeBlocks blocks
+	maxPriorityFeeBlocks     = 20
+	maxPriorityFeePercentile = 60
+)
+
+// FeeHistory returns the base fee, the gas usage and the rewards at the given percentiles of the blockCount blocks
+// up to lastBlock, the base fee of the block after lastBlock is appended. The fee market keeps the history of the
+// last fees.FEE_HISTORY_LEN blocks, the blocks it didn't record report the minimum fee of the fee options.
+func (svc *Service) FeeHistory(blockCount rpc.DecimalOrHex, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error) {
+	svc.logger.Debug("eth_feeHistory", "blockCount", blockCount, "lastBlock", lastBlock, "rewardPercentiles", rewardPercentiles)
+
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid reward percentile: %f", p)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return nil, fmt.Errorf("invalid reward percentile: #%d:%f > #%d:%f", i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+
+	latest := svc.getState().Version()
+	last := svc.getStateHeight(lastBlock.Int64())
+	if last > latest {
+		return nil, errors.New("requested block is in the future")
+	}
+	count := int64(blockCount)
+	if count > fees.FEE_HISTORY_LEN {
+		count = fees.FEE_HISTORY_LEN
+	}
+	oldest := last - count + 1
+	if oldest < rpctypes.InitialBlockNumber {
+		oldest = rpctypes.InitialBlockNumber
+	}
+
+	feePool := svc.ctx.GetFeePool()
+	result := &rpctypes.FeeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(big.NewInt(oldest)),
+		BaseFee:      make([]*hexutil.Big, 0, last-oldest+2),
+		GasUsedRatio: make([]float64, 0, last-oldest+1),
+	}
+	if len(rewardPercentiles) != 0 {
+		result.Reward = make([][]*hexutil.Big, 0, last-oldest+1)
+	}
+	if count == 0 {
+		return result, nil
+	}
+
+	maxGas := svc.blockMaxGas()
+	for height := oldest; height <= last; height++ {
+		record, err := svc.getBlockFee(height, maxGas)
+		if err != nil {
+			return nil, err
+		}
+		if len(rewardPercentiles) != 0 {
+			rewards := record.Rewards(rewardPercentiles)
+			blockRewards := make([]*hexutil.Big, len(rewards))
+			for i, reward := range rewards {
+				blockRewards[i] = (*hexutil.Big)(reward)
+			}
+			result.Reward = append(result.Reward, blockRewards)
+		}
+		ratio := float64(0)
+		if record.GasLimit > 0 {
+			ratio = float64(record.GasUsed) / float64(record.GasLimit)
+		}
+		result.BaseFee = append(result.BaseFee, (*hexutil.Big)(record.BaseFee.BigInt()))
+		result.GasUsedRatio = append(result.GasUsedRatio, ratio)
+	}
+
+	// the base fee of the next block
+	next := feePool.MinFee().Amount.BigInt()
+	if last < latest {
+		record, err := feePool.GetBlockFee(last + 1)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			next = record.BaseFee.BigInt()
+		}
+	}
+	result.BaseFee = append(result.BaseFee, (*hexutil.Big)(next))
+	return result, nil
+}
+
+// MaxPriorityFeePerGas returns the priority fee a transaction should pay above the base fee to be included in time,
+// the blocks without transactions are left out, zero if none of the recent blocks has any
+func (svc *Service) MaxPriorityFeePerGas() (*hexutil.Big, error) {
+	svc.logger.Debug("eth_maxPriorityFeePerGas")
+
+	last := svc.getState().Version()
+	oldest := last - maxPriorityFeeBlocks + 1
+	if oldest < rpctypes.InitialBlockNumber {
+		oldest = rpctypes.InitialBlockNumber
+	}
+
+	maxGas := svc.blockMaxGas()
+	rewards := make([]*big.Int, 0, maxPriorityFeeBlocks)
+	for height := oldest; height <= last; height++ {
+		record, err := svc.getBlockFee(height, maxGas)
+		if err != nil {
+			return nil, err
+		}
+		if len(record.GasPrices) == 0 {
+			continue
+		}
+		rewards = append(rewards, record.Rewards([]float64{maxPriorityFeePercentile})[0])
+	}
+	if len(rewards) == 0 {
+		return (*hexutil.Big)(new(big.Int)), nil
+	}
+	sort.Slice(rewards, func(i, j int) bool {
+		return rewards[i].Cmp(rewards[j]) < 0
+	})
+	return (*hexutil.Big)(rewards[(len(rewards)-1)*maxPriorityFeePercentile/100]), nil
+}
+
+// getBlockFee returns the gas usage of the block recorded by the fee market, it is computed from the block for the
+// blocks it didn't record
+func (svc *Service) getBlockFee(height int64, maxGas int64) (*fees.BlockFee, error) {
+	record, err := svc.ctx.GetFeePool().GetBlockFee(height)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return svc.blockFee(height, maxGas)
+	}
+	return record, nil
+}
+
+// blockFee sums the gas used and the gas prices paid by the transactions of a block the fee market didn't record
+func (svc *Service) blockFee(height int64, maxGas int64) (*fees.BlockFee, error) {
+	results, err := tmrpccore.BlockResults(nil, &height)
+	if err != nil {
+		return nil, err
+	}
+	record := &fees.BlockFee{
+		Height:   height,
+		GasLimit: maxGas,
+		BaseFee:  *svc.ctx.GetFeePool().GetOpt().MinFee().Amount,
+	}
+	block := svc.GetBlockStore().LoadBlock(height)
+	for i, txResult := range results.TxsResults {
+		record.GasUsed += txResult.GasUsed
+		if block == nil || i >= len(block.Txs) || txResult.Code != 0 || txResult.GasUsed <= 0 {
+			continue
+		}
+		tx, err := rpctypes.ParseLegacyTx(block.Txs[i])
+		if err != nil {
+			continue
+		}
+		record.GasPrices = fees.AddGasPrice(record.GasPrices, tx.Fee.Price.Value, txResult.GasUsed)
+	}
+	return record, nil
+}
+
+// blockMaxGas returns the gas limit of the blocks set by the genesis, -1 when they are unlimited and 0 when the
+// genesis has no consensus params
+func (svc *Service) blockMaxGas() int64 {
+	genesis := svc.ctx.GetGenesisDoc()
+	if genesis == nil || genesis.ConsensusParams == nil {
+		return 0
+	}
+	return genesis.ConsensusParams.Block.MaxGas
+}
@@ -71,6 +71,6 @@ func (svc *Service) Hashrate() hexutil.Uint64 {
 // GasPrice returns the current gas price
 func (svc *Service) GasPrice() *hexutil.Big {
 	svc.logger.Debug("eth_gasPrice")
-	out := svc.ctx.GetFeePool().GetOpt().MinFee().Amount.BigInt()
+	out := svc.ctx.GetFeePool().MinFee().Amount.BigInt()
 	return (*hexutil.Big)(out)
 }
@@ -0,0 +1,66 @@
+package eth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/storage"
+	rpcutils "github.com/Oneledger/protocol/web3/utils"
+	"github.com/ethereum/go-ethereum/common"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// maxPendingTxs caps the mempool transactions delivered for a pending request, the ones fitting in the next block
+// are delivered up to it
+const maxPendingTxs = 1000
+
+// pendingContext returns an action context over a scratch copy of the latest state with the mempool transactions
+// delivered on top of it in their mempool order, as the next block would, nothing is written back to the chain state.
+// Only the transactions fitting in the next block are delivered, at most maxPendingTxs.
+func (svc *Service) pendingContext() (*action.Context, error) {
+	height := svc.getState().Version()
+	block := svc.GetBlockStore().LoadBlock(height)
+	if block == nil {
+		return nil, errors.New("failed to get block")
+	}
+	header := &abci.Header{
+		ChainID:         block.ChainID,
+		Height:          height + 1,
+		Time:            time.Now().UTC(),
+		ProposerAddress: block.ProposerAddress,
+	}
+
+	// the transactions are charged on a gas store sharing the cache of the state, the returned context reads their
+	// changes without running out of the block gas
+	state := storage.NewState(svc.ctx.GetChainState())
+	deliverState := state.WithGas(rpcutils.NewBlockGasCalculator(svc.ctx.GetGenesisDoc(), state))
+	deliverCtx := svc.ctx.GetActionContext(header, deliverState)
+	deliverCtx.StateDB.SetBlockHash(common.BytesToHash(block.Hash()))
+
+	for _, rawTx := range svc.pendingTxs() {
+		_, err := rpcutils.DeliverTx(deliverCtx, rawTx, nil)
+		if err != nil {
+			svc.logger.Debug("pending state", "skipped tx", common.BytesToHash(rawTx.Hash()), "err", err)
+		}
+	}
+
+	ctx := svc.ctx.GetActionContext(header, state)
+	ctx.StateDB.SetBlockHash(common.BytesToHash(block.Hash()))
+	return ctx, nil
+}
+
+// pendingTxs reaps the mempool transactions fitting in the next block by the block max bytes and gas of the genesis
+func (svc *Service) pendingTxs() tmtypes.Txs {
+	maxBytes, maxGas := int64(-1), int64(-1)
+	if genesis := svc.ctx.GetGenesisDoc(); genesis != nil && genesis.ConsensusParams != nil {
+		maxBytes = genesis.ConsensusParams.Block.MaxBytes
+		maxGas = genesis.ConsensusParams.Block.MaxGas
+	}
+	txs := svc.GetMempool().ReapMaxBytesMaxGas(maxBytes, maxGas)
+	if len(txs) > maxPendingTxs {
+		txs = txs[:maxPendingTxs]
+	}
+	return txs
+}
@@ -0,0 +1,145 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	"github.com/Oneledger/protocol/web3/wallet"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+// Sign signs the data with the unlocked key of the address, the data is prefixed as an ethereum message
+func (svc *Service) Sign(ctx context.Context, address common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	svc.logger.Debug("eth_sign", "address", address)
+	if err := wallet.Authorize(ctx, svc.ctx.GetConfig()); err != nil {
+		return nil, err
+	}
+	return SignText(svc.ctx.GetWallet(), address, "", data)
+}
+
+// SignTypedData_v4 signs the EIP-712 typed data with the unlocked key of the address
+func (svc *Service) SignTypedData_v4(ctx context.Context, address common.Address, typedData core.TypedData) (hexutil.Bytes, error) {
+	svc.logger.Debug("eth_signTypedData_v4", "address", address)
+	if err := wallet.Authorize(ctx, svc.ctx.GetConfig()); err != nil {
+		return nil, err
+	}
+
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, err
+	}
+	typedDataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, err
+	}
+	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash)))
+
+	signature, err := svc.ctx.GetWallet().SignHash(address, "", crypto.Keccak256(rawData))
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+// SendTransaction signs the transaction with the unlocked key of the sender and submits it, the missing nonce, gas
+// and fees are filled in
+func (svc *Service) SendTransaction(ctx context.Context, args rpctypes.SendTxArgs) (common.Hash, error) {
+	svc.logger.Debug("eth_sendTransaction", "from", args.From)
+	if err := wallet.Authorize(ctx, svc.ctx.GetConfig()); err != nil {
+		return common.Hash{}, err
+	}
+	return SignAndSendTransaction(svc, args, "")
+}
+
+// SignText signs the data prefixed as an ethereum message, the passphrase is only needed when the account is locked
+func SignText(w *wallet.Wallet, address common.Address, passphrase string, data []byte) (hexutil.Bytes, error) {
+	signature, err := w.SignHash(address, passphrase, accounts.TextHash(data))
+	if err != nil {
+		return nil, err
+	}
+	// transform the V from 0/1 to 27/28 as the ethereum wallets
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+// SignAndSendTransaction fills in the defaults of the args, signs the transaction with the key of the sender and
+// submits it, the passphrase is only needed when the account is locked
+func SignAndSendTransaction(svc *Service, args rpctypes.SendTxArgs, passphrase string) (common.Hash, error) {
+	chainID, err := svc.ChainId()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := svc.setTxDefaults(&args); err != nil {
+		return common.Hash{}, err
+	}
+
+	tx, err := svc.ctx.GetWallet().SignTx(args.From, passphrase, args.ToTransaction(chainID.ToInt()), chainID.ToInt())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return svc.submitTransaction(tx)
+}
+
+// setTxDefaults fills in the nonce, fees and gas of the transaction which are not set
+func (svc *Service) setTxDefaults(args *rpctypes.SendTxArgs) error {
+	if args.Data != nil && args.Input != nil && !bytes.Equal(*args.Data, *args.Input) {
+		return errors.New(`both "data" and "input" are set and not equal. Please use "input" to pass transaction call data`)
+	}
+	if args.To == nil && len(args.GetData()) == 0 {
+		return errors.New("contract creation without any data provided")
+	}
+	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
+		return errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
+	}
+
+	if args.Value == nil {
+		args.Value = new(hexutil.Big)
+	}
+	if args.Nonce == nil {
+		nonce := hexutil.Uint64(svc.getNonce(args.From, uint64(svc.getState().Version()), true))
+		args.Nonce = &nonce
+	}
+
+	if args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil {
+		if args.MaxPriorityFeePerGas == nil {
+			args.MaxPriorityFeePerGas = new(hexutil.Big)
+		}
+		if args.MaxFeePerGas == nil {
+			baseFee := svc.GasPrice().ToInt()
+			args.MaxFeePerGas = (*hexutil.Big)(new(big.Int).Add(baseFee, args.MaxPriorityFeePerGas.ToInt()))
+		}
+		if args.MaxFeePerGas.ToInt().Cmp(args.MaxPriorityFeePerGas.ToInt()) < 0 {
+			return fmt.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", args.MaxFeePerGas, args.MaxPriorityFeePerGas)
+		}
+	} else if args.GasPrice == nil {
+		args.GasPrice = svc.GasPrice()
+	}
+
+	if args.Gas == nil {
+		gasPrice := args.GasPrice
+		if gasPrice == nil {
+			gasPrice = args.MaxFeePerGas
+		}
+		gas, err := svc.EstimateGas(rpctypes.CallArgs{
+			From:     args.From,
+			To:       args.To,
+			GasPrice: gasPrice,
+			Value:    args.Value,
+			Data:     args.GetData(),
+		})
+		if err != nil {
+			return err
+		}
+		args.Gas = &gas
+	}
+	return nil
+}
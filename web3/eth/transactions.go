@@ -39,6 +39,16 @@ func (svc *Service) GetTransactionCount(address common.Address, blockNrOrHash rp
 
 	svc.logger.Debug("eth_getTransactionCount", "address", address, "height", height)
 
+	// for pending
+	if height == rpctypes.PendingBlockNumber {
+		actionCtx, err := svc.pendingContext()
+		if err != nil {
+			return nil, err
+		}
+		n := hexutil.Uint64(actionCtx.StateDB.GetNonce(address))
+		return &n, nil
+	}
+
 	// getting actual block
 	blockNum := svc.getStateHeight(height)
 
@@ -47,11 +57,6 @@ func (svc *Service) GetTransactionCount(address common.Address, blockNrOrHash rp
 	if err == nil {
 		txLen = ethAcc.Sequence
 	}
-
-	// for pending
-	if height == rpctypes.PendingBlockNumber {
-		txLen += rpcutils.GetPendingTxCountByAddress(svc.GetMempool(), address)
-	}
 	n := hexutil.Uint64(txLen)
 	return &n, nil
 }
@@ -147,6 +152,8 @@ func (svc *Service) GetTransactionReceipt(hash common.Hash) (*rpctypes.Transacti
 		TransactionIndex:  *oneTx.TransactionIndex,
 		From:              oneTx.From,
 		To:                oneTx.To,
+		Type:              oneTx.Type,
+		EffectiveGasPrice: oneTx.GasPrice,
 	}
 
 	return receipt, nil
@@ -243,17 +250,13 @@ func (svc *Service) submitTransaction(tx *ethtypes.Transaction) (common.Hash, er
 		return common.Hash{}, err
 	}
 
-	if tx.Type() != ethtypes.LegacyTxType {
-		return common.Hash{}, errors.New("only legacy transactions allowed over RPC")
-	}
-
 	if !tx.Protected() {
 		// Ensure only eip155 signed transactions are submitted if EIP155Required is set.
 		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
 	}
 
 	// Print a log with full tx details for manual investigations and interventions
-	signer := ethtypes.NewEIP155Signer(tx.ChainId())
+	signer := ethtypes.NewLondonSigner(tx.ChainId())
 	from, err := ethtypes.Sender(signer, tx)
 	if err != nil {
 		return common.Hash{}, err
@@ -286,7 +289,8 @@ func (svc *Service) submitTransaction(tx *ethtypes.Transaction) (common.Hash, er
 func (svc *Service) sendTx(ethTx *ethtypes.Transaction) (common.Hash, error) {
 	config := svc.ctx.GetConfig()
 
-	signedTx, err := rpcutils.EthToOLSignedTx(ethTx)
+	baseFee := svc.ctx.GetFeePool().MinFee().Amount.BigInt()
+	signedTx, err := rpcutils.EthToOLSignedTx(ethTx, baseFee)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -0,0 +1,99 @@
+package personal
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/web3/eth"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	"github.com/Oneledger/protocol/web3/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ rpctypes.Web3Service = (*Service)(nil)
+
+// Service manages the accounts of the node keystore, all the methods require the owner token
+type Service struct {
+	ctx    rpctypes.Web3Context
+	eth    *eth.Service
+	logger *log.Logger
+}
+
+func NewService(ctx rpctypes.Web3Context, ethService *eth.Service) *Service {
+	return &Service{ctx: ctx, eth: ethService, logger: log.NewLoggerWithPrefix(os.Stdout, "personal")}
+}
+
+func (svc *Service) authorize(ctx context.Context) error {
+	return wallet.Authorize(ctx, svc.ctx.GetConfig())
+}
+
+// ListAccounts returns the addresses of the keystore
+func (svc *Service) ListAccounts(ctx context.Context) ([]common.Address, error) {
+	svc.logger.Debug("personal_listAccounts")
+	if err := svc.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return svc.ctx.GetWallet().Accounts()
+}
+
+// NewAccount creates an ethereum key in the keystore encrypted with the passphrase
+func (svc *Service) NewAccount(ctx context.Context, passphrase string) (common.Address, error) {
+	svc.logger.Debug("personal_newAccount")
+	if err := svc.authorize(ctx); err != nil {
+		return common.Address{}, err
+	}
+	address, err := svc.ctx.GetWallet().NewAccount(passphrase)
+	if err != nil {
+		return common.Address{}, err
+	}
+	svc.logger.Info("Created keystore account", "address", address)
+	return address, nil
+}
+
+// UnlockAccount keeps the key of the address unlocked for the duration in seconds, 300 by default and until locked
+// for 0
+func (svc *Service) UnlockAccount(ctx context.Context, address common.Address, passphrase string, duration *uint64) (bool, error) {
+	svc.logger.Debug("personal_unlockAccount", "address", address)
+	if err := svc.authorize(ctx); err != nil {
+		return false, err
+	}
+	d := wallet.DefaultUnlockDuration
+	if duration != nil {
+		d = time.Duration(*duration) * time.Second
+	}
+	if err := svc.ctx.GetWallet().Unlock(address, passphrase, d); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// LockAccount locks the key of the address
+func (svc *Service) LockAccount(ctx context.Context, address common.Address) (bool, error) {
+	svc.logger.Debug("personal_lockAccount", "address", address)
+	if err := svc.authorize(ctx); err != nil {
+		return false, err
+	}
+	svc.ctx.GetWallet().Lock(address)
+	return true, nil
+}
+
+// Sign signs the data prefixed as an ethereum message with the key of the address decrypted with the passphrase
+func (svc *Service) Sign(ctx context.Context, data hexutil.Bytes, address common.Address, passphrase string) (hexutil.Bytes, error) {
+	svc.logger.Debug("personal_sign", "address", address)
+	if err := svc.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return eth.SignText(svc.ctx.GetWallet(), address, passphrase, data)
+}
+
+// SendTransaction signs the transaction with the key of the sender decrypted with the passphrase and submits it
+func (svc *Service) SendTransaction(ctx context.Context, args rpctypes.SendTxArgs, passphrase string) (common.Hash, error) {
+	svc.logger.Debug("personal_sendTransaction", "from", args.From)
+	if err := svc.authorize(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	return eth.SignAndSendTransaction(svc.eth, args, passphrase)
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/log"
+	protocolrpc "github.com/Oneledger/protocol/rpc"
 	rpctypes "github.com/Oneledger/protocol/web3/types"
+	"github.com/Oneledger/protocol/web3/wallet"
 
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -37,6 +39,18 @@ func RegisterApis(src *rpc.Server, apis map[string]rpctypes.Web3Service) error {
 	return nil
 }
 
+// ownerHandler marks the requests carrying a valid owner token, only them can use the node keys when the rpc auth
+// is configured
+func (s *Server) ownerHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("Authorization")
+		if token != "" && s.cfg.Node.Auth.RPCPrivateKey != "" && protocolrpc.VerifyToken(s.cfg, token) == nil {
+			r = r.WithContext(wallet.WithOwner(r.Context()))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (s *Server) start(rpcInfo interface{}, apis map[string]rpctypes.Web3Service) error {
 	var (
 		err               error
@@ -59,7 +73,7 @@ func (s *Server) start(rpcInfo interface{}, apis map[string]rpctypes.Web3Service
 		enabled = rpcCfg.Enabled
 		availableAPINames = rpcCfg.API
 		keepAlive = rpcCfg.KeepAlive
-		handler = node.NewHTTPHandlerStack(rpcSrv, s.cfg.API.HTTPConfig.CORSDomain, s.cfg.API.HTTPConfig.VHosts)
+		handler = s.ownerHandler(node.NewHTTPHandlerStack(rpcSrv, s.cfg.API.HTTPConfig.CORSDomain, s.cfg.API.HTTPConfig.VHosts))
 	case *config.WSConfig:
 		name = "WS"
 		uri = fmt.Sprintf("%s:%d", rpcCfg.Addr, rpcCfg.Port)
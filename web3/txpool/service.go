@@ -0,0 +1,152 @@
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/utils"
+	rpctypes "github.com/Oneledger/protocol/web3/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ rpctypes.Web3Service = (*Service)(nil)
+
+type Service struct {
+	ctx    rpctypes.Web3Context
+	logger *log.Logger
+}
+
+func NewService(ctx rpctypes.Web3Context) *Service {
+	return &Service{ctx: ctx, logger: log.NewLoggerWithPrefix(os.Stdout, "txpool")}
+}
+
+// Content returns the pending and queued transactions of the mempool grouped by sender and nonce
+func (svc *Service) Content() (map[string]map[string]map[string]*rpctypes.Transaction, error) {
+	svc.logger.Debug("txpool_content")
+
+	pending, queued, err := svc.pool()
+	if err != nil {
+		return nil, err
+	}
+	content := map[string]map[string]map[string]*rpctypes.Transaction{
+		"pending": make(map[string]map[string]*rpctypes.Transaction),
+		"queued":  make(map[string]map[string]*rpctypes.Transaction),
+	}
+	group := func(txs map[common.Address][]*rpctypes.Transaction, dump map[string]map[string]*rpctypes.Transaction) {
+		for sender, senderTxs := range txs {
+			byNonce := make(map[string]*rpctypes.Transaction, len(senderTxs))
+			for _, tx := range senderTxs {
+				byNonce[fmt.Sprintf("%d", tx.Nonce)] = tx
+			}
+			dump[sender.Hex()] = byNonce
+		}
+	}
+	group(pending, content["pending"])
+	group(queued, content["queued"])
+	return content, nil
+}
+
+// Status returns the number of pending and queued transactions of the mempool
+func (svc *Service) Status() (map[string]hexutil.Uint, error) {
+	svc.logger.Debug("txpool_status")
+
+	pending, queued, err := svc.pool()
+	if err != nil {
+		return nil, err
+	}
+	count := func(txs map[common.Address][]*rpctypes.Transaction) (total hexutil.Uint) {
+		for _, senderTxs := range txs {
+			total += hexutil.Uint(len(senderTxs))
+		}
+		return
+	}
+	return map[string]hexutil.Uint{
+		"pending": count(pending),
+		"queued":  count(queued),
+	}, nil
+}
+
+// Inspect returns a summary of the pending and queued transactions of the mempool grouped by sender and nonce
+func (svc *Service) Inspect() (map[string]map[string]map[string]string, error) {
+	svc.logger.Debug("txpool_inspect")
+
+	pending, queued, err := svc.pool()
+	if err != nil {
+		return nil, err
+	}
+	content := map[string]map[string]map[string]string{
+		"pending": make(map[string]map[string]string),
+		"queued":  make(map[string]map[string]string),
+	}
+	format := func(tx *rpctypes.Transaction) string {
+		if tx.To != nil {
+			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To.Hex(), tx.Value.ToInt(), uint64(tx.Gas), tx.GasPrice.ToInt())
+		}
+		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value.ToInt(), uint64(tx.Gas), tx.GasPrice.ToInt())
+	}
+	group := func(txs map[common.Address][]*rpctypes.Transaction, dump map[string]map[string]string) {
+		for sender, senderTxs := range txs {
+			byNonce := make(map[string]string, len(senderTxs))
+			for _, tx := range senderTxs {
+				byNonce[fmt.Sprintf("%d", tx.Nonce)] = format(tx)
+			}
+			dump[sender.Hex()] = byNonce
+		}
+	}
+	group(pending, content["pending"])
+	group(queued, content["queued"])
+	return content, nil
+}
+
+// pool decodes the native and OLVM transactions of the mempool and splits them by sender, the transactions
+// following the nonce of the sender account are pending, the ones after a nonce gap are queued
+func (svc *Service) pool() (pending, queued map[common.Address][]*rpctypes.Transaction, err error) {
+	genesis := svc.ctx.GetGenesisDoc()
+	if genesis == nil {
+		return nil, nil, errors.New("genesis not found")
+	}
+	chainID := utils.HashToBigInt(genesis.ChainID)
+
+	bySender := make(map[common.Address][]*rpctypes.Transaction)
+	for _, rawTx := range svc.ctx.GetMempool().ReapMaxTxs(-1) {
+		tx, err := rpctypes.LegacyRawBlockAndTxToEthTx(nil, &rawTx, chainID, nil)
+		if err != nil {
+			svc.logger.Debug("txpool", "failed to decode tx", common.BytesToHash(rawTx.Hash()), "err", err)
+			continue
+		}
+		bySender[tx.From] = append(bySender[tx.From], tx)
+	}
+
+	pending = make(map[common.Address][]*rpctypes.Transaction)
+	queued = make(map[common.Address][]*rpctypes.Transaction)
+
+	height := svc.ctx.GetChainState().Version
+	keeper := svc.ctx.GetAccountKeeper()
+	for sender, txs := range bySender {
+		sort.SliceStable(txs, func(i, j int) bool {
+			return txs[i].Nonce < txs[j].Nonce
+		})
+
+		var next uint64
+		acc, err := keeper.GetVersionedAccount(sender.Bytes(), height)
+		if err == nil {
+			next = acc.Sequence
+		}
+		for _, tx := range txs {
+			nonce := uint64(tx.Nonce)
+			if nonce > next {
+				queued[sender] = append(queued[sender], tx)
+				continue
+			}
+			if nonce == next {
+				next++
+			}
+			pending[sender] = append(pending[sender], tx)
+		}
+	}
+	return pending, queued, nil
+}
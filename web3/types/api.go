@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/app/node"
 	"github.com/Oneledger/protocol/config"
 	"github.com/Oneledger/protocol/consensus"
@@ -8,7 +9,10 @@ import (
 	"github.com/Oneledger/protocol/data/evm"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
 	"github.com/Oneledger/protocol/vm"
+	"github.com/Oneledger/protocol/web3/wallet"
+	abci "github.com/tendermint/tendermint/abci/types"
 	cs "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
@@ -25,6 +29,9 @@ type EthService interface {
 	GetStateDB() *vm.CommitStateDB
 }
 
+// ActionContextBuilder creates an action context on top of the given state which is not shared with the block processing
+type ActionContextBuilder func(header *abci.Header, state *storage.State) *action.Context
+
 // Web3Context interface to define required elements for the API Context
 type Web3Context interface {
 	// propagation structures
@@ -41,6 +48,9 @@ type Web3Context interface {
 	GetFeePool() *fees.Store
 	GetNodeContext() *node.Context
 	GetConfig() *config.Server
+	GetChainState() *storage.ChainState
+	GetActionContext(header *abci.Header, state *storage.State) *action.Context
+	GetWallet() *wallet.Wallet
 
 	// service registry
 	RegisterService(name string, srv Web3Service)
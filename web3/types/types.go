@@ -27,6 +27,74 @@ type CallArgs struct {
 	Data     hexutil.Bytes   `json:"data"`
 }
 
+// SendTxArgs represents the arguments of a transaction signed by the node keys, a dynamic fee transaction is sent
+// when one of its fees is set
+type SendTxArgs struct {
+	From                 common.Address       `json:"from"`
+	To                   *common.Address      `json:"to"`
+	Gas                  *hexutil.Uint64      `json:"gas"`
+	GasPrice             *hexutil.Big         `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big         `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big         `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big         `json:"value"`
+	Nonce                *hexutil.Uint64      `json:"nonce"`
+	Data                 *hexutil.Bytes       `json:"data"`
+	Input                *hexutil.Bytes       `json:"input"`
+	AccessList           *ethtypes.AccessList `json:"accessList,omitempty"`
+}
+
+// GetData returns the input of the transaction, which can be set in either field
+func (args *SendTxArgs) GetData() []byte {
+	if args.Input != nil {
+		return *args.Input
+	}
+	if args.Data != nil {
+		return *args.Data
+	}
+	return nil
+}
+
+// ToTransaction builds the unsigned transaction once the defaults are set
+func (args *SendTxArgs) ToTransaction(chainID *big.Int) *ethtypes.Transaction {
+	var accessList ethtypes.AccessList
+	if args.AccessList != nil {
+		accessList = *args.AccessList
+	}
+	switch {
+	case args.MaxFeePerGas != nil:
+		return ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      uint64(*args.Nonce),
+			GasTipCap:  (*big.Int)(args.MaxPriorityFeePerGas),
+			GasFeeCap:  (*big.Int)(args.MaxFeePerGas),
+			Gas:        uint64(*args.Gas),
+			To:         args.To,
+			Value:      (*big.Int)(args.Value),
+			Data:       args.GetData(),
+			AccessList: accessList,
+		})
+	case args.AccessList != nil:
+		return ethtypes.NewTx(&ethtypes.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      uint64(*args.Nonce),
+			GasPrice:   (*big.Int)(args.GasPrice),
+			Gas:        uint64(*args.Gas),
+			To:         args.To,
+			Value:      (*big.Int)(args.Value),
+			Data:       args.GetData(),
+			AccessList: accessList,
+		})
+	}
+	return ethtypes.NewTx(&ethtypes.LegacyTx{
+		Nonce:    uint64(*args.Nonce),
+		GasPrice: (*big.Int)(args.GasPrice),
+		Gas:      uint64(*args.Gas),
+		To:       args.To,
+		Value:    (*big.Int)(args.Value),
+		Data:     args.GetData(),
+	})
+}
+
 // Header represents a block header in the Ethereum blockchain.
 type Header struct {
 	ParentHash  common.Hash         `json:"parentHash"       gencodec:"required"`
@@ -88,6 +156,13 @@ type Transaction struct {
 	V                *hexutil.Big    `json:"v"`
 	R                *common.Hash    `json:"r"`
 	S                *common.Hash    `json:"s"`
+
+	// typed transaction fields
+	Type      hexutil.Uint64       `json:"type"`
+	Accesses  *ethtypes.AccessList `json:"accessList,omitempty"`
+	ChainID   *hexutil.Big         `json:"chainId,omitempty"`
+	GasFeeCap *hexutil.Big         `json:"maxFeePerGas,omitempty"`
+	GasTipCap *hexutil.Big         `json:"maxPriorityFeePerGas,omitempty"`
 }
 
 // TransactionReceipt represents a mined transaction returned to RPC clients.
@@ -113,4 +188,16 @@ type TransactionReceipt struct {
 	// sender and receiver (contract or EOA) addresses
 	From common.Address  `json:"from"`
 	To   *common.Address `json:"to"`
+
+	// the transaction type and the gas price it paid
+	Type              hexutil.Uint64 `json:"type"`
+	EffectiveGasPrice *hexutil.Big   `json:"effectiveGasPrice"`
+}
+
+// FeeHistoryResult is the base fee and gas usage of a range of blocks, returned by eth_feeHistory
+type FeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
 }
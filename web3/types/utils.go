@@ -133,27 +133,30 @@ func LegacyRawBlockAndTxToEthTx(tmBlock *tmtypes.Block, tmTx *tmtypes.Tx, chainI
 	if len(lTx.Signatures) > 0 {
 		actSig := lTx.Signatures[0]
 		sig := actSig.Signed
-		pubKeyHandler, err := actSig.Signer.GetHandler()
+		signer, err := actSig.Address()
 		if err != nil {
 			return nil, err
 		}
-		from = common.BytesToAddress(pubKeyHandler.Address().Bytes())
-
-		var tmpV byte
-		if len(sig) == 65 {
-			tmpV = sig[len(sig)-1:][0]
-		} else {
-			// for legacy support
-			tmpV = byte(int(sig[0]) % 2)
-		}
+		from = common.BytesToAddress(signer.Bytes())
+
+		// a multisig account signs with a bundle of signatures, there is no single signature to show
+		if len(sig) >= 64 {
+			var tmpV byte
+			if len(sig) == 65 {
+				tmpV = sig[len(sig)-1:][0]
+			} else {
+				// for legacy support
+				tmpV = byte(int(sig[0]) % 2)
+			}
 
-		r = new(common.Hash)
-		*r = common.BytesToHash(sig[:32])
+			r = new(common.Hash)
+			*r = common.BytesToHash(sig[:32])
 
-		s = new(common.Hash)
-		*s = common.BytesToHash(sig[32:64])
+			s = new(common.Hash)
+			*s = common.BytesToHash(sig[32:64])
 
-		v = new(big.Int).SetBytes([]byte{tmpV + 27})
+			v = new(big.Int).SetBytes([]byte{tmpV + 27})
+		}
 	}
 
 	err = jsonSerializer.Deserialize(lTx.Data, &unpackedData)
@@ -173,6 +176,23 @@ func LegacyRawBlockAndTxToEthTx(tmBlock *tmtypes.Block, tmTx *tmtypes.Tx, chainI
 		}
 		nonce = hexutil.Uint64(unpackedData.Nonce)
 	}
+	// the native transactions carry the nonce of the signer account outside of their data
+	if lTx.Type != action.OLVM {
+		nonce = hexutil.Uint64(lTx.Nonce)
+	}
+
+	// the typed fields are set by the OLVM txs only, the other txs may use the same keys
+	var typed typedTxData
+	if lTx.Type == action.OLVM {
+		err = jsonSerializer.Deserialize(lTx.Data, &typed)
+		if err != nil {
+			typed = typedTxData{}
+		}
+	}
+	if typed.TxType != ethtypes.LegacyTxType && v != nil {
+		// the typed transactions sign with the y parity only
+		v.Sub(v, big.NewInt(27))
+	}
 
 	return &Transaction{
 		BlockHash:        blockHash,
@@ -189,9 +209,30 @@ func LegacyRawBlockAndTxToEthTx(tmBlock *tmtypes.Block, tmTx *tmtypes.Tx, chainI
 		V:                (*hexutil.Big)(v),
 		R:                r,
 		S:                s,
+		Type:             hexutil.Uint64(typed.TxType),
+		Accesses:         typed.AccessList,
+		ChainID:          typed.chainID(),
+		GasFeeCap:        (*hexutil.Big)(typed.GasFeeCap),
+		GasTipCap:        (*hexutil.Big)(typed.GasTipCap),
 	}, nil
 }
 
+// typedTxData is the part of the OLVM tx data describing the typed ethereum transactions
+type typedTxData struct {
+	ChainID    *big.Int             `json:"chainID"`
+	TxType     uint64               `json:"type"`
+	AccessList *ethtypes.AccessList `json:"accessList"`
+	GasFeeCap  *big.Int             `json:"gasFeeCap"`
+	GasTipCap  *big.Int             `json:"gasTipCap"`
+}
+
+func (t typedTxData) chainID() *hexutil.Big {
+	if t.TxType == ethtypes.LegacyTxType {
+		return nil
+	}
+	return (*hexutil.Big)(t.ChainID)
+}
+
 // ParseLegacyTx is used to parse the signed tx for old OneLedger tx types
 func ParseLegacyTx(tmTx tmtypes.Tx) (*action.SignedTx, error) {
 	tx := &action.SignedTx{}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/utils"
+)
+
+// NewBlockGasCalculator returns the block gas calculator the same way the block processing creates it, with the
+// gas schedule of the state
+func NewBlockGasCalculator(genesis *tmtypes.GenesisDoc, state *storage.State) storage.GasCalculator {
+	limit := int64(0)
+	if genesis != nil && genesis.ConsensusParams != nil {
+		limit = genesis.ConsensusParams.Block.MaxGas
+	}
+	gas := storage.Gas(0)
+	if limit < 0 {
+		gas = math.MaxInt64
+	} else {
+		gas = storage.Gas(limit)
+	}
+	gasOpt, err := governance.NewStore("g", state).GetGasOptions()
+	if err != nil {
+		return storage.NewGasCalculator(gas)
+	}
+	return storage.NewGasCalculatorWithOptions(gas, *gasOpt)
+}
+
+// DeliverTx runs the transaction on the state of the context with action.DeliverTx, the way the block processing
+// does, with the tracer attached to the vm
+func DeliverTx(ctx *action.Context, rawTx tmtypes.Tx, tracer ethvm.Tracer) (action.Response, error) {
+	tx := &action.SignedTx{}
+	err := serialize.GetSerializer(serialize.NETWORK).Deserialize(rawTx, tx)
+	if err != nil {
+		return action.Response{}, fmt.Errorf("failed to deserialize transaction %X: %s", rawTx.Hash(), err)
+	}
+
+	ctx.StateDB.Prepare(common.BytesToHash(utils.GetTransactionHash(rawTx)))
+	ctx.StateDB.SetTracer(tracer)
+	defer ctx.StateDB.SetTracer(nil)
+
+	_, response, _, _, err := action.DeliverTx(ctx, tx, storage.Gas(len(rawTx)))
+	if err != nil {
+		// a transaction with a wrong nonce isn't run
+		return action.Response{Log: err.Error()}, nil
+	}
+	return response, nil
+}
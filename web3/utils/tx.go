@@ -11,31 +11,6 @@ import (
 	"github.com/tendermint/tendermint/mempool"
 )
 
-// GetPendingTxCountByAddress is used to get pending tx count (nonce) for user address
-// NOTE: Working right now only with legacy tx
-func GetPendingTxCountByAddress(mem mempool.Mempool, address common.Address) (total uint64) {
-	for _, tx := range mem.ReapMaxTxs(50) {
-		lTx, err := rpctypes.ParseLegacyTx(tx)
-		if err != nil {
-			// means tx is not legacy and we need to check is tx is ethereum
-			// TODO: Add ethereum tx check when it will be released
-			continue
-		}
-		// This is only for legacy tx
-		for _, sig := range lTx.Signatures {
-			pubKeyHandler, err := sig.Signer.GetHandler()
-			if err != nil {
-				continue
-			}
-			// match if signer is a user
-			if pubKeyHandler.Address().Equal(address.Bytes()) {
-				total++
-			}
-		}
-	}
-	return
-}
-
 // GetPendingTx search for tx in pool
 func GetPendingTx(mem mempool.Mempool, hash common.Hash, chainID *big.Int) (*rpctypes.Transaction, error) {
 	for _, uTx := range mem.ReapMaxTxs(50) {
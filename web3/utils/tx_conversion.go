@@ -17,16 +17,25 @@ import (
 var (
 	jsonSerializer = serialize.GetSerializer(serialize.NETWORK)
 	big8           = big.NewInt(8)
+	big27          = big.NewInt(27)
 )
 
-func EthToOLSignedTx(tx *ethtypes.Transaction) (*action.SignedTx, error) {
+// EthToOLSignedTx converts a signed ethereum transaction to an OLVM tx, the dynamic fee transactions pay the base
+// fee plus their tip, up to their fee cap, the fee cap when the base fee is nil
+func EthToOLSignedTx(tx *ethtypes.Transaction, baseFee *big.Int) (*action.SignedTx, error) {
 	chainId := tx.ChainId()
 
-	signer := ethtypes.NewEIP155Signer(chainId)
-	chainIdMul := new(big.Int).Mul(chainId, big.NewInt(2))
+	signer := ethtypes.NewLondonSigner(chainId)
 	V, R, S := tx.RawSignatureValues()
-	V = new(big.Int).Sub(V, chainIdMul)
-	V.Sub(V, big8)
+	if tx.Type() == ethtypes.LegacyTxType {
+		// EIP-155 encodes the chain id in V
+		chainIdMul := new(big.Int).Mul(chainId, big.NewInt(2))
+		V = new(big.Int).Sub(V, chainIdMul)
+		V.Sub(V, big8)
+	} else {
+		// the typed transactions sign with the y parity only
+		V = new(big.Int).Add(V, big27)
+	}
 
 	sighash := signer.Hash(tx)
 	pub, err := utils.RecoverPlain(sighash, R, S, V, true)
@@ -47,7 +56,7 @@ func EthToOLSignedTx(tx *ethtypes.Transaction) (*action.SignedTx, error) {
 
 	// fee setting
 	gas := int64(tx.Gas())
-	gasPrice := tx.GasPrice()
+	gasPrice := effectiveGasPrice(tx, baseFee)
 	price := action.NewAmount(action.DEFAULT_CURRENCY, balance.Amount(*gasPrice))
 	fee := action.Fee{Price: *price, Gas: gas}
 
@@ -67,6 +76,15 @@ func EthToOLSignedTx(tx *ethtypes.Transaction) (*action.SignedTx, error) {
 		Nonce:   tx.Nonce(),
 		ChainID: tx.ChainId(),
 	}
+	if tx.Type() != ethtypes.LegacyTxType {
+		accesses := tx.AccessList()
+		msg.TxType = int64(tx.Type())
+		msg.AccessList = &accesses
+	}
+	if tx.Type() == ethtypes.DynamicFeeTxType {
+		msg.GasFeeCap = tx.GasFeeCap()
+		msg.GasTipCap = tx.GasTipCap()
+	}
 	data, err := msg.Marshal()
 	if err != nil {
 		return nil, err
@@ -84,3 +102,16 @@ func EthToOLSignedTx(tx *ethtypes.Transaction) (*action.SignedTx, error) {
 	}
 	return &signedTx, nil
 }
+
+// effectiveGasPrice returns the gas price paid by the transaction, the dynamic fee transactions under the base fee
+// keep their fee cap and are rejected by the fee validation
+func effectiveGasPrice(tx *ethtypes.Transaction, baseFee *big.Int) *big.Int {
+	if tx.Type() != ethtypes.DynamicFeeTxType || baseFee == nil {
+		return tx.GasPrice()
+	}
+	tip, err := tx.EffectiveGasTip(baseFee)
+	if err != nil {
+		return tx.GasFeeCap()
+	}
+	return tip.Add(tip, baseFee)
+}
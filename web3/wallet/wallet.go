@@ -0,0 +1,234 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/accounts"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// KeyStorePath is the keystore of the node shared with the client, relative to the root directory of the node
+	KeyStorePath = "keystore/"
+
+	// DefaultUnlockDuration is used when the unlock duration is not given
+	DefaultUnlockDuration = 300 * time.Second
+)
+
+var (
+	ErrUnauthorized       = errors.New("the node keys require the owner token")
+	ErrInsecureUnlock     = errors.New("the node keys require the rpc auth, or allow_insecure_unlock on local networks")
+	ErrUnknownAccount     = errors.New("unknown account")
+	ErrLocked             = errors.New("authentication needed: password or unlock")
+	ErrInvalidPassphrase  = errors.New("could not decrypt key with given password")
+	ErrUnsupportedKeyType = errors.New("account key is not an ethereum key")
+)
+
+type ownerKey struct{}
+
+// WithOwner marks the context of a request carrying a valid owner token
+func WithOwner(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ownerKey{}, true)
+}
+
+// Authorize checks the request may use the node keys, without the rpc auth configured they can only be used when
+// the insecure unlock is allowed
+func Authorize(ctx context.Context, cfg *config.Server) error {
+	if cfg.Node.Auth.RPCPrivateKey == "" {
+		if cfg.Node.Auth.AllowInsecureUnlock {
+			return nil
+		}
+		return ErrInsecureUnlock
+	}
+	if owner, _ := ctx.Value(ownerKey{}).(bool); owner {
+		return nil
+	}
+	return ErrUnauthorized
+}
+
+// Wallet signs with the ethereum keys of the node keystore, the keys are kept in memory while unlocked
+type Wallet struct {
+	path string
+
+	mu       sync.Mutex
+	unlocked map[common.Address]*unlockedKey
+}
+
+type unlockedKey struct {
+	key   *ecdsa.PrivateKey
+	timer *time.Timer
+}
+
+func NewWallet(path string) *Wallet {
+	return &Wallet{
+		path:     path,
+		unlocked: make(map[common.Address]*unlockedKey),
+	}
+}
+
+// Accounts lists the addresses of the keystore
+func (w *Wallet) Accounts() ([]common.Address, error) {
+	addresses := make([]common.Address, 0)
+
+	store, err := accounts.NewWalletKeyStore(w.path)
+	if err != nil {
+		return addresses, err
+	}
+	oltAddresses, err := store.ListAddresses()
+	if err != nil {
+		return addresses, err
+	}
+	for _, oltAddress := range oltAddresses {
+		addresses = append(addresses, common.BytesToAddress(oltAddress))
+	}
+	return addresses, nil
+}
+
+// NewAccount generates an ethereum key and saves it to the keystore encrypted with the passphrase
+func (w *Wallet) NewAccount(passphrase string) (common.Address, error) {
+	if passphrase == "" {
+		return common.Address{}, ErrInvalidPassphrase
+	}
+	ecdsaKey, err := crypto.GenerateKey()
+	if err != nil {
+		return common.Address{}, err
+	}
+	privKey, err := keys.GetPrivateKeyFromBytes(crypto.FromECDSA(ecdsaKey), keys.ETHSECP)
+	if err != nil {
+		return common.Address{}, err
+	}
+	pubKey, err := keys.GetPublicKeyFromBytes(crypto.CompressPubkey(&ecdsaKey.PublicKey), keys.ETHSECP)
+	if err != nil {
+		return common.Address{}, err
+	}
+	address := crypto.PubkeyToAddress(ecdsaKey.PublicKey)
+	account, err := accounts.NewAccount(chain.ONELEDGER, address.Hex(), &privKey, &pubKey)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	store, err := accounts.NewWalletKeyStore(w.path)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !store.Open(keys.Address(address.Bytes()), passphrase) {
+		return common.Address{}, ErrInvalidPassphrase
+	}
+	defer store.Close()
+
+	err = store.Add(account)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return address, nil
+}
+
+// Unlock keeps the key of the account in memory for the duration, a zero duration keeps it until locked
+func (w *Wallet) Unlock(address common.Address, passphrase string, duration time.Duration) error {
+	key, err := w.decrypt(address, passphrase)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lock(address)
+	unlocked := &unlockedKey{key: key}
+	if duration > 0 {
+		unlocked.timer = time.AfterFunc(duration, func() {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			if w.unlocked[address] == unlocked {
+				delete(w.unlocked, address)
+			}
+		})
+	}
+	w.unlocked[address] = unlocked
+	return nil
+}
+
+// Lock removes the key of the account from memory
+func (w *Wallet) Lock(address common.Address) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lock(address)
+}
+
+func (w *Wallet) lock(address common.Address) {
+	if unlocked, ok := w.unlocked[address]; ok {
+		if unlocked.timer != nil {
+			unlocked.timer.Stop()
+		}
+		delete(w.unlocked, address)
+	}
+}
+
+// SignHash signs the hash with the key of the account, the passphrase is only needed when the account is locked
+func (w *Wallet) SignHash(address common.Address, passphrase string, hash []byte) ([]byte, error) {
+	key, err := w.key(address, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(hash, key)
+}
+
+// SignTx signs the transaction with the key of the account, the passphrase is only needed when the account is locked
+func (w *Wallet) SignTx(address common.Address, passphrase string, tx *ethtypes.Transaction, chainID *big.Int) (*ethtypes.Transaction, error) {
+	key, err := w.key(address, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return ethtypes.SignTx(tx, ethtypes.NewLondonSigner(chainID), key)
+}
+
+func (w *Wallet) key(address common.Address, passphrase string) (*ecdsa.PrivateKey, error) {
+	if passphrase != "" {
+		return w.decrypt(address, passphrase)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	unlocked, ok := w.unlocked[address]
+	if !ok {
+		return nil, ErrLocked
+	}
+	return unlocked.key, nil
+}
+
+// decrypt reads the key of the account from the keystore
+func (w *Wallet) decrypt(address common.Address, passphrase string) (*ecdsa.PrivateKey, error) {
+	store, err := accounts.NewWalletKeyStore(w.path)
+	if err != nil {
+		return nil, err
+	}
+	oltAddress := keys.Address(address.Bytes())
+	if !store.KeyExists(oltAddress) {
+		return nil, ErrUnknownAccount
+	}
+	if !store.Open(oltAddress, passphrase) {
+		return nil, ErrInvalidPassphrase
+	}
+	defer store.Close()
+
+	account, err := store.GetAccount(oltAddress)
+	if err != nil {
+		return nil, err
+	}
+	if account.PrivateKey == nil || account.PrivateKey.Keytype != keys.ETHSECP {
+		return nil, ErrUnsupportedKeyType
+	}
+	return keys.ETHSECP256K1TOECDSA(account.PrivateKey.Data), nil
+}